@@ -0,0 +1,79 @@
+package application
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	app_interfaces "codecademy-yellowbelt2/infrastructure/interface/application"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"fmt"
+	"time"
+)
+
+type BackupUseCase struct {
+	backupRepo repository.IBackupRepository
+}
+
+func NewBackupUseCase(backupRepo repository.IBackupRepository) app_interfaces.IBackupUseCase {
+	return &BackupUseCase{backupRepo: backupRepo}
+}
+
+func (uc *BackupUseCase) CreateBackup(to string) (*entity.Backup, error) {
+	return uc.backupRepo.Create(entity.BackupManual, to)
+}
+
+func (uc *BackupUseCase) ListBackups() ([]*entity.Backup, error) {
+	return uc.backupRepo.List()
+}
+
+func (uc *BackupUseCase) VerifyBackup(ref string) (*entity.Backup, error) {
+	return uc.backupRepo.Verify(ref)
+}
+
+// RestoreBackup só mexe nos dados depois de verificar o backup e de guardar
+// os dados atuais em outro, para que a restauração possa ser desfeita
+func (uc *BackupUseCase) RestoreBackup(ref string) (*entity.Backup, *entity.Backup, error) {
+	if _, err := uc.backupRepo.Verify(ref); err != nil {
+		return nil, nil, err
+	}
+	previous, err := uc.backupRepo.Create(entity.BackupPreRestore, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not back up current data before restoring: %w", err)
+	}
+	restored, err := uc.backupRepo.Restore(ref)
+	if err != nil {
+		return nil, previous, err
+	}
+	return restored, previous, nil
+}
+
+// AutoBackup conta só os backups automáticos: os manuais e os de antes de
+// uma restauração nunca são apagados pela retenção
+func (uc *BackupUseCase) AutoBackup(interval time.Duration, keep int) (*entity.Backup, error) {
+	backups, err := uc.backupRepo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var autos []*entity.Backup // do mais novo ao mais antigo, como List devolve
+	for _, backup := range backups {
+		if backup.Trigger == entity.BackupAuto {
+			autos = append(autos, backup)
+		}
+	}
+	if len(autos) > 0 && time.Since(autos[0].CreatedAt) < interval {
+		return nil, nil
+	}
+
+	backup, err := uc.backupRepo.Create(entity.BackupAuto, "")
+	if err != nil {
+		return nil, err
+	}
+	autos = append([]*entity.Backup{backup}, autos...)
+	if keep > 0 && len(autos) > keep {
+		for _, old := range autos[keep:] {
+			if err := uc.backupRepo.Delete(old.Name); err != nil {
+				return backup, fmt.Errorf("could not remove old backup %s: %w", old.Name, err)
+			}
+		}
+	}
+	return backup, nil
+}
@@ -0,0 +1,110 @@
+package application
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	repoMock "codecademy-yellowbelt2/infrastructure/interface/repository"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupUseCase_RestoreBacksUpCurrentDataFirst(t *testing.T) {
+	// Arrange
+	repo := new(repoMock.MockBackupRepository)
+	useCase := NewBackupUseCase(repo)
+	backup := &entity.Backup{Name: "todo-backup-20240301-120000-manual.tar.gz"}
+	previous := &entity.Backup{Name: "todo-backup-20240305-090000-pre-restore.tar.gz"}
+	repo.On("Verify", backup.Name).Return(backup, nil)
+	repo.On("Create", entity.BackupPreRestore, "").Return(previous, nil)
+	repo.On("Restore", backup.Name).Return(backup, nil)
+
+	// Act
+	restored, safety, err := useCase.RestoreBackup(backup.Name)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, backup, restored)
+	assert.Equal(t, previous, safety)
+	repo.AssertExpectations(t)
+}
+
+func TestBackupUseCase_RestoreRefusesCorruptedBackup(t *testing.T) {
+	// Arrange
+	repo := new(repoMock.MockBackupRepository)
+	useCase := NewBackupUseCase(repo)
+	repo.On("Verify", "broken.tar.gz").Return(nil, entity.ErrBackupCorrupted)
+
+	// Act
+	_, _, err := useCase.RestoreBackup("broken.tar.gz")
+
+	// Assert
+	assert.ErrorIs(t, err, entity.ErrBackupCorrupted)
+	repo.AssertNotCalled(t, "Create", entity.BackupPreRestore, "")
+	repo.AssertNotCalled(t, "Restore", "broken.tar.gz")
+}
+
+func TestBackupUseCase_AutoBackupWaitsForTheInterval(t *testing.T) {
+	// Arrange
+	repo := new(repoMock.MockBackupRepository)
+	useCase := NewBackupUseCase(repo)
+	repo.On("List").Return([]*entity.Backup{
+		{Name: "manual", Trigger: entity.BackupManual, CreatedAt: time.Now().Add(-time.Minute)},
+		{Name: "auto", Trigger: entity.BackupAuto, CreatedAt: time.Now().Add(-2 * time.Hour)},
+	}, nil)
+
+	// Act
+	backup, err := useCase.AutoBackup(24*time.Hour, 7)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, backup)
+	repo.AssertNotCalled(t, "Create", entity.BackupAuto, "")
+}
+
+func TestBackupUseCase_AutoBackupPrunesOnlyOldAutomaticBackups(t *testing.T) {
+	// Arrange
+	repo := new(repoMock.MockBackupRepository)
+	useCase := NewBackupUseCase(repo)
+	day := 24 * time.Hour
+	repo.On("List").Return([]*entity.Backup{
+		{Name: "auto-2", Trigger: entity.BackupAuto, CreatedAt: time.Now().Add(-2 * day)},
+		{Name: "manual", Trigger: entity.BackupManual, CreatedAt: time.Now().Add(-3 * day)},
+		{Name: "auto-4", Trigger: entity.BackupAuto, CreatedAt: time.Now().Add(-4 * day)},
+		{Name: "pre-restore", Trigger: entity.BackupPreRestore, CreatedAt: time.Now().Add(-5 * day)},
+		{Name: "auto-6", Trigger: entity.BackupAuto, CreatedAt: time.Now().Add(-6 * day)},
+	}, nil)
+	created := &entity.Backup{Name: "auto-0", Trigger: entity.BackupAuto}
+	repo.On("Create", entity.BackupAuto, "").Return(created, nil)
+	repo.On("Delete", "auto-4").Return(nil)
+	repo.On("Delete", "auto-6").Return(nil)
+
+	// Act
+	backup, err := useCase.AutoBackup(day, 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, created, backup)
+	repo.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "Delete", 2)
+}
+
+func TestBackupUseCase_AutoBackupReportsPruneFailure(t *testing.T) {
+	// Arrange
+	repo := new(repoMock.MockBackupRepository)
+	useCase := NewBackupUseCase(repo)
+	repo.On("List").Return([]*entity.Backup{
+		{Name: "auto-old", Trigger: entity.BackupAuto, CreatedAt: time.Now().Add(-48 * time.Hour)},
+	}, nil)
+	created := &entity.Backup{Name: "auto-new", Trigger: entity.BackupAuto}
+	repo.On("Create", entity.BackupAuto, "").Return(created, nil)
+	repo.On("Delete", "auto-old").Return(errors.New("permission denied"))
+
+	// Act
+	backup, err := useCase.AutoBackup(24*time.Hour, 1)
+
+	// Assert
+	assert.Equal(t, created, backup, "Expected the new backup to be reported even when pruning fails")
+	assert.EqualError(t, err, "could not remove old backup auto-old: permission denied")
+}
@@ -0,0 +1,176 @@
+package application
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	app_interfaces "codecademy-yellowbelt2/infrastructure/interface/application"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ContextStore reúne os repositórios com os dados de um contexto
+type ContextStore struct {
+	Todos   repository.ITodoRepository
+	History repository.IHistoryRepository
+}
+
+// ContextStoreOpener abre os repositórios de um contexto pelo nome
+type ContextStoreOpener func(name string) (*ContextStore, error)
+
+type ContextUseCase struct {
+	contextRepo repository.IContextRepository
+	open        ContextStoreOpener
+	actor       string
+}
+
+type ContextOption func(*ContextUseCase)
+
+// WithMoveActor define quem aparece no histórico das tarefas movidas
+func WithMoveActor(actor string) ContextOption {
+	return func(uc *ContextUseCase) {
+		uc.actor = actor
+	}
+}
+
+func NewContextUseCase(contextRepo repository.IContextRepository, open ContextStoreOpener, opts ...ContextOption) app_interfaces.IContextUseCase {
+	uc := &ContextUseCase{
+		contextRepo: contextRepo,
+		open:        open,
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+func (uc *ContextUseCase) ListContexts() ([]string, error) {
+	return uc.contextRepo.List()
+}
+
+func (uc *ContextUseCase) CurrentContext() (string, error) {
+	return uc.contextRepo.Current()
+}
+
+func (uc *ContextUseCase) CreateContext(name string) error {
+	return uc.contextRepo.Create(name)
+}
+
+// DeleteContext apaga o contexto e suas tarefas; sem force, só apaga
+// contextos vazios (tarefas na lixeira também contam)
+func (uc *ContextUseCase) DeleteContext(name string, force bool) error {
+	if name == entity.DefaultContext {
+		return entity.ErrDefaultContext
+	}
+	if err := uc.ensureExists(name); err != nil {
+		return err
+	}
+	current, err := uc.contextRepo.Current()
+	if err != nil {
+		return err
+	}
+	if current == name {
+		return entity.ErrContextInUse
+	}
+
+	if !force {
+		store, err := uc.open(name)
+		if err != nil {
+			return err
+		}
+		todos, err := store.Todos.Find(repository.TodoQuery{IncludeDeleted: true})
+		if err != nil {
+			return err
+		}
+		if len(todos) > 0 {
+			return fmt.Errorf("%w: %d todos", entity.ErrContextNotEmpty, len(todos))
+		}
+	}
+
+	return uc.contextRepo.Delete(name)
+}
+
+func (uc *ContextUseCase) RenameContext(oldName, newName string) error {
+	if oldName == entity.DefaultContext {
+		return entity.ErrDefaultContext
+	}
+	return uc.contextRepo.Rename(oldName, newName)
+}
+
+func (uc *ContextUseCase) UseContext(name string) error {
+	return uc.contextRepo.SetCurrent(name)
+}
+
+// MoveTodo leva a tarefa do contexto atual para outro mantendo o ID e o
+// histórico; a tarefa é criada no destino antes de sair da origem
+func (uc *ContextUseCase) MoveTodo(id, to string) (*entity.Todo, error) {
+	from, err := uc.contextRepo.Current()
+	if err != nil {
+		return nil, err
+	}
+	if to == from {
+		return nil, entity.ErrSameContext
+	}
+	if err := uc.ensureExists(to); err != nil {
+		return nil, err
+	}
+
+	source, err := uc.open(from)
+	if err != nil {
+		return nil, err
+	}
+	target, err := uc.open(to)
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := source.Todos.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := target.Todos.GetByID(id); err == nil {
+		return nil, fmt.Errorf("todo %s already exists in context %q", id, to)
+	}
+
+	entries, err := source.History.GetByTodoID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	moved := todo.Clone()
+	if err := target.Todos.Create(moved); err != nil {
+		return nil, err
+	}
+	if err := source.Todos.Delete(id); err != nil {
+		if rollbackErr := target.Todos.Delete(id); rollbackErr != nil {
+			return nil, errors.Join(err, rollbackErr)
+		}
+		return nil, err
+	}
+
+	// O histórico continua na origem (como acontece com tarefas removidas) e é
+	// copiado para o destino, com o registro da mudança nos dois lados
+	record := entity.NewHistoryEntry(id, entity.HistoryActionMoved, uc.actor, []entity.FieldChange{
+		{Field: "context", OldValue: from, NewValue: to},
+	})
+	for _, entry := range append(entries, record) {
+		if err := target.History.Append(entry); err != nil {
+			return moved, err
+		}
+	}
+	if err := source.History.Append(record); err != nil {
+		return moved, err
+	}
+	return moved, nil
+}
+
+func (uc *ContextUseCase) ensureExists(name string) error {
+	names, err := uc.contextRepo.List()
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(names, name) {
+		return entity.ErrContextNotFound
+	}
+	return nil
+}
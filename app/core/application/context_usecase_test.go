@@ -0,0 +1,140 @@
+package application
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	repoInterfaces "codecademy-yellowbelt2/infrastructure/interface/repository"
+	"codecademy-yellowbelt2/infrastructure/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newContextFixture cria um caso de uso com um contexto "work" além do padrão
+func newContextFixture(t *testing.T) (*ContextUseCase, repoInterfaces.IContextRepository, map[string]*ContextStore) {
+	t.Helper()
+	contextRepo := repository.NewInMemoryContextRepository()
+	assert.NoError(t, contextRepo.Create("work"))
+
+	stores := map[string]*ContextStore{}
+	open := func(name string) (*ContextStore, error) {
+		if store, ok := stores[name]; ok {
+			return store, nil
+		}
+		stores[name] = &ContextStore{
+			Todos:   repository.NewInMemoryTodoRepository(),
+			History: repository.NewInMemoryHistoryRepository(),
+		}
+		return stores[name], nil
+	}
+	useCase := NewContextUseCase(contextRepo, open, WithMoveActor("ana")).(*ContextUseCase)
+	return useCase, contextRepo, stores
+}
+
+func TestContextUseCase_MoveTodoKeepsIDAndHistory(t *testing.T) {
+	// Arrange
+	useCase, _, stores := newContextFixture(t)
+	source, _ := useCase.open(entity.DefaultContext)
+	todo := entity.NewTodo("Relatório", "")
+	assert.NoError(t, source.Todos.Create(todo))
+	assert.NoError(t, source.History.Append(entity.NewHistoryEntry(todo.ID, entity.HistoryActionCreated, "ana", nil)))
+
+	// Act
+	moved, err := useCase.MoveTodo(todo.ID, "work")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, todo.ID, moved.ID)
+
+	_, err = source.Todos.GetByID(todo.ID)
+	assert.Error(t, err, "Expected the todo to leave the source context")
+	inTarget, err := stores["work"].Todos.GetByID(todo.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Relatório", inTarget.Title)
+
+	history, _ := stores["work"].History.GetByTodoID(todo.ID)
+	assert.Len(t, history, 2)
+	assert.Equal(t, entity.HistoryActionCreated, history[0].Action)
+	assert.Equal(t, entity.HistoryActionMoved, history[1].Action)
+	assert.Equal(t, "ana", history[1].Actor)
+	assert.Equal(t, []entity.FieldChange{{Field: "context", OldValue: entity.DefaultContext, NewValue: "work"}}, history[1].Changes)
+
+	sourceHistory, _ := source.History.GetByTodoID(todo.ID)
+	assert.Equal(t, entity.HistoryActionMoved, sourceHistory[len(sourceHistory)-1].Action)
+}
+
+func TestContextUseCase_MoveTodoRejectsSameOrUnknownContext(t *testing.T) {
+	// Arrange
+	useCase, _, _ := newContextFixture(t)
+
+	// Act
+	_, sameErr := useCase.MoveTodo("a1", entity.DefaultContext)
+	_, unknownErr := useCase.MoveTodo("a1", "casa")
+
+	// Assert
+	assert.ErrorIs(t, sameErr, entity.ErrSameContext)
+	assert.ErrorIs(t, unknownErr, entity.ErrContextNotFound)
+}
+
+func TestContextUseCase_MoveTodoUnknownTodo(t *testing.T) {
+	// Arrange
+	useCase, _, stores := newContextFixture(t)
+
+	// Act
+	_, err := useCase.MoveTodo("missing", "work")
+
+	// Assert
+	assert.Error(t, err)
+	todos, _ := stores["work"].Todos.Find(repoInterfaces.TodoQuery{IncludeDeleted: true})
+	assert.Empty(t, todos)
+}
+
+func TestContextUseCase_DeleteContextRequiresForceWhenNotEmpty(t *testing.T) {
+	// Arrange
+	useCase, contextRepo, _ := newContextFixture(t)
+	target, _ := useCase.open("work")
+	assert.NoError(t, target.Todos.Create(entity.NewTodo("Reunião", "")))
+
+	// Act
+	err := useCase.DeleteContext("work", false)
+	names, _ := contextRepo.List()
+	forcedErr := useCase.DeleteContext("work", true)
+	afterForce, _ := contextRepo.List()
+
+	// Assert
+	assert.ErrorIs(t, err, entity.ErrContextNotEmpty)
+	assert.Contains(t, names, "work")
+	assert.NoError(t, forcedErr)
+	assert.Equal(t, []string{entity.DefaultContext}, afterForce)
+}
+
+func TestContextUseCase_DeleteContextRejectsDefaultAndCurrent(t *testing.T) {
+	// Arrange
+	useCase, _, _ := newContextFixture(t)
+	assert.NoError(t, useCase.UseContext("work"))
+
+	// Act
+	defaultErr := useCase.DeleteContext(entity.DefaultContext, true)
+	currentErr := useCase.DeleteContext("work", true)
+	unknownErr := useCase.DeleteContext("casa", true)
+
+	// Assert
+	assert.ErrorIs(t, defaultErr, entity.ErrDefaultContext)
+	assert.ErrorIs(t, currentErr, entity.ErrContextInUse)
+	assert.ErrorIs(t, unknownErr, entity.ErrContextNotFound)
+}
+
+func TestContextUseCase_RenameContext(t *testing.T) {
+	// Arrange
+	useCase, _, _ := newContextFixture(t)
+	assert.NoError(t, useCase.UseContext("work"))
+
+	// Act
+	err := useCase.RenameContext("work", "trabalho")
+	defaultErr := useCase.RenameContext(entity.DefaultContext, "outro")
+	current, _ := useCase.CurrentContext()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.ErrorIs(t, defaultErr, entity.ErrDefaultContext)
+	assert.Equal(t, "trabalho", current)
+}
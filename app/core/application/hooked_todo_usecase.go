@@ -0,0 +1,392 @@
+package application
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	app_interfaces "codecademy-yellowbelt2/infrastructure/interface/application"
+	hook_interfaces "codecademy-yellowbelt2/infrastructure/interface/hook"
+	"log"
+)
+
+// HookedTodoUseCase decora um ITodoUseCase executando hooks antes e depois
+// de criar, atualizar, concluir e remover tarefas. Os hooks "pre-" podem
+// vetar a operação e, em create/update, alterar título e descrição; falhas
+// dos hooks "post-" são apenas registradas.
+//
+// Importar cria ou atualiza tarefas e roda os mesmos hooks; atribuir,
+// remover responsáveis e reabrir rodam pre-update e post-update. Restaurar da
+// lixeira, esvaziá-la, arquivar, desarquivar, desfazer e refazer não rodam
+// hooks: apenas movem tarefas ou revertem operações que já passaram por eles
+type HookedTodoUseCase struct {
+	app_interfaces.ITodoUseCase
+	runner   hook_interfaces.IHookRunner
+	disabled bool
+	onError  func(hook string, err error)
+}
+
+var _ app_interfaces.IHookToggle = (*HookedTodoUseCase)(nil)
+
+type HookOption func(*HookedTodoUseCase)
+
+// WithHookErrorHandler substitui o log padrão das falhas dos hooks "post-"
+func WithHookErrorHandler(handler func(hook string, err error)) HookOption {
+	return func(uc *HookedTodoUseCase) {
+		uc.onError = handler
+	}
+}
+
+func NewHookedTodoUseCase(inner app_interfaces.ITodoUseCase, runner hook_interfaces.IHookRunner, opts ...HookOption) app_interfaces.ITodoUseCase {
+	uc := &HookedTodoUseCase{
+		ITodoUseCase: inner,
+		runner:       runner,
+		onError: func(hook string, err error) {
+			log.Printf("hook %s failed: %v", hook, err)
+		},
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// DisableHooks faz as próximas operações irem direto ao use case decorado
+func (uc *HookedTodoUseCase) DisableHooks() {
+	uc.disabled = true
+}
+
+// EnableHooks volta a executar os hooks depois de um DisableHooks
+func (uc *HookedTodoUseCase) EnableHooks() {
+	uc.disabled = false
+}
+
+func (uc *HookedTodoUseCase) CreateTodo(title, description string) (*entity.Todo, error) {
+	title, description, err := uc.runPreEdit(hook_interfaces.PreCreate, &entity.Todo{Title: title, Description: description})
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := uc.ITodoUseCase.CreateTodo(title, description)
+	if err != nil {
+		return nil, err
+	}
+	uc.runPost(hook_interfaces.PostCreate, todo)
+	return todo, nil
+}
+
+func (uc *HookedTodoUseCase) UpdateTodo(id, title, description string) (*entity.Todo, error) {
+	title, description, err := uc.runPreUpdate(id, title, description)
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := uc.ITodoUseCase.UpdateTodo(id, title, description)
+	if err != nil {
+		return nil, err
+	}
+	uc.runPost(hook_interfaces.PostUpdate, todo)
+	return todo, nil
+}
+
+func (uc *HookedTodoUseCase) UpdateTodoIfVersion(id string, version int, title, description string) (*entity.Todo, error) {
+	title, description, err := uc.runPreUpdate(id, title, description)
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := uc.ITodoUseCase.UpdateTodoIfVersion(id, version, title, description)
+	if err != nil {
+		return nil, err
+	}
+	uc.runPost(hook_interfaces.PostUpdate, todo)
+	return todo, nil
+}
+
+func (uc *HookedTodoUseCase) CompleteTodo(id string) (*entity.Todo, error) {
+	if _, err := uc.runPre(hook_interfaces.PreComplete, id); err != nil {
+		return nil, err
+	}
+
+	todo, err := uc.ITodoUseCase.CompleteTodo(id)
+	if err != nil {
+		return nil, err
+	}
+	uc.runPost(hook_interfaces.PostComplete, todo)
+	return todo, nil
+}
+
+func (uc *HookedTodoUseCase) DeleteTodo(id string) error {
+	todo, err := uc.runPre(hook_interfaces.PreDelete, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.ITodoUseCase.DeleteTodo(id); err != nil {
+		return err
+	}
+	if todo != nil {
+		uc.runPost(hook_interfaces.PostDelete, uc.deletedTodo(todo))
+	}
+	return nil
+}
+
+// deletedTodo devolve a tarefa como ficou na lixeira, com DeletedAt preenchido
+func (uc *HookedTodoUseCase) deletedTodo(todo *entity.Todo) *entity.Todo {
+	deleted, err := uc.ITodoUseCase.GetDeletedTodos()
+	if err == nil {
+		for _, candidate := range deleted {
+			if candidate.ID == todo.ID {
+				return candidate
+			}
+		}
+	}
+	todo = todo.Clone()
+	todo.MarkAsDeleted()
+	return todo
+}
+
+func (uc *HookedTodoUseCase) ReopenTodo(id string) (*entity.Todo, error) {
+	if err := uc.runPreChange(id, (*entity.Todo).MarkAsIncomplete); err != nil {
+		return nil, err
+	}
+
+	todo, err := uc.ITodoUseCase.ReopenTodo(id)
+	if err != nil {
+		return nil, err
+	}
+	uc.runPost(hook_interfaces.PostUpdate, todo)
+	return todo, nil
+}
+
+func (uc *HookedTodoUseCase) AssignTodo(id string, assignees ...string) (*entity.Todo, error) {
+	err := uc.runPreChange(id, func(todo *entity.Todo) {
+		for _, name := range assignees {
+			todo.Assign(name)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := uc.ITodoUseCase.AssignTodo(id, assignees...)
+	if err != nil {
+		return nil, err
+	}
+	uc.runPost(hook_interfaces.PostUpdate, todo)
+	return todo, nil
+}
+
+func (uc *HookedTodoUseCase) UnassignTodo(id string, assignees ...string) (*entity.Todo, error) {
+	err := uc.runPreChange(id, func(todo *entity.Todo) {
+		for _, name := range assignees {
+			todo.Unassign(name)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := uc.ITodoUseCase.UnassignTodo(id, assignees...)
+	if err != nil {
+		return nil, err
+	}
+	uc.runPost(hook_interfaces.PostUpdate, todo)
+	return todo, nil
+}
+
+// ImportTodos executa pre-create ou pre-update conforme o que a importação
+// faria com cada tarefa: as vetadas ficam de fora com o erro do hook e as
+// demais podem ter título e descrição alterados por ele
+func (uc *HookedTodoUseCase) ImportTodos(batch app_interfaces.Import) ([]app_interfaces.ImportResult, error) {
+	if uc.disabled || batch.DryRun {
+		return uc.ITodoUseCase.ImportTodos(batch)
+	}
+
+	plan := batch
+	plan.DryRun = true
+	planned, err := uc.ITodoUseCase.ImportTodos(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	rejected := make(map[int]error)
+	inner := batch
+	inner.Todos = make([]*entity.Todo, 0, len(batch.Todos))
+	for i, result := range planned {
+		imported := batch.Todos[i]
+		if preHook, _, hooked := importHooks(result.Action); result.Err == nil && hooked {
+			title, description, err := uc.runPreEdit(preHook, result.Todo)
+			if err != nil {
+				rejected[i] = err
+				continue
+			}
+			imported = imported.Clone()
+			imported.Title, imported.Description = title, description
+		}
+		inner.Todos = append(inner.Todos, imported)
+	}
+
+	results, err := uc.ITodoUseCase.ImportTodos(inner)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mantém a ordem do arquivo, intercalando as tarefas vetadas
+	merged := make([]app_interfaces.ImportResult, 0, len(batch.Todos))
+	for i := range batch.Todos {
+		if err, vetoed := rejected[i]; vetoed {
+			merged = append(merged, app_interfaces.ImportResult{Err: err})
+			continue
+		}
+		result := results[0]
+		results = results[1:]
+		if _, postHook, hooked := importHooks(result.Action); result.Err == nil && hooked {
+			uc.runPost(postHook, result.Todo)
+		}
+		merged = append(merged, result)
+	}
+	return merged, nil
+}
+
+// RunBatch executa o hook "pre-" de cada tarefa antes do lote: as vetadas
+// ficam de fora com o erro do hook. Como o lote aplica o mesmo título e
+// descrição a todas, alterações devolvidas por pre-update são ignoradas
+func (uc *HookedTodoUseCase) RunBatch(batch app_interfaces.Batch) ([]app_interfaces.BatchResult, error) {
+	preHook, postHook, hooked := batchHooks(batch.Action)
+	if uc.disabled || batch.DryRun || !hooked {
+		return uc.ITodoUseCase.RunBatch(batch)
+	}
+
+	rejected := make(map[string]error)
+	var allowed []string
+	for _, id := range uniqueIDs(batch.IDs) {
+		todo, err := uc.ITodoUseCase.GetTodoByID(id)
+		if err == nil {
+			if batch.Action == entity.OperationUpdate {
+				todo.Update(batch.Title, batch.Description)
+			}
+			_, err = uc.runner.Run(preHook, todo)
+		}
+		if err != nil {
+			rejected[id] = err
+			continue
+		}
+		allowed = append(allowed, id)
+	}
+
+	inner := batch
+	inner.IDs = allowed
+	results, err := uc.ITodoUseCase.RunBatch(inner)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mantém a ordem original dos IDs, intercalando as tarefas vetadas
+	byID := make(map[string]app_interfaces.BatchResult, len(results))
+	for _, result := range results {
+		byID[result.ID] = result
+		if result.Err == nil {
+			todo := result.Todo
+			if batch.Action == entity.OperationDelete {
+				todo = uc.deletedTodo(todo)
+			}
+			uc.runPost(postHook, todo)
+		}
+	}
+	merged := make([]app_interfaces.BatchResult, 0, len(batch.IDs))
+	for _, id := range uniqueIDs(batch.IDs) {
+		if err, vetoed := rejected[id]; vetoed {
+			merged = append(merged, app_interfaces.BatchResult{ID: id, Err: err})
+			continue
+		}
+		merged = append(merged, byID[id])
+	}
+	return merged, nil
+}
+
+// runPre executa o hook com o estado atual da tarefa e devolve esse estado
+func (uc *HookedTodoUseCase) runPre(hook, id string) (*entity.Todo, error) {
+	if uc.disabled {
+		return nil, nil
+	}
+	todo, err := uc.ITodoUseCase.GetTodoByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.runner.Run(hook, todo); err != nil {
+		return nil, err
+	}
+	return todo, nil
+}
+
+// runPreChange executa pre-update com a tarefa como ficaria depois de change;
+// como no lote, título e descrição devolvidos pelo hook são ignorados
+func (uc *HookedTodoUseCase) runPreChange(id string, change func(todo *entity.Todo)) error {
+	if uc.disabled {
+		return nil
+	}
+	todo, err := uc.ITodoUseCase.GetTodoByID(id)
+	if err != nil {
+		return err
+	}
+	change(todo)
+	_, err = uc.runner.Run(hook_interfaces.PreUpdate, todo)
+	return err
+}
+
+func (uc *HookedTodoUseCase) runPreUpdate(id, title, description string) (string, string, error) {
+	if uc.disabled {
+		return title, description, nil
+	}
+	todo, err := uc.ITodoUseCase.GetTodoByID(id)
+	if err != nil {
+		return "", "", err
+	}
+	todo.Update(title, description)
+	return uc.runPreEdit(hook_interfaces.PreUpdate, todo)
+}
+
+// runPreEdit executa o hook com a tarefa proposta; apenas título e descrição
+// devolvidos pelo hook são aproveitados
+func (uc *HookedTodoUseCase) runPreEdit(hook string, proposed *entity.Todo) (string, string, error) {
+	if uc.disabled {
+		return proposed.Title, proposed.Description, nil
+	}
+	modified, err := uc.runner.Run(hook, proposed)
+	if err != nil {
+		return "", "", err
+	}
+	if modified == nil {
+		return proposed.Title, proposed.Description, nil
+	}
+	return modified.Title, modified.Description, nil
+}
+
+func (uc *HookedTodoUseCase) runPost(hook string, todo *entity.Todo) {
+	if uc.disabled || todo == nil {
+		return
+	}
+	if _, err := uc.runner.Run(hook, todo); err != nil {
+		uc.onError(hook, err)
+	}
+}
+
+func importHooks(action string) (string, string, bool) {
+	switch action {
+	case app_interfaces.ImportCreated:
+		return hook_interfaces.PreCreate, hook_interfaces.PostCreate, true
+	case app_interfaces.ImportUpdated:
+		return hook_interfaces.PreUpdate, hook_interfaces.PostUpdate, true
+	}
+	return "", "", false
+}
+
+func batchHooks(action string) (string, string, bool) {
+	switch action {
+	case entity.OperationComplete:
+		return hook_interfaces.PreComplete, hook_interfaces.PostComplete, true
+	case entity.OperationUpdate:
+		return hook_interfaces.PreUpdate, hook_interfaces.PostUpdate, true
+	case entity.OperationDelete:
+		return hook_interfaces.PreDelete, hook_interfaces.PostDelete, true
+	}
+	return "", "", false
+}
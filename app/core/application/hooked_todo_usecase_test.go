@@ -0,0 +1,248 @@
+package application
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	app_interfaces "codecademy-yellowbelt2/infrastructure/interface/application"
+	hookMock "codecademy-yellowbelt2/infrastructure/interface/hook"
+	"codecademy-yellowbelt2/infrastructure/repository"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newHookedUseCase(runner *hookMock.MockHookRunner, opts ...HookOption) app_interfaces.ITodoUseCase {
+	return NewHookedTodoUseCase(NewTodoUseCase(repository.NewInMemoryTodoRepository()), runner, opts...)
+}
+
+func TestHookedTodoUseCase_PreCreateCanModifyTodo(t *testing.T) {
+	// Arrange
+	runner := new(hookMock.MockHookRunner)
+	useCase := newHookedUseCase(runner)
+	runner.On("Run", hookMock.PreCreate, &entity.Todo{Title: "draft", Description: "desc"}).
+		Return(&entity.Todo{Title: "Draft", Description: "desc #inbox"}, nil)
+	runner.On("Run", hookMock.PostCreate, mock.Anything).Return(nil, nil)
+
+	// Act
+	todo, err := useCase.CreateTodo("draft", "desc")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Draft", todo.Title)
+	assert.Equal(t, "desc #inbox", todo.Description)
+	runner.AssertCalled(t, "Run", hookMock.PostCreate, mock.MatchedBy(func(todo *entity.Todo) bool { return todo.ID != "" }))
+}
+
+func TestHookedTodoUseCase_PreHookVetoesOperation(t *testing.T) {
+	// Arrange
+	runner := new(hookMock.MockHookRunner)
+	runner.On("Run", hookMock.PreCreate, mock.Anything).Return(nil, nil).Once()
+	runner.On("Run", hookMock.PostCreate, mock.Anything).Return(nil, nil)
+	rejected := &hookMock.HookRejectedError{Hook: hookMock.PreComplete, Message: "tests are red"}
+	runner.On("Run", hookMock.PreComplete, mock.Anything).Return(nil, rejected)
+	useCase := newHookedUseCase(runner)
+	todo, _ := useCase.CreateTodo("Ship", "")
+
+	// Act
+	_, err := useCase.CompleteTodo(todo.ID)
+	stored, _ := useCase.GetTodoByID(todo.ID)
+
+	// Assert
+	assert.ErrorIs(t, err, rejected)
+	assert.False(t, stored.Completed, "Expected vetoed todo to stay pending")
+	runner.AssertNotCalled(t, "Run", hookMock.PostComplete, mock.Anything)
+}
+
+func TestHookedTodoUseCase_PreUpdateReceivesProposedChange(t *testing.T) {
+	// Arrange
+	runner := new(hookMock.MockHookRunner)
+	useCase := newHookedUseCase(runner)
+	runner.On("Run", hookMock.PreCreate, mock.Anything).Return(nil, nil)
+	runner.On("Run", hookMock.PostCreate, mock.Anything).Return(nil, nil)
+	todo, _ := useCase.CreateTodo("Old", "Same")
+	runner.On("Run", hookMock.PreUpdate, mock.MatchedBy(func(proposed *entity.Todo) bool {
+		return proposed.ID == todo.ID && proposed.Title == "New" && proposed.Description == "Same"
+	})).Return(nil, nil)
+	runner.On("Run", hookMock.PostUpdate, mock.Anything).Return(nil, nil)
+
+	// Act
+	updated, err := useCase.UpdateTodoIfVersion(todo.ID, todo.Version, "New", "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "New", updated.Title)
+	runner.AssertExpectations(t)
+}
+
+func TestHookedTodoUseCase_PostHookFailureIsOnlyReported(t *testing.T) {
+	// Arrange
+	runner := new(hookMock.MockHookRunner)
+	var reported []string
+	useCase := newHookedUseCase(runner, WithHookErrorHandler(func(hook string, err error) {
+		reported = append(reported, hook+": "+err.Error())
+	}))
+	runner.On("Run", hookMock.PreCreate, mock.Anything).Return(nil, nil)
+	runner.On("Run", hookMock.PostCreate, mock.Anything).Return(nil, nil)
+	todo, _ := useCase.CreateTodo("Notify", "")
+	runner.On("Run", hookMock.PreDelete, mock.Anything).Return(nil, nil)
+	runner.On("Run", hookMock.PostDelete, mock.Anything).Return(nil, errors.New("chat is down"))
+
+	// Act
+	err := useCase.DeleteTodo(todo.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"post-delete: chat is down"}, reported)
+}
+
+func TestHookedTodoUseCase_DisableHooksSkipsRunner(t *testing.T) {
+	// Arrange
+	runner := new(hookMock.MockHookRunner)
+	useCase := newHookedUseCase(runner)
+	useCase.(app_interfaces.IHookToggle).DisableHooks()
+
+	// Act
+	todo, createErr := useCase.CreateTodo("Quiet", "")
+	_, completeErr := useCase.CompleteTodo(todo.ID)
+
+	// Assert
+	assert.NoError(t, createErr)
+	assert.NoError(t, completeErr)
+	runner.AssertNotCalled(t, "Run", mock.Anything, mock.Anything)
+}
+
+func TestHookedTodoUseCase_EnableHooksRestoresRunner(t *testing.T) {
+	// Arrange
+	runner := new(hookMock.MockHookRunner)
+	useCase := newHookedUseCase(runner)
+	runner.On("Run", hookMock.PreCreate, mock.Anything).Return(nil, nil)
+	runner.On("Run", hookMock.PostCreate, mock.Anything).Return(nil, nil)
+	toggle := useCase.(app_interfaces.IHookToggle)
+	toggle.DisableHooks()
+	useCase.CreateTodo("Quiet", "")
+
+	// Act
+	toggle.EnableHooks()
+	_, err := useCase.CreateTodo("Loud", "")
+
+	// Assert
+	assert.NoError(t, err)
+	runner.AssertNumberOfCalls(t, "Run", 2)
+}
+
+func TestHookedTodoUseCase_RunBatchSkipsVetoedTodos(t *testing.T) {
+	// Arrange
+	runner := new(hookMock.MockHookRunner)
+	useCase := newHookedUseCase(runner)
+	runner.On("Run", hookMock.PreCreate, mock.Anything).Return(nil, nil)
+	runner.On("Run", hookMock.PostCreate, mock.Anything).Return(nil, nil)
+	first, _ := useCase.CreateTodo("First", "")
+	blocked, _ := useCase.CreateTodo("Blocked", "")
+	veto := &hookMock.HookRejectedError{Hook: hookMock.PreComplete, Message: "blocked"}
+	runner.On("Run", hookMock.PreComplete, mock.MatchedBy(func(todo *entity.Todo) bool { return todo.ID == blocked.ID })).Return(nil, veto)
+	runner.On("Run", hookMock.PreComplete, mock.Anything).Return(nil, nil)
+	runner.On("Run", hookMock.PostComplete, mock.Anything).Return(nil, nil)
+
+	// Act
+	results, err := useCase.RunBatch(app_interfaces.Batch{Action: entity.OperationComplete, IDs: []string{blocked.ID, first.ID, "missing"}})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, blocked.ID, results[0].ID)
+	assert.ErrorIs(t, results[0].Err, veto)
+	assert.NoError(t, results[1].Err)
+	assert.True(t, results[1].Todo.Completed)
+	assert.Error(t, results[2].Err)
+	runner.AssertNumberOfCalls(t, "Run", 4+2+1) // create, pre-complete das existentes, post-complete
+}
+
+func TestHookedTodoUseCase_ImportSkipsTodosVetoedByPreCreate(t *testing.T) {
+	// Arrange
+	runner := new(hookMock.MockHookRunner)
+	useCase := newHookedUseCase(runner)
+	veto := &hookMock.HookRejectedError{Hook: hookMock.PreCreate, Message: "no secrets"}
+	runner.On("Run", hookMock.PreCreate, mock.MatchedBy(func(todo *entity.Todo) bool { return todo.Title == "Secret" })).Return(nil, veto)
+	runner.On("Run", hookMock.PreCreate, mock.Anything).Return(&entity.Todo{Title: "Public!", Description: "ok"}, nil)
+	runner.On("Run", hookMock.PostCreate, mock.Anything).Return(nil, nil)
+	batch := app_interfaces.Import{Todos: []*entity.Todo{{Title: "Secret"}, {Title: "Public"}}}
+
+	// Act
+	results, err := useCase.ImportTodos(batch)
+
+	// Assert
+	if !assert.NoError(t, err) || !assert.Len(t, results, 2) {
+		return
+	}
+	assert.ErrorIs(t, results[0].Err, veto)
+	assert.NoError(t, results[1].Err)
+	assert.Equal(t, app_interfaces.ImportCreated, results[1].Action)
+	assert.Equal(t, "Public!", results[1].Todo.Title)
+	todos, _ := useCase.GetAllTodos()
+	assert.Len(t, todos, 1)
+	runner.AssertNumberOfCalls(t, "Run", 3) // pre-create das duas, post-create da importada
+}
+
+func TestHookedTodoUseCase_AssignRunsUpdateHooks(t *testing.T) {
+	// Arrange
+	runner := new(hookMock.MockHookRunner)
+	useCase := newHookedUseCase(runner)
+	runner.On("Run", hookMock.PreCreate, mock.Anything).Return(nil, nil)
+	runner.On("Run", hookMock.PostCreate, mock.Anything).Return(nil, nil)
+	todo, _ := useCase.CreateTodo("Review", "")
+	runner.On("Run", hookMock.PreUpdate, mock.MatchedBy(func(proposed *entity.Todo) bool {
+		return assert.ObjectsAreEqual([]string{"ana"}, proposed.Assignees)
+	})).Return(nil, nil)
+	runner.On("Run", hookMock.PostUpdate, mock.Anything).Return(nil, nil)
+
+	// Act
+	assigned, err := useCase.AssignTodo(todo.ID, "ana")
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"ana"}, assigned.Assignees)
+	runner.AssertCalled(t, "Run", hookMock.PreUpdate, mock.Anything)
+	runner.AssertCalled(t, "Run", hookMock.PostUpdate, mock.Anything)
+}
+
+func TestHookedTodoUseCase_PostDeleteReceivesDeletedTodo(t *testing.T) {
+	// Arrange
+	runner := new(hookMock.MockHookRunner)
+	useCase := newHookedUseCase(runner)
+	runner.On("Run", hookMock.PreCreate, mock.Anything).Return(nil, nil)
+	runner.On("Run", hookMock.PostCreate, mock.Anything).Return(nil, nil)
+	todo, _ := useCase.CreateTodo("Old", "")
+	runner.On("Run", hookMock.PreDelete, mock.Anything).Return(nil, nil)
+	runner.On("Run", hookMock.PostDelete, mock.Anything).Return(nil, nil)
+
+	// Act
+	err := useCase.DeleteTodo(todo.ID)
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	runner.AssertCalled(t, "Run", hookMock.PostDelete, mock.MatchedBy(func(deleted *entity.Todo) bool {
+		return deleted.ID == todo.ID && deleted.DeletedAt != nil
+	}))
+}
+
+func TestHookedTodoUseCase_UndoDoesNotRunHooks(t *testing.T) {
+	// Arrange
+	runner := new(hookMock.MockHookRunner)
+	inner := NewTodoUseCase(repository.NewInMemoryTodoRepository(), WithJournal(repository.NewInMemoryJournalRepository(), 10))
+	useCase := NewHookedTodoUseCase(inner, runner)
+	runner.On("Run", hookMock.PreCreate, mock.Anything).Return(nil, nil)
+	runner.On("Run", hookMock.PostCreate, mock.Anything).Return(nil, nil)
+	useCase.CreateTodo("Undone", "")
+
+	// Act
+	_, err := useCase.Undo()
+
+	// Assert
+	assert.NoError(t, err)
+	runner.AssertNumberOfCalls(t, "Run", 2)
+}
@@ -3,17 +3,91 @@ package application
 import (
 	"codecademy-yellowbelt2/core/domain/entity"
 	app_interfaces "codecademy-yellowbelt2/infrastructure/interface/application"
+	event_interfaces "codecademy-yellowbelt2/infrastructure/interface/event"
 	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const DefaultJournalSize = 50
+
+var (
+	ErrHistoryDisabled = errors.New("todo history is not enabled")
+	ErrJournalDisabled = errors.New("undo journal is not enabled")
+	ErrNothingToUndo   = errors.New("nothing to undo")
+	ErrNothingToRedo   = errors.New("nothing to redo")
+	ErrJournalConflict = errors.New("todo was changed after this operation")
+	ErrArchiveDisabled = errors.New("todo archive is not enabled")
+	ErrUnknownAction   = errors.New("unknown batch action")
+	ErrNotCompactable  = errors.New("todo store does not support compaction")
+	ErrNotWatchable    = errors.New("todo store does not support watching")
 )
 
 type TodoUseCase struct {
-	todoRepo repository.ITodoRepository
+	todoRepo    repository.ITodoRepository
+	historyRepo repository.IHistoryRepository
+	journalRepo repository.IJournalRepository
+	archiveRepo repository.ITodoRepository
+	publisher   event_interfaces.IEventPublisher
+	journalSize int
+	actor       string
+
+	// pending acumula histórico, diário e eventos enquanto uma transação está
+	// aberta, para que só sejam gravados e publicados se ela for confirmada
+	pending *[]func(uc *TodoUseCase) error
+}
+
+type Option func(*TodoUseCase)
+
+// WithHistoryRepository habilita o registro do histórico de alterações
+func WithHistoryRepository(historyRepo repository.IHistoryRepository) Option {
+	return func(uc *TodoUseCase) {
+		uc.historyRepo = historyRepo
+	}
+}
+
+// WithJournal habilita desfazer/refazer guardando no máximo size operações
+func WithJournal(journalRepo repository.IJournalRepository, size int) Option {
+	return func(uc *TodoUseCase) {
+		uc.journalRepo = journalRepo
+		uc.journalSize = size
+	}
 }
 
-func NewTodoUseCase(todoRepo repository.ITodoRepository) app_interfaces.ITodoUseCase {
-	return &TodoUseCase{
-		todoRepo: todoRepo,
+// WithArchiveRepository define o armazenamento separado das tarefas arquivadas
+func WithArchiveRepository(archiveRepo repository.ITodoRepository) Option {
+	return func(uc *TodoUseCase) {
+		uc.archiveRepo = archiveRepo
+	}
+}
+
+// WithEventPublisher publica um evento de domínio a cada alteração gravada
+func WithEventPublisher(publisher event_interfaces.IEventPublisher) Option {
+	return func(uc *TodoUseCase) {
+		uc.publisher = publisher
+	}
+}
+
+// WithActor define quem é registrado como autor das alterações
+func WithActor(actor string) Option {
+	return func(uc *TodoUseCase) {
+		uc.actor = actor
+	}
+}
+
+func NewTodoUseCase(todoRepo repository.ITodoRepository, opts ...Option) app_interfaces.ITodoUseCase {
+	uc := &TodoUseCase{
+		todoRepo:    todoRepo,
+		journalSize: DefaultJournalSize,
+	}
+	for _, opt := range opts {
+		opt(uc)
 	}
+	return uc
 }
 
 func (uc *TodoUseCase) CreateTodo(title, description string) (*entity.Todo, error) {
@@ -22,11 +96,22 @@ func (uc *TodoUseCase) CreateTodo(title, description string) (*entity.Todo, erro
 	if err != nil {
 		return nil, err
 	}
+
+	if err := uc.recordChange(entity.OperationCreate, nil, todo); err != nil {
+		return nil, err
+	}
 	return todo, nil
 }
 
+// GetTodoByID busca a tarefa nas tarefas ativas e, se não encontrar, no arquivo
 func (uc *TodoUseCase) GetTodoByID(id string) (*entity.Todo, error) {
-	return uc.todoRepo.GetByID(id)
+	todo, err := uc.todoRepo.GetByID(id)
+	if err != nil && uc.archiveRepo != nil {
+		if archived, archiveErr := uc.archiveRepo.GetByID(id); archiveErr == nil {
+			return archived, nil
+		}
+	}
+	return todo, err
 }
 
 func (uc *TodoUseCase) GetAllTodos() ([]*entity.Todo, error) {
@@ -39,12 +124,33 @@ func (uc *TodoUseCase) UpdateTodo(id, title, description string) (*entity.Todo,
 		return nil, err
 	}
 
-	todo.Update(title, description)
-	err = uc.todoRepo.Update(todo)
+	return uc.updateTodo(todo, title, description)
+}
+
+// UpdateTodoIfVersion só altera a tarefa se ela ainda estiver na versão lida
+// pelo cliente, que pode então recarregar e tentar de novo ou mesclar
+func (uc *TodoUseCase) UpdateTodoIfVersion(id string, version int, title, description string) (*entity.Todo, error) {
+	todo, err := uc.todoRepo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
+	if todo.Version != version {
+		return nil, &entity.VersionConflictError{ID: id, Expected: version, Current: todo.Version}
+	}
 
+	return uc.updateTodo(todo, title, description)
+}
+
+func (uc *TodoUseCase) updateTodo(todo *entity.Todo, title, description string) (*entity.Todo, error) {
+	before := todo.Clone()
+	todo.Update(title, description)
+	if err := uc.todoRepo.Update(todo); err != nil {
+		return nil, err
+	}
+
+	if err := uc.recordChange(entity.OperationUpdate, before, todo); err != nil {
+		return nil, err
+	}
 	return todo, nil
 }
 
@@ -54,15 +160,845 @@ func (uc *TodoUseCase) CompleteTodo(id string) (*entity.Todo, error) {
 		return nil, err
 	}
 
+	before := todo.Clone()
 	todo.MarkAsCompleted()
 	err = uc.todoRepo.Update(todo)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := uc.recordChange(entity.OperationComplete, before, todo); err != nil {
+		return nil, err
+	}
+	return todo, nil
+}
+
+// ReopenTodo volta uma tarefa concluída para pendente
+func (uc *TodoUseCase) ReopenTodo(id string) (*entity.Todo, error) {
+	todo, err := uc.todoRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := todo.Clone()
+	todo.MarkAsIncomplete()
+	if err := uc.todoRepo.Update(todo); err != nil {
+		return nil, err
+	}
+
+	if err := uc.recordChange(entity.OperationReopen, before, todo); err != nil {
+		return nil, err
+	}
 	return todo, nil
 }
 
+// DeleteTodo move a tarefa para a lixeira; ela só é removida de fato por PurgeTodos
 func (uc *TodoUseCase) DeleteTodo(id string) error {
-	return uc.todoRepo.Delete(id)
+	todo, err := uc.todoRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	before := todo.Clone()
+	todo.MarkAsDeleted()
+	if err := uc.todoRepo.Update(todo); err != nil {
+		return err
+	}
+
+	return uc.recordChange(entity.OperationDelete, before, todo)
+}
+
+func (uc *TodoUseCase) GetDeletedTodos() ([]*entity.Todo, error) {
+	return uc.todoRepo.Find(repository.TodoQuery{OnlyDeleted: true})
+}
+
+func (uc *TodoUseCase) RestoreTodo(id string) (*entity.Todo, error) {
+	todo, err := uc.findDeletedByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := todo.Clone()
+	todo.Restore()
+	err = uc.todoRepo.Update(todo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.recordChange(entity.OperationRestore, before, todo); err != nil {
+		return nil, err
+	}
+	return todo, nil
+}
+
+// PurgeTodos remove definitivamente as tarefas que estão na lixeira há mais
+// de olderThan; zero esvazia a lixeira inteira
+func (uc *TodoUseCase) PurgeTodos(olderThan time.Duration) ([]*entity.Todo, error) {
+	var purged []*entity.Todo
+	err := uc.inTransaction(func(txUseCase *TodoUseCase) error {
+		deleted, err := txUseCase.GetDeletedTodos()
+		if err != nil {
+			return err
+		}
+
+		cutoff := time.Now().Add(-olderThan)
+		purged = make([]*entity.Todo, 0, len(deleted))
+		for _, todo := range deleted {
+			if olderThan > 0 && todo.DeletedAt.After(cutoff) {
+				continue
+			}
+
+			if err := txUseCase.todoRepo.Delete(todo.ID); err != nil {
+				return err
+			}
+			purged = append(purged, todo)
+
+			if err := txUseCase.recordChange(entity.OperationPurge, todo.Clone(), nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return purged, nil
+}
+
+func (uc *TodoUseCase) AssignTodo(id string, assignees ...string) (*entity.Todo, error) {
+	names, err := normalizeAssignees(assignees)
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := uc.todoRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := todo.Clone()
+	changed := false
+	for _, name := range names {
+		if todo.Assign(name) {
+			changed = true
+		}
+	}
+	if !changed {
+		return todo, nil
+	}
+
+	err = uc.todoRepo.Update(todo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.recordChange(entity.OperationAssign, before, todo); err != nil {
+		return nil, err
+	}
+	return todo, nil
+}
+
+func (uc *TodoUseCase) UnassignTodo(id string, assignees ...string) (*entity.Todo, error) {
+	names, err := normalizeAssignees(assignees)
+	if err != nil {
+		return nil, err
+	}
+
+	todo, err := uc.todoRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := todo.Clone()
+	changed := false
+	for _, name := range names {
+		if todo.Unassign(name) {
+			changed = true
+		}
+	}
+	if !changed {
+		return todo, nil
+	}
+
+	err = uc.todoRepo.Update(todo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.recordChange(entity.OperationUnassign, before, todo); err != nil {
+		return nil, err
+	}
+	return todo, nil
+}
+
+// GetTodosByAssignee retorna as tarefas atribuídas ao responsável informado;
+// um responsável vazio retorna as tarefas sem nenhuma atribuição
+func (uc *TodoUseCase) GetTodosByAssignee(assignee string) ([]*entity.Todo, error) {
+	todos, err := uc.todoRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	assignee = strings.TrimSpace(assignee)
+	filtered := make([]*entity.Todo, 0, len(todos))
+	for _, todo := range todos {
+		if (assignee == "" && len(todo.Assignees) == 0) || (assignee != "" && todo.IsAssignedTo(assignee)) {
+			filtered = append(filtered, todo)
+		}
+	}
+
+	return filtered, nil
+}
+
+// ArchiveTodos move as tarefas concluídas antes de completedBefore para o
+// arquivo; o tempo zero arquiva todas as concluídas
+func (uc *TodoUseCase) ArchiveTodos(completedBefore time.Time) ([]*entity.Todo, error) {
+	if uc.archiveRepo == nil {
+		return nil, ErrArchiveDisabled
+	}
+
+	var archived []*entity.Todo
+	err := uc.inTransaction(func(txUseCase *TodoUseCase) error {
+		todos, err := txUseCase.todoRepo.GetAll()
+		if err != nil {
+			return err
+		}
+
+		archived = make([]*entity.Todo, 0)
+		for _, todo := range todos {
+			if !todo.Completed {
+				continue
+			}
+			if !completedBefore.IsZero() && !todo.CompletionTime().Before(completedBefore) {
+				continue
+			}
+
+			before := todo.Clone()
+			todo.MarkAsArchived()
+			if err := txUseCase.archiveRepo.Create(todo); err != nil {
+				return err
+			}
+			if err := txUseCase.todoRepo.Delete(todo.ID); err != nil {
+				return err
+			}
+			archived = append(archived, todo)
+
+			if err := txUseCase.recordAction(entity.HistoryActionArchived, before, todo); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return archived, nil
+}
+
+func (uc *TodoUseCase) GetArchivedTodos() ([]*entity.Todo, error) {
+	if uc.archiveRepo == nil {
+		return nil, ErrArchiveDisabled
+	}
+	return uc.archiveRepo.GetAll()
+}
+
+// UnarchiveTodo devolve a tarefa arquivada para as tarefas ativas; como em
+// ArchiveTodos, a tarefa nunca fica nos dois armazenamentos nem em nenhum
+func (uc *TodoUseCase) UnarchiveTodo(id string) (*entity.Todo, error) {
+	if uc.archiveRepo == nil {
+		return nil, ErrArchiveDisabled
+	}
+
+	var todo *entity.Todo
+	err := uc.inTransaction(func(txUseCase *TodoUseCase) error {
+		var err error
+		todo, err = txUseCase.archiveRepo.GetByID(id)
+		if err != nil {
+			return err
+		}
+
+		before := todo.Clone()
+		todo.MarkAsUnarchived()
+		if err := txUseCase.todoRepo.Create(todo); err != nil {
+			return err
+		}
+		if err := txUseCase.archiveRepo.Delete(id); err != nil {
+			return err
+		}
+		return txUseCase.recordAction(entity.HistoryActionUnarchived, before, todo)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return todo, nil
+}
+
+func (uc *TodoUseCase) FilterTodos(filter entity.TodoFilter) ([]*entity.Todo, error) {
+	todos, err := uc.todoRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*entity.Todo, 0, len(todos))
+	for _, todo := range todos {
+		if filter.Matches(todo) {
+			filtered = append(filtered, todo)
+		}
+	}
+	return filtered, nil
+}
+
+// RunBatch aplica a ação do lote a cada tarefa e devolve um resultado por ID;
+// a falha de uma tarefa não interrompe as demais. As tarefas bem-sucedidas são
+// gravadas juntas em uma única transação. Em modo DryRun nada é gravado
+func (uc *TodoUseCase) RunBatch(batch app_interfaces.Batch) ([]app_interfaces.BatchResult, error) {
+	switch batch.Action {
+	case entity.OperationComplete, entity.OperationDelete, entity.OperationUpdate:
+	default:
+		return nil, ErrUnknownAction
+	}
+
+	ids := uniqueIDs(batch.IDs)
+	results := make([]app_interfaces.BatchResult, 0, len(ids))
+
+	if batch.DryRun {
+		for _, id := range ids {
+			result := app_interfaces.BatchResult{ID: id}
+			result.Todo, result.Err = uc.todoRepo.GetByID(id)
+			results = append(results, result)
+		}
+		return results, nil
+	}
+
+	err := uc.inTransaction(func(txUseCase *TodoUseCase) error {
+		for _, id := range ids {
+			result := app_interfaces.BatchResult{ID: id}
+
+			switch batch.Action {
+			case entity.OperationComplete:
+				result.Todo, result.Err = txUseCase.CompleteTodo(id)
+			case entity.OperationUpdate:
+				result.Todo, result.Err = txUseCase.UpdateTodo(id, batch.Title, batch.Description)
+			case entity.OperationDelete:
+				result.Todo, result.Err = txUseCase.todoRepo.GetByID(id)
+				if result.Err == nil {
+					result.Err = txUseCase.DeleteTodo(id)
+				}
+			}
+			results = append(results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ImportTodos grava tarefas lidas de outro formato mantendo ID e datas. As
+// que já existem (pelo ID, inclusive na lixeira, ou pelo título com
+// MatchTitle) recebem os dados importados e as demais são criadas; a falha de
+// uma tarefa não interrompe as outras e as bem-sucedidas são gravadas em uma
+// única transação. Em modo DryRun nada é gravado
+func (uc *TodoUseCase) ImportTodos(batch app_interfaces.Import) ([]app_interfaces.ImportResult, error) {
+	results := make([]app_interfaces.ImportResult, 0, len(batch.Todos))
+	if batch.DryRun {
+		for _, imported := range batch.Todos {
+			result := app_interfaces.ImportResult{}
+			result.Todo, _, result.Action, result.Err = uc.planImport(imported, batch.MatchTitle)
+			results = append(results, result)
+		}
+		return results, nil
+	}
+
+	err := uc.inTransaction(func(txUseCase *TodoUseCase) error {
+		for _, imported := range batch.Todos {
+			result := app_interfaces.ImportResult{}
+			result.Todo, result.Action, result.Err = txUseCase.importTodo(imported, batch.MatchTitle)
+			results = append(results, result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (uc *TodoUseCase) importTodo(imported *entity.Todo, matchTitle bool) (*entity.Todo, string, error) {
+	todo, existing, action, err := uc.planImport(imported, matchTitle)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch action {
+	case app_interfaces.ImportCreated:
+		if err := uc.todoRepo.Create(todo); err != nil {
+			return nil, "", err
+		}
+		if err := uc.recordChange(entity.OperationCreate, nil, todo); err != nil {
+			return nil, "", err
+		}
+	case app_interfaces.ImportUpdated:
+		if err := uc.todoRepo.Update(todo); err != nil {
+			return nil, "", err
+		}
+		if err := uc.recordChange(entity.OperationUpdate, existing, todo); err != nil {
+			return nil, "", err
+		}
+	}
+	return todo, action, nil
+}
+
+// planImport valida a tarefa importada e decide, sem gravar nada, se ela
+// será criada, atualizada ou mantida; devolve a tarefa como ficaria gravada
+func (uc *TodoUseCase) planImport(imported *entity.Todo, matchTitle bool) (todo, existing *entity.Todo, action string, err error) {
+	switch {
+	case strings.TrimSpace(imported.Title) == "":
+		return nil, nil, "", errors.New("todo title is required")
+	case !entity.IsValidPriority(imported.Priority):
+		return nil, nil, "", fmt.Errorf("invalid priority %q (expected a letter from A to Z)", imported.Priority)
+	}
+
+	todo = imported.Clone()
+	now := time.Now()
+	todo.UpdatedAt = now
+	if !todo.Completed {
+		todo.CompletedAt = nil
+	}
+	todo.DeletedAt = nil
+	todo.ArchivedAt = nil
+
+	if todo.ID != "" {
+		if uc.archiveRepo != nil {
+			if _, err := uc.archiveRepo.GetByID(todo.ID); err == nil {
+				return nil, nil, "", errors.New("todo is archived")
+			}
+		}
+		existing, _ = uc.findAnyByID(todo.ID)
+	}
+	if existing == nil && matchTitle {
+		if existing, err = uc.findByTitle(todo.Title); err != nil {
+			return nil, nil, "", err
+		}
+	}
+	if existing == nil {
+		if todo.ID == "" {
+			todo.ID = entity.NewTodo(todo.Title, "").ID
+		}
+		if todo.CreatedAt.IsZero() {
+			todo.CreatedAt = now
+		}
+		if todo.Completed && todo.CompletedAt == nil {
+			todo.CompletedAt = &now
+		}
+		return todo, nil, app_interfaces.ImportCreated, nil
+	}
+
+	todo.ID = existing.ID
+	todo.Version = existing.Version
+	// Formatos que não guardam algum dos campos opcionais não os apagam
+	if todo.CreatedAt.IsZero() {
+		todo.CreatedAt = existing.CreatedAt
+	}
+	if todo.Description == "" {
+		todo.Description = existing.Description
+	}
+	if len(todo.Assignees) == 0 {
+		todo.Assignees = slices.Clone(existing.Assignees)
+	}
+	if todo.Priority == "" {
+		todo.Priority = existing.Priority
+	}
+	if len(todo.Projects) == 0 {
+		todo.Projects = slices.Clone(existing.Projects)
+	}
+	if len(todo.Tags) == 0 {
+		todo.Tags = slices.Clone(existing.Tags)
+	}
+	if todo.DueDate == nil && existing.DueDate != nil {
+		dueDate := *existing.DueDate
+		todo.DueDate = &dueDate
+	}
+	// Sem data de conclusão no arquivo, mantém a que já estava gravada
+	if todo.Completed && todo.CompletedAt == nil && existing.CompletedAt != nil {
+		completedAt := *existing.CompletedAt
+		todo.CompletedAt = &completedAt
+	}
+	if todo.Completed && todo.CompletedAt == nil {
+		todo.CompletedAt = &now
+	}
+	if len(entity.DiffTodos(existing, todo)) == 0 {
+		return existing, existing, app_interfaces.ImportUnchanged, nil
+	}
+	return todo, existing, app_interfaces.ImportUpdated, nil
+}
+
+// findByTitle procura, inclusive na lixeira, uma tarefa com o mesmo título
+// sem diferenciar maiúsculas; nil quando não há nenhuma
+func (uc *TodoUseCase) findByTitle(title string) (*entity.Todo, error) {
+	todos, err := uc.todoRepo.Find(repository.TodoQuery{IncludeDeleted: true})
+	if err != nil {
+		return nil, err
+	}
+	title = strings.TrimSpace(title)
+	for _, todo := range todos {
+		if strings.EqualFold(strings.TrimSpace(todo.Title), title) {
+			return todo, nil
+		}
+	}
+	return nil, nil
+}
+
+// inTransaction executa fn com uma cópia do use case ligada a uma transação
+// dos repositórios que suportam unit of work; histórico e diário são gravados
+// somente após o commit. Repositórios sem suporte executam fn diretamente
+func (uc *TodoUseCase) inTransaction(fn func(txUseCase *TodoUseCase) error) error {
+	if uc.pending != nil {
+		return fn(uc)
+	}
+
+	txUseCase := *uc
+	txUseCase.pending = &[]func(uc *TodoUseCase) error{}
+
+	var transactions []repository.ITodoTransaction
+	begin := func(repo repository.ITodoRepository) (repository.ITodoRepository, error) {
+		unitOfWork, ok := repo.(repository.IUnitOfWork)
+		if !ok {
+			return repo, nil
+		}
+		tx, err := unitOfWork.Begin()
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, tx)
+		return tx, nil
+	}
+	rollback := func() {
+		for _, tx := range transactions {
+			tx.Rollback()
+		}
+	}
+
+	var err error
+	if txUseCase.todoRepo, err = begin(uc.todoRepo); err != nil {
+		return err
+	}
+	if uc.archiveRepo != nil {
+		if txUseCase.archiveRepo, err = begin(uc.archiveRepo); err != nil {
+			rollback()
+			return err
+		}
+	}
+
+	if err := fn(&txUseCase); err != nil {
+		rollback()
+		return err
+	}
+
+	// O arquivo é confirmado antes do armazenamento principal: se o segundo
+	// commit falhar, as tarefas ficam duplicadas em vez de perdidas
+	for i := len(transactions) - 1; i >= 0; i-- {
+		if err := transactions[i].Commit(); err != nil {
+			for _, pendingTx := range transactions[:i] {
+				pendingTx.Rollback()
+			}
+			return err
+		}
+	}
+
+	for _, record := range *txUseCase.pending {
+		if err := record(uc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+// GetTodoHistory retorna a linha do tempo da tarefa, inclusive após ela ser deletada
+func (uc *TodoUseCase) GetTodoHistory(id string) ([]*entity.HistoryEntry, error) {
+	if uc.historyRepo == nil {
+		return nil, ErrHistoryDisabled
+	}
+	return uc.historyRepo.GetByTodoID(id)
+}
+
+// Undo reverte a última operação registrada no diário, restaurando a tarefa
+// exatamente como era (mesmo ID e timestamps)
+func (uc *TodoUseCase) Undo() (*entity.Operation, error) {
+	if uc.journalRepo == nil {
+		return nil, ErrJournalDisabled
+	}
+
+	journal, err := uc.journalRepo.Load()
+	if err != nil {
+		return nil, err
+	}
+	if len(journal.Undo) == 0 {
+		return nil, ErrNothingToUndo
+	}
+
+	operation := journal.Undo[len(journal.Undo)-1]
+	if err := uc.applyTransition(operation.After, operation.Before); err != nil {
+		return nil, err
+	}
+
+	journal.Undo = journal.Undo[:len(journal.Undo)-1]
+	journal.Redo = append(journal.Redo, operation)
+	if err := uc.journalRepo.Save(journal); err != nil {
+		return nil, err
+	}
+
+	if err := uc.recordAction(transitionAction(operation.After, operation.Before), operation.After, operation.Before); err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// Redo reaplica a última operação desfeita
+func (uc *TodoUseCase) Redo() (*entity.Operation, error) {
+	if uc.journalRepo == nil {
+		return nil, ErrJournalDisabled
+	}
+
+	journal, err := uc.journalRepo.Load()
+	if err != nil {
+		return nil, err
+	}
+	if len(journal.Redo) == 0 {
+		return nil, ErrNothingToRedo
+	}
+
+	operation := journal.Redo[len(journal.Redo)-1]
+	if err := uc.applyTransition(operation.Before, operation.After); err != nil {
+		return nil, err
+	}
+
+	journal.Redo = journal.Redo[:len(journal.Redo)-1]
+	journal.Undo = append(journal.Undo, operation)
+	if err := uc.journalRepo.Save(journal); err != nil {
+		return nil, err
+	}
+
+	if err := uc.recordAction(transitionAction(operation.Before, operation.After), operation.Before, operation.After); err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// CompactStore reduz o armazenamento das tarefas quando ele acumula
+// histórico, como o log de eventos
+func (uc *TodoUseCase) CompactStore() (repository.CompactResult, error) {
+	compactable, ok := uc.todoRepo.(repository.ICompactable)
+	if !ok {
+		return repository.CompactResult{}, ErrNotCompactable
+	}
+	return compactable.Compact()
+}
+
+// WatchTodos acompanha as alterações gravadas no armazenamento, inclusive
+// por outras execuções da CLI, até ctx terminar
+func (uc *TodoUseCase) WatchTodos(ctx context.Context) (<-chan *entity.Event, error) {
+	watchable, ok := uc.todoRepo.(repository.IWatchable)
+	if !ok {
+		return nil, ErrNotWatchable
+	}
+	return watchable.Watch(ctx)
+}
+
+// applyTransition leva a tarefa do estado from para o estado to, recusando-se
+// a continuar se ela foi alterada por fora do diário nesse meio tempo
+func (uc *TodoUseCase) applyTransition(from, to *entity.Todo) error {
+	switch {
+	case from == nil && to == nil:
+		return nil
+	case from == nil:
+		if _, err := uc.findAnyByID(to.ID); err == nil {
+			return ErrJournalConflict
+		}
+		return uc.todoRepo.Create(to.Clone())
+	default:
+		current, err := uc.findAnyByID(from.ID)
+		if err != nil {
+			return err
+		}
+		if !current.UpdatedAt.Equal(from.UpdatedAt) {
+			return ErrJournalConflict
+		}
+		if to == nil {
+			return uc.todoRepo.Delete(from.ID)
+		}
+		target := to.Clone()
+		target.Version = current.Version
+		return uc.todoRepo.Update(target)
+	}
+}
+
+func transitionAction(from, to *entity.Todo) string {
+	switch {
+	case from == nil:
+		return entity.HistoryActionCreated
+	case to == nil:
+		return entity.HistoryActionPurged
+	case !from.IsDeleted() && to.IsDeleted():
+		return entity.HistoryActionDeleted
+	case from.IsDeleted() && !to.IsDeleted():
+		return entity.HistoryActionRestored
+	default:
+		return entity.HistoryActionUpdated
+	}
+}
+
+// findAnyByID busca a tarefa mesmo que ela esteja na lixeira
+func (uc *TodoUseCase) findAnyByID(id string) (*entity.Todo, error) {
+	return uc.findOne(repository.TodoQuery{ID: id, IncludeDeleted: true})
+}
+
+func (uc *TodoUseCase) findDeletedByID(id string) (*entity.Todo, error) {
+	return uc.findOne(repository.TodoQuery{ID: id, OnlyDeleted: true})
+}
+
+func (uc *TodoUseCase) findOne(query repository.TodoQuery) (*entity.Todo, error) {
+	todos, err := uc.todoRepo.Find(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(todos) == 0 {
+		return nil, errors.New("todo not found")
+	}
+	return todos[0], nil
+}
+
+func (uc *TodoUseCase) recordChange(operationType string, before, after *entity.Todo) error {
+	if err := uc.recordAction(historyAction(operationType), before, after); err != nil {
+		return err
+	}
+	return uc.recordOperation(operationType, before, after)
+}
+
+func (uc *TodoUseCase) recordOperation(operationType string, before, after *entity.Todo) error {
+	if uc.journalRepo == nil {
+		return nil
+	}
+	if uc.pending != nil {
+		before, after := before.Clone(), after.Clone()
+		*uc.pending = append(*uc.pending, func(target *TodoUseCase) error {
+			return target.recordOperation(operationType, before, after)
+		})
+		return nil
+	}
+
+	journal, err := uc.journalRepo.Load()
+	if err != nil {
+		return err
+	}
+
+	journal.Record(entity.NewOperation(operationType, before, after.Clone()), uc.journalSize)
+	return uc.journalRepo.Save(journal)
+}
+
+func historyAction(operationType string) string {
+	switch operationType {
+	case entity.OperationCreate:
+		return entity.HistoryActionCreated
+	case entity.OperationComplete:
+		return entity.HistoryActionCompleted
+	case entity.OperationDelete:
+		return entity.HistoryActionDeleted
+	case entity.OperationRestore:
+		return entity.HistoryActionRestored
+	case entity.OperationPurge:
+		return entity.HistoryActionPurged
+	default:
+		return entity.HistoryActionUpdated
+	}
+}
+
+// recordAction registra no histórico uma alteração já gravada e a publica
+// como evento de domínio
+func (uc *TodoUseCase) recordAction(action string, before, after *entity.Todo) error {
+	if err := uc.recordHistory(action, before, after); err != nil {
+		return err
+	}
+	uc.publishEvent(action, before, after)
+	return nil
+}
+
+func (uc *TodoUseCase) publishEvent(action string, before, after *entity.Todo) {
+	if uc.publisher == nil {
+		return
+	}
+	if uc.pending != nil {
+		before, after := before.Clone(), after.Clone()
+		*uc.pending = append(*uc.pending, func(target *TodoUseCase) error {
+			target.publishEvent(action, before, after)
+			return nil
+		})
+		return
+	}
+
+	event := entity.NewEvent(entity.EventTypeForAction(action), changedTodoID(before, after), after)
+	event.Previous = before.Clone()
+	event.Actor = uc.actor
+	uc.publisher.Publish(event)
+}
+
+func (uc *TodoUseCase) recordHistory(action string, before, after *entity.Todo) error {
+	if uc.historyRepo == nil {
+		return nil
+	}
+	if uc.pending != nil {
+		before, after := before.Clone(), after.Clone()
+		*uc.pending = append(*uc.pending, func(target *TodoUseCase) error {
+			return target.recordHistory(action, before, after)
+		})
+		return nil
+	}
+
+	return uc.historyRepo.Append(entity.NewHistoryEntry(changedTodoID(before, after), action, uc.actor, entity.DiffTodos(before, after)))
+}
+
+func changedTodoID(before, after *entity.Todo) string {
+	if after != nil {
+		return after.ID
+	}
+	if before != nil {
+		return before.ID
+	}
+	return ""
+}
+
+func normalizeAssignees(assignees []string) ([]string, error) {
+	if len(assignees) == 0 {
+		return nil, errors.New("at least one assignee is required")
+	}
+
+	names := make([]string, 0, len(assignees))
+	for _, assignee := range assignees {
+		name := strings.TrimSpace(assignee)
+		if name == "" {
+			return nil, errors.New("assignee cannot be empty")
+		}
+		names = append(names, name)
+	}
+
+	return names, nil
 }
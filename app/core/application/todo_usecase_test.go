@@ -2,10 +2,15 @@ package application
 
 import (
 	"codecademy-yellowbelt2/core/domain/entity"
+	app_interfaces "codecademy-yellowbelt2/infrastructure/interface/application"
+	eventMock "codecademy-yellowbelt2/infrastructure/interface/event"
 	repoMock "codecademy-yellowbelt2/infrastructure/interface/repository"
 	"codecademy-yellowbelt2/infrastructure/repository"
+	"context"
 	"errors"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -53,6 +58,58 @@ func TestTodoUseCase_UpdateTodo(t *testing.T) {
 	assert.Equal(t, "Updated", updated.Title, "Expected todo to be updated")
 }
 
+func TestTodoUseCase_UpdateTodoIfVersion(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	todo, _ := useCase.CreateTodo("Original", "")
+
+	// Act
+	updated, err := useCase.UpdateTodoIfVersion(todo.ID, 1, "Updated", "")
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, "Updated", updated.Title, "Expected todo to be updated")
+	assert.Equal(t, 2, updated.Version, "Expected version to be incremented")
+}
+
+func TestShouldReturnConflictWhenUpdatingStaleVersion(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	todo, _ := useCase.CreateTodo("Original", "")
+	useCase.UpdateTodo(todo.ID, "Changed elsewhere", "")
+
+	// Act
+	updated, err := useCase.UpdateTodoIfVersion(todo.ID, 1, "Mine", "")
+	current, _ := useCase.GetTodoByID(todo.ID)
+
+	// Assert
+	var conflict *entity.VersionConflictError
+	assert.Nil(t, updated)
+	assert.ErrorIs(t, err, entity.ErrVersionConflict)
+	assert.ErrorAs(t, err, &conflict)
+	assert.Equal(t, 2, conflict.Current)
+	assert.Equal(t, "Changed elsewhere", current.Title, "Expected todo to be left untouched")
+}
+
+func TestShouldUndoUpdateOnVersionedFileRepository(t *testing.T) {
+	// Arrange
+	repo := repository.NewFileTodoRepository(filepath.Join(t.TempDir(), "todos.json"))
+	useCase := NewTodoUseCase(repo, WithJournal(repository.NewInMemoryJournalRepository(), 10))
+	todo, _ := useCase.CreateTodo("Original", "")
+	useCase.UpdateTodo(todo.ID, "Updated", "")
+
+	// Act
+	_, undoErr := useCase.Undo()
+	undone, _ := useCase.GetTodoByID(todo.ID)
+
+	// Assert
+	assert.NoError(t, undoErr, "Expected undo to adopt the current version")
+	assert.Equal(t, "Original", undone.Title)
+	assert.Equal(t, 3, undone.Version)
+}
+
 func TestTodoUseCase_CompleteTodo(t *testing.T) {
 	// Arrange
 	repo := repository.NewInMemoryTodoRepository()
@@ -67,6 +124,27 @@ func TestTodoUseCase_CompleteTodo(t *testing.T) {
 	assert.True(t, completed.Completed, "Expected todo to be completed")
 }
 
+func TestTodoUseCase_ReopenTodo(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo, WithJournal(repository.NewInMemoryJournalRepository(), DefaultJournalSize))
+	todo, _ := useCase.CreateTodo("Test", "Test Description")
+	useCase.CompleteTodo(todo.ID)
+
+	// Act
+	reopened, err := useCase.ReopenTodo(todo.ID)
+	undone, undoErr := useCase.Undo()
+	stored, _ := useCase.GetTodoByID(todo.ID)
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.False(t, reopened.Completed, "Expected todo to be pending again")
+	assert.Nil(t, reopened.CompletedAt, "Expected completion time to be cleared")
+	assert.NoError(t, undoErr, "Expected reopening to be undoable")
+	assert.Equal(t, entity.OperationReopen, undone.Type)
+	assert.True(t, stored.Completed, "Expected undo to complete the todo again")
+}
+
 func TestTodoUseCase_DeleteTodo(t *testing.T) {
 	// Arrange
 	repo := repository.NewInMemoryTodoRepository()
@@ -165,3 +243,918 @@ func TestShouldReturnErrorWhenCompleteTodoUpdateFails(t *testing.T) {
 	assert.Error(t, err, "Expected error when repository Update fails")
 	mockRepo.AssertExpectations(t)
 }
+
+func TestTodoUseCase_AssignTodo(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	todo, _ := useCase.CreateTodo("Sprint chore", "")
+
+	// Act
+	assigned, err := useCase.AssignTodo(todo.ID, "alice", " bob ", "alice")
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, []string{"alice", "bob"}, assigned.Assignees, "Expected assignees to be deduplicated and trimmed")
+}
+
+func TestTodoUseCase_UnassignTodo(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	todo, _ := useCase.CreateTodo("Sprint chore", "")
+	useCase.AssignTodo(todo.ID, "alice", "bob")
+
+	// Act
+	unassigned, err := useCase.UnassignTodo(todo.ID, "alice")
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, []string{"bob"}, unassigned.Assignees, "Expected only bob to remain assigned")
+}
+
+func TestShouldReturnErrorWhenAssigneeIsEmpty(t *testing.T) {
+	// Arrange
+	mockRepo := new(repoMock.MockTodoRepository)
+	useCase := NewTodoUseCase(mockRepo)
+
+	// Act
+	todo, err := useCase.AssignTodo("some-id", " ")
+
+	// Assert
+	assert.Nil(t, todo, "Expected todo to be nil when assignee is empty")
+	assert.Error(t, err, "Expected error when assignee is empty")
+	mockRepo.AssertNotCalled(t, "GetByID", mock.Anything)
+}
+
+func TestTodoUseCase_GetTodosByAssignee(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	mine, _ := useCase.CreateTodo("Mine", "")
+	theirs, _ := useCase.CreateTodo("Theirs", "")
+	unassigned, _ := useCase.CreateTodo("Nobody", "")
+	useCase.AssignTodo(mine.ID, "alice")
+	useCase.AssignTodo(theirs.ID, "bob")
+
+	// Act
+	aliceTodos, aliceErr := useCase.GetTodosByAssignee("alice")
+	noneTodos, noneErr := useCase.GetTodosByAssignee("")
+
+	// Assert
+	assert.NoError(t, aliceErr, "Expected no error")
+	assert.NoError(t, noneErr, "Expected no error")
+	assert.Len(t, aliceTodos, 1, "Expected one todo assigned to alice")
+	assert.Equal(t, mine.ID, aliceTodos[0].ID, "Expected alice's todo")
+	assert.Len(t, noneTodos, 1, "Expected one unassigned todo")
+	assert.Equal(t, unassigned.ID, noneTodos[0].ID, "Expected the unassigned todo")
+}
+
+func TestTodoUseCase_RecordsHistoryForEveryChange(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	historyRepo := repository.NewInMemoryHistoryRepository()
+	useCase := NewTodoUseCase(repo, WithHistoryRepository(historyRepo), WithActor("alice"))
+	todo, _ := useCase.CreateTodo("Original", "Description")
+	useCase.UpdateTodo(todo.ID, "Updated", "")
+	useCase.AssignTodo(todo.ID, "bob")
+	useCase.CompleteTodo(todo.ID)
+	useCase.DeleteTodo(todo.ID)
+
+	// Act
+	entries, err := useCase.GetTodoHistory(todo.ID)
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Len(t, entries, 5, "Expected one entry per change, including delete")
+	actions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		actions = append(actions, entry.Action)
+		assert.Equal(t, "alice", entry.Actor, "Expected actor to be recorded")
+	}
+	assert.Equal(t, []string{
+		entity.HistoryActionCreated,
+		entity.HistoryActionUpdated,
+		entity.HistoryActionUpdated,
+		entity.HistoryActionCompleted,
+		entity.HistoryActionDeleted,
+	}, actions, "Expected actions in chronological order")
+	assert.Equal(t, []entity.FieldChange{{Field: "title", OldValue: "Original", NewValue: "Updated"}}, entries[1].Changes, "Expected old and new title")
+	assert.Equal(t, []entity.FieldChange{{Field: "assignees", OldValue: "", NewValue: "bob"}}, entries[2].Changes, "Expected assignment diff")
+}
+
+func TestShouldReturnErrorWhenHistoryIsDisabled(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+
+	// Act
+	entries, err := useCase.GetTodoHistory("some-id")
+
+	// Assert
+	assert.Nil(t, entries, "Expected no entries")
+	assert.ErrorIs(t, err, ErrHistoryDisabled, "Expected history disabled error")
+}
+
+func TestShouldReturnErrorWhenHistoryAppendFails(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	mockHistory := new(repoMock.MockHistoryRepository)
+	useCase := NewTodoUseCase(repo, WithHistoryRepository(mockHistory))
+	mockHistory.On("Append", mock.AnythingOfType("*entity.HistoryEntry")).Return(errors.New("append error"))
+
+	// Act
+	todo, err := useCase.CreateTodo("Title", "Description")
+
+	// Assert
+	assert.Nil(t, todo, "Expected todo to be nil when history cannot be recorded")
+	assert.Error(t, err, "Expected error when history Append fails")
+	mockHistory.AssertExpectations(t)
+}
+
+func TestTodoUseCase_UndoDeleteRestoresOriginalTodo(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo, WithJournal(repository.NewInMemoryJournalRepository(), 10))
+	todo, _ := useCase.CreateTodo("Keep me", "Important")
+	original := *todo
+	useCase.DeleteTodo(todo.ID)
+
+	// Act
+	operation, err := useCase.Undo()
+	restored, getErr := useCase.GetTodoByID(todo.ID)
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, entity.OperationDelete, operation.Type, "Expected delete to be undone")
+	assert.NoError(t, getErr, "Expected todo to be restored")
+	assert.Equal(t, original.ID, restored.ID, "Expected original ID")
+	assert.True(t, original.CreatedAt.Equal(restored.CreatedAt), "Expected original CreatedAt")
+	assert.True(t, original.UpdatedAt.Equal(restored.UpdatedAt), "Expected original UpdatedAt")
+}
+
+func TestTodoUseCase_UndoAndRedoUpdate(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo, WithJournal(repository.NewInMemoryJournalRepository(), 10))
+	todo, _ := useCase.CreateTodo("Original", "")
+	useCase.UpdateTodo(todo.ID, "Updated", "")
+
+	// Act
+	_, undoErr := useCase.Undo()
+	undone, _ := useCase.GetTodoByID(todo.ID)
+	undoneTitle := undone.Title
+	_, redoErr := useCase.Redo()
+	redone, _ := useCase.GetTodoByID(todo.ID)
+
+	// Assert
+	assert.NoError(t, undoErr, "Expected no error on undo")
+	assert.NoError(t, redoErr, "Expected no error on redo")
+	assert.Equal(t, "Original", undoneTitle, "Expected title to be reverted")
+	assert.Equal(t, "Updated", redone.Title, "Expected title to be reapplied")
+}
+
+func TestTodoUseCase_UndoCreateRemovesTodo(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo, WithJournal(repository.NewInMemoryJournalRepository(), 10))
+	todo, _ := useCase.CreateTodo("Oops", "")
+
+	// Act
+	_, err := useCase.Undo()
+	_, getErr := useCase.GetTodoByID(todo.ID)
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Error(t, getErr, "Expected created todo to be removed")
+}
+
+func TestTodoUseCase_NewOperationClearsRedo(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo, WithJournal(repository.NewInMemoryJournalRepository(), 10))
+	useCase.CreateTodo("First", "")
+	useCase.Undo()
+	useCase.CreateTodo("Second", "")
+
+	// Act
+	operation, err := useCase.Redo()
+
+	// Assert
+	assert.Nil(t, operation, "Expected nothing to redo")
+	assert.ErrorIs(t, err, ErrNothingToRedo, "Expected redo stack to be cleared")
+}
+
+func TestTodoUseCase_JournalIsBounded(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo, WithJournal(repository.NewInMemoryJournalRepository(), 2))
+	useCase.CreateTodo("First", "")
+	useCase.CreateTodo("Second", "")
+	useCase.CreateTodo("Third", "")
+
+	// Act
+	_, firstErr := useCase.Undo()
+	_, secondErr := useCase.Undo()
+	_, thirdErr := useCase.Undo()
+	todos, _ := useCase.GetAllTodos()
+
+	// Assert
+	assert.NoError(t, firstErr, "Expected no error")
+	assert.NoError(t, secondErr, "Expected no error")
+	assert.ErrorIs(t, thirdErr, ErrNothingToUndo, "Expected only the last 2 operations to be kept")
+	assert.Len(t, todos, 1, "Expected the oldest creation to remain")
+}
+
+func TestShouldRefuseUndoWhenTodoChangedOutsideJournal(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo, WithJournal(repository.NewInMemoryJournalRepository(), 10))
+	todo, _ := useCase.CreateTodo("Original", "")
+	useCase.UpdateTodo(todo.ID, "Updated", "")
+	stored, _ := repo.GetByID(todo.ID)
+	changed := *stored
+	changed.Update("Changed elsewhere", "")
+	repo.Update(&changed)
+
+	// Act
+	operation, err := useCase.Undo()
+
+	// Assert
+	assert.Nil(t, operation, "Expected no operation to be undone")
+	assert.ErrorIs(t, err, ErrJournalConflict, "Expected conflict error")
+}
+
+func TestShouldReturnErrorWhenJournalIsDisabled(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+
+	// Act
+	_, undoErr := useCase.Undo()
+	_, redoErr := useCase.Redo()
+
+	// Assert
+	assert.ErrorIs(t, undoErr, ErrJournalDisabled, "Expected journal disabled error")
+	assert.ErrorIs(t, redoErr, ErrJournalDisabled, "Expected journal disabled error")
+}
+
+func TestTodoUseCase_DeleteMovesTodoToTrash(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	todo, _ := useCase.CreateTodo("Trash me", "")
+	useCase.CreateTodo("Keep me", "")
+
+	// Act
+	err := useCase.DeleteTodo(todo.ID)
+	todos, _ := useCase.GetAllTodos()
+	deleted, deletedErr := useCase.GetDeletedTodos()
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Len(t, todos, 1, "Expected deleted todo to be hidden from list")
+	assert.NoError(t, deletedErr, "Expected no error")
+	assert.Len(t, deleted, 1, "Expected deleted todo to be in the trash")
+	assert.Equal(t, todo.ID, deleted[0].ID, "Expected the deleted todo")
+	assert.NotNil(t, deleted[0].DeletedAt, "Expected DeletedAt to be set")
+}
+
+func TestTodoUseCase_RestoreTodo(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	todo, _ := useCase.CreateTodo("Restore me", "")
+	useCase.DeleteTodo(todo.ID)
+
+	// Act
+	restored, err := useCase.RestoreTodo(todo.ID)
+	found, getErr := useCase.GetTodoByID(todo.ID)
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Nil(t, restored.DeletedAt, "Expected DeletedAt to be cleared")
+	assert.NoError(t, getErr, "Expected todo to be visible again")
+	assert.Equal(t, todo.ID, found.ID, "Expected the restored todo")
+}
+
+func TestShouldReturnErrorWhenRestoringTodoNotInTrash(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	todo, _ := useCase.CreateTodo("Alive", "")
+
+	// Act
+	restored, err := useCase.RestoreTodo(todo.ID)
+
+	// Assert
+	assert.Nil(t, restored, "Expected nothing to be restored")
+	assert.Error(t, err, "Expected error when todo is not in the trash")
+}
+
+func TestTodoUseCase_PurgeTodosOlderThan(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	old, _ := useCase.CreateTodo("Old", "")
+	recent, _ := useCase.CreateTodo("Recent", "")
+	useCase.DeleteTodo(old.ID)
+	useCase.DeleteTodo(recent.ID)
+	longAgo := time.Now().Add(-40 * 24 * time.Hour)
+	deleted, _ := repo.Find(repoMock.TodoQuery{ID: old.ID, OnlyDeleted: true})
+	deleted[0].DeletedAt = &longAgo
+	repo.Update(deleted[0])
+
+	// Act
+	purged, err := useCase.PurgeTodos(30 * 24 * time.Hour)
+	remaining, _ := useCase.GetDeletedTodos()
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Len(t, purged, 1, "Expected only the old todo to be purged")
+	assert.Equal(t, old.ID, purged[0].ID, "Expected the old todo to be purged")
+	assert.Len(t, remaining, 1, "Expected the recent todo to stay in the trash")
+	assert.Equal(t, recent.ID, remaining[0].ID, "Expected the recent todo to remain")
+}
+
+func TestTodoUseCase_PurgeAllTodos(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	todo, _ := useCase.CreateTodo("Trash", "")
+	useCase.CreateTodo("Alive", "")
+	useCase.DeleteTodo(todo.ID)
+
+	// Act
+	purged, err := useCase.PurgeTodos(0)
+	all, _ := repo.Find(repoMock.TodoQuery{IncludeDeleted: true})
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Len(t, purged, 1, "Expected the trash to be emptied")
+	assert.Len(t, all, 1, "Expected only the alive todo to remain stored")
+}
+
+func TestTodoUseCase_ArchiveCompletedTodos(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	archiveRepo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo, WithArchiveRepository(archiveRepo))
+	done, _ := useCase.CreateTodo("Done", "")
+	useCase.CreateTodo("Pending", "")
+	useCase.CompleteTodo(done.ID)
+
+	// Act
+	archived, err := useCase.ArchiveTodos(time.Time{})
+	active, _ := useCase.GetAllTodos()
+	archivedTodos, archivedErr := useCase.GetArchivedTodos()
+	shown, showErr := useCase.GetTodoByID(done.ID)
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Len(t, archived, 1, "Expected only completed todos to be archived")
+	assert.Len(t, active, 1, "Expected archived todo to leave the active store")
+	assert.NoError(t, archivedErr, "Expected no error")
+	assert.Len(t, archivedTodos, 1, "Expected archived todo in the archive store")
+	assert.NoError(t, showErr, "Expected archived todo to still be readable")
+	assert.NotNil(t, shown.ArchivedAt, "Expected ArchivedAt to be set")
+}
+
+func TestTodoUseCase_ArchiveOnlyTodosCompletedBefore(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	archiveRepo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo, WithArchiveRepository(archiveRepo))
+	old, _ := useCase.CreateTodo("Old", "")
+	recent, _ := useCase.CreateTodo("Recent", "")
+	completed, _ := useCase.CompleteTodo(old.ID)
+	useCase.CompleteTodo(recent.ID)
+	longAgo := time.Now().Add(-60 * 24 * time.Hour)
+	completed.CompletedAt = &longAgo
+	repo.Update(completed)
+
+	// Act
+	archived, err := useCase.ArchiveTodos(time.Now().Add(-30 * 24 * time.Hour))
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Len(t, archived, 1, "Expected only the old todo to be archived")
+	assert.Equal(t, old.ID, archived[0].ID, "Expected the old todo to be archived")
+}
+
+func TestTodoUseCase_UnarchiveTodo(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	archiveRepo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo, WithArchiveRepository(archiveRepo))
+	todo, _ := useCase.CreateTodo("Done", "")
+	useCase.CompleteTodo(todo.ID)
+	useCase.ArchiveTodos(time.Time{})
+
+	// Act
+	unarchived, err := useCase.UnarchiveTodo(todo.ID)
+	active, _ := useCase.GetAllTodos()
+	archivedTodos, _ := useCase.GetArchivedTodos()
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Nil(t, unarchived.ArchivedAt, "Expected ArchivedAt to be cleared")
+	assert.Len(t, active, 1, "Expected todo back in the active store")
+	assert.Empty(t, archivedTodos, "Expected archive to be empty")
+}
+
+func TestShouldReturnErrorWhenArchiveIsDisabled(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+
+	// Act
+	_, archiveErr := useCase.ArchiveTodos(time.Time{})
+	_, listErr := useCase.GetArchivedTodos()
+	_, unarchiveErr := useCase.UnarchiveTodo("some-id")
+
+	// Assert
+	assert.ErrorIs(t, archiveErr, ErrArchiveDisabled, "Expected archive disabled error")
+	assert.ErrorIs(t, listErr, ErrArchiveDisabled, "Expected archive disabled error")
+	assert.ErrorIs(t, unarchiveErr, ErrArchiveDisabled, "Expected archive disabled error")
+}
+
+func TestTodoUseCase_RunBatchReportsPartialFailures(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	first, _ := useCase.CreateTodo("First", "")
+	second, _ := useCase.CreateTodo("Second", "")
+
+	// Act
+	results, err := useCase.RunBatch(app_interfaces.Batch{
+		Action: entity.OperationComplete,
+		IDs:    []string{first.ID, "missing", second.ID, first.ID},
+	})
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Len(t, results, 3, "Expected duplicated IDs to be ignored")
+	assert.NoError(t, results[0].Err, "Expected first todo to be completed")
+	assert.True(t, results[0].Todo.Completed, "Expected first todo to be completed")
+	assert.Error(t, results[1].Err, "Expected missing todo to fail")
+	assert.NoError(t, results[2].Err, "Expected second todo to be completed despite previous failure")
+	assert.Equal(t, 1, app_interfaces.CountBatchFailures(results), "Expected one failure")
+}
+
+func TestTodoUseCase_RunBatchDryRunDoesNotWrite(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	todo, _ := useCase.CreateTodo("Keep", "")
+
+	// Act
+	results, err := useCase.RunBatch(app_interfaces.Batch{
+		Action: entity.OperationDelete,
+		IDs:    []string{todo.ID},
+		DryRun: true,
+	})
+	_, getErr := useCase.GetTodoByID(todo.ID)
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Len(t, results, 1, "Expected one preview result")
+	assert.Equal(t, "Keep", results[0].Todo.Title, "Expected preview to show the todo")
+	assert.NoError(t, getErr, "Expected todo to remain after dry run")
+}
+
+func TestTodoUseCase_RunBatchUpdateAndDelete(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	first, _ := useCase.CreateTodo("First", "")
+	second, _ := useCase.CreateTodo("Second", "")
+
+	// Act
+	updateResults, updateErr := useCase.RunBatch(app_interfaces.Batch{
+		Action:      entity.OperationUpdate,
+		IDs:         []string{first.ID, second.ID},
+		Description: "sprint-12",
+	})
+	deleteResults, deleteErr := useCase.RunBatch(app_interfaces.Batch{
+		Action: entity.OperationDelete,
+		IDs:    []string{first.ID, second.ID},
+	})
+	remaining, _ := useCase.GetAllTodos()
+
+	// Assert
+	assert.NoError(t, updateErr, "Expected no error")
+	assert.Equal(t, "sprint-12", updateResults[1].Todo.Description, "Expected description to be updated")
+	assert.NoError(t, deleteErr, "Expected no error")
+	assert.Zero(t, app_interfaces.CountBatchFailures(deleteResults), "Expected no failures")
+	assert.Empty(t, remaining, "Expected all todos to be deleted")
+}
+
+func TestTodoUseCase_ImportTodosCreatesAndUpdatesByID(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	historyRepo := repository.NewInMemoryHistoryRepository()
+	useCase := NewTodoUseCase(repo, WithHistoryRepository(historyRepo))
+	existing, _ := useCase.CreateTodo("Old title", "Keep me")
+	createdAt := time.Date(2024, 1, 10, 0, 0, 0, 0, time.Local)
+
+	// Act
+	results, err := useCase.ImportTodos(app_interfaces.Import{Todos: []*entity.Todo{
+		{ID: existing.ID, Title: "New title", Priority: "A", Projects: []string{"casa"}},
+		{ID: "imported-1", Title: "Imported", CreatedAt: createdAt, Completed: true},
+		{ID: "imported-2", Title: "  "},
+	}})
+	updated, _ := useCase.GetTodoByID(existing.ID)
+	created, _ := useCase.GetTodoByID("imported-1")
+	history, _ := useCase.GetTodoHistory(existing.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, app_interfaces.ImportUpdated, results[0].Action)
+	assert.Equal(t, app_interfaces.ImportCreated, results[1].Action)
+	assert.Error(t, results[2].Err, "Expected an empty title to be rejected")
+
+	assert.Equal(t, "New title", updated.Title)
+	assert.Equal(t, "Keep me", updated.Description, "Expected a missing description to keep the current one")
+	assert.Equal(t, "A", updated.Priority)
+	assert.Equal(t, 2, updated.Version)
+	assert.Equal(t, entity.HistoryActionUpdated, history[len(history)-1].Action)
+
+	assert.True(t, created.CreatedAt.Equal(createdAt), "Expected the imported creation date to be kept")
+	assert.True(t, created.Completed)
+	assert.NotNil(t, created.CompletedAt, "Expected a completed todo without completion date to get one")
+}
+
+func TestTodoUseCase_ImportTodosSkipsUnchangedTodos(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	existing, _ := useCase.CreateTodo("Same", "")
+
+	// Act
+	results, err := useCase.ImportTodos(app_interfaces.Import{Todos: []*entity.Todo{{ID: existing.ID, Title: "Same"}}})
+	stored, _ := useCase.GetTodoByID(existing.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, app_interfaces.ImportUnchanged, results[0].Action)
+	assert.Equal(t, 1, stored.Version, "Expected unchanged todos not to be rewritten")
+}
+
+func TestShouldReturnErrorWhenBatchActionIsUnknown(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+
+	// Act
+	results, err := useCase.RunBatch(app_interfaces.Batch{Action: "explode", IDs: []string{"1"}})
+
+	// Assert
+	assert.Nil(t, results, "Expected no results")
+	assert.ErrorIs(t, err, ErrUnknownAction, "Expected unknown action error")
+}
+
+func TestTodoUseCase_FilterTodos(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	done, _ := useCase.CreateTodo("Sprint 12 deploy", "")
+	useCase.CreateTodo("Sprint 12 review", "")
+	useCase.CreateTodo("Groceries", "")
+	useCase.CompleteTodo(done.ID)
+
+	// Act
+	todos, err := useCase.FilterTodos(entity.TodoFilter{Status: entity.StatusPending, TitleContains: "sprint"})
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Len(t, todos, 1, "Expected only the pending sprint todo")
+	assert.Equal(t, "Sprint 12 review", todos[0].Title, "Expected the pending sprint todo")
+}
+
+type failingCommitRepository struct {
+	repoMock.ITodoRepository
+}
+
+func (r failingCommitRepository) Begin() (repoMock.ITodoTransaction, error) {
+	tx, err := r.ITodoRepository.(repoMock.IUnitOfWork).Begin()
+	return failingCommitTransaction{tx}, err
+}
+
+type failingCommitTransaction struct {
+	repoMock.ITodoTransaction
+}
+
+func (tx failingCommitTransaction) Commit() error {
+	tx.ITodoTransaction.Rollback()
+	return errors.New("commit error")
+}
+
+func TestTodoUseCase_RunBatchIsAtomicWhenCommitFails(t *testing.T) {
+	// Arrange
+	inner := repository.NewInMemoryTodoRepository()
+	historyRepo := repository.NewInMemoryHistoryRepository()
+	setup := NewTodoUseCase(inner)
+	first, _ := setup.CreateTodo("First", "")
+	second, _ := setup.CreateTodo("Second", "")
+	useCase := NewTodoUseCase(failingCommitRepository{inner}, WithHistoryRepository(historyRepo))
+
+	// Act
+	results, err := useCase.RunBatch(app_interfaces.Batch{
+		Action: entity.OperationComplete,
+		IDs:    []string{first.ID, second.ID},
+	})
+	todos, _ := inner.GetAll()
+	history, _ := historyRepo.GetByTodoID(first.ID)
+
+	// Assert
+	assert.Nil(t, results, "Expected no results when the batch is not committed")
+	assert.EqualError(t, err, "commit error", "Expected commit error")
+	for _, todo := range todos {
+		assert.False(t, todo.Completed, "Expected no todo to be completed")
+	}
+	assert.Empty(t, history, "Expected history to be recorded only after commit")
+}
+
+func TestTodoUseCase_UnarchiveIsAtomicWhenCommitFails(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	archiveRepo := repository.NewInMemoryTodoRepository()
+	historyRepo := repository.NewInMemoryHistoryRepository()
+	setup := NewTodoUseCase(repo, WithArchiveRepository(archiveRepo))
+	todo, _ := setup.CreateTodo("Done", "")
+	setup.CompleteTodo(todo.ID)
+	setup.ArchiveTodos(time.Time{})
+	useCase := NewTodoUseCase(repo, WithArchiveRepository(failingCommitRepository{archiveRepo}), WithHistoryRepository(historyRepo))
+
+	// Act
+	_, err := useCase.UnarchiveTodo(todo.ID)
+	active, _ := repo.GetAll()
+	archived, _ := archiveRepo.GetAll()
+	history, _ := historyRepo.GetByTodoID(todo.ID)
+
+	// Assert
+	assert.EqualError(t, err, "commit error", "Expected commit error")
+	assert.Empty(t, active, "Expected the todo not to reach the active store")
+	assert.Len(t, archived, 1, "Expected the todo to stay archived")
+	assert.Empty(t, history, "Expected history to be recorded only after commit")
+}
+
+func TestTodoUseCase_RunBatchRecordsHistoryAfterCommit(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	historyRepo := repository.NewInMemoryHistoryRepository()
+	journalRepo := repository.NewInMemoryJournalRepository()
+	useCase := NewTodoUseCase(repo, WithHistoryRepository(historyRepo), WithJournal(journalRepo, 10))
+	todo, _ := useCase.CreateTodo("First", "")
+
+	// Act
+	_, err := useCase.RunBatch(app_interfaces.Batch{Action: entity.OperationDelete, IDs: []string{todo.ID}})
+	history, _ := historyRepo.GetByTodoID(todo.ID)
+	_, undoErr := useCase.Undo()
+	restored, getErr := useCase.GetTodoByID(todo.ID)
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Len(t, history, 2, "Expected creation and deletion entries")
+	assert.NoError(t, undoErr, "Expected batch deletion to be undoable")
+	assert.NoError(t, getErr, "Expected todo to be restored")
+	assert.Equal(t, todo.ID, restored.ID, "Expected the same todo")
+}
+
+func TestTodoUseCase_CompactStore(t *testing.T) {
+	// Arrange
+	repo := repository.NewEventSourcedTodoRepository(filepath.Join(t.TempDir(), "todos.events.jsonl"))
+	useCase := NewTodoUseCase(repo)
+	todo, _ := useCase.CreateTodo("Compact me", "")
+	useCase.CompleteTodo(todo.ID)
+
+	// Act
+	result, err := useCase.CompactStore()
+	todos, _ := useCase.GetAllTodos()
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, 1, result.Todos, "Expected the todo to be kept in the snapshot")
+	assert.Len(t, todos, 1, "Expected state to survive compaction")
+}
+
+func TestShouldReturnErrorWhenStoreIsNotCompactable(t *testing.T) {
+	// Arrange
+	useCase := NewTodoUseCase(repository.NewInMemoryTodoRepository())
+
+	// Act
+	_, err := useCase.CompactStore()
+
+	// Assert
+	assert.ErrorIs(t, err, ErrNotCompactable)
+}
+
+func TestTodoUseCase_WatchTodos(t *testing.T) {
+	// Arrange
+	useCase := NewTodoUseCase(repository.NewInMemoryTodoRepository())
+	ctx, cancel := context.WithCancel(context.Background())
+	changes, err := useCase.WatchTodos(ctx)
+	todo, _ := useCase.CreateTodo("Watched", "")
+
+	// Act
+	useCase.DeleteTodo(todo.ID)
+	useCase.RestoreTodo(todo.ID)
+	cancel()
+	var types []string
+	for event := range changes {
+		types = append(types, event.Type)
+	}
+
+	// Assert
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, []string{entity.EventTodoCreated, entity.EventTodoDeleted, entity.EventTodoRestored}, types)
+}
+
+func TestShouldReturnErrorWhenStoreIsNotWatchable(t *testing.T) {
+	// Arrange
+	useCase := NewTodoUseCase(new(repoMock.MockTodoRepository))
+
+	// Act
+	_, err := useCase.WatchTodos(context.Background())
+
+	// Assert
+	assert.ErrorIs(t, err, ErrNotWatchable)
+}
+
+func eventOfType(eventType string) interface{} {
+	return mock.MatchedBy(func(event *entity.Event) bool {
+		return event.Type == eventType
+	})
+}
+
+func TestTodoUseCase_PublishesEventsAfterWrites(t *testing.T) {
+	// Arrange
+	publisher := new(eventMock.MockEventPublisher)
+	publisher.On("Publish", eventOfType(entity.EventTodoCreated)).Once()
+	publisher.On("Publish", eventOfType(entity.EventTodoCompleted)).Once()
+	publisher.On("Publish", eventOfType(entity.EventTodoDeleted)).Once()
+	useCase := NewTodoUseCase(repository.NewInMemoryTodoRepository(), WithEventPublisher(publisher), WithActor("alice"))
+
+	// Act
+	todo, _ := useCase.CreateTodo("Evented", "")
+	useCase.CompleteTodo(todo.ID)
+	useCase.DeleteTodo(todo.ID)
+
+	// Assert
+	publisher.AssertExpectations(t)
+	completed := publisher.Calls[1].Arguments.Get(0).(*entity.Event)
+	assert.Equal(t, todo.ID, completed.TodoID)
+	assert.Equal(t, "alice", completed.Actor)
+	assert.False(t, completed.Previous.Completed, "Expected previous state")
+	assert.True(t, completed.Todo.Completed, "Expected new state")
+}
+
+func TestShouldNotPublishEventWhenWriteFails(t *testing.T) {
+	// Arrange
+	mockRepo := new(repoMock.MockTodoRepository)
+	publisher := new(eventMock.MockEventPublisher)
+	mockRepo.On("Create", mock.Anything).Return(errors.New("disk full"))
+	useCase := NewTodoUseCase(mockRepo, WithEventPublisher(publisher))
+
+	// Act
+	_, err := useCase.CreateTodo("Lost", "")
+
+	// Assert
+	assert.Error(t, err)
+	publisher.AssertNotCalled(t, "Publish", mock.Anything)
+}
+
+func TestShouldPublishBatchEventsOnlyAfterCommit(t *testing.T) {
+	// Arrange
+	inner := repository.NewInMemoryTodoRepository()
+	setup := NewTodoUseCase(inner)
+	first, _ := setup.CreateTodo("First", "")
+	publisher := new(eventMock.MockEventPublisher)
+	useCase := NewTodoUseCase(failingCommitRepository{inner}, WithEventPublisher(publisher))
+
+	// Act
+	_, err := useCase.RunBatch(app_interfaces.Batch{
+		Action: entity.OperationComplete,
+		IDs:    []string{first.ID},
+	})
+
+	// Assert
+	assert.EqualError(t, err, "commit error")
+	publisher.AssertNotCalled(t, "Publish", mock.Anything)
+}
+
+func TestTodoUseCase_PublishesPurgeWithPreviousState(t *testing.T) {
+	// Arrange
+	publisher := new(eventMock.MockEventPublisher)
+	publisher.On("Publish", mock.Anything)
+	useCase := NewTodoUseCase(repository.NewInMemoryTodoRepository(), WithEventPublisher(publisher))
+	todo, _ := useCase.CreateTodo("Purged", "")
+	useCase.DeleteTodo(todo.ID)
+
+	// Act
+	useCase.PurgeTodos(0)
+
+	// Assert
+	purged := publisher.Calls[len(publisher.Calls)-1].Arguments.Get(0).(*entity.Event)
+	assert.Equal(t, entity.EventTodoPurged, purged.Type)
+	assert.Nil(t, purged.Todo, "Expected no state after purge")
+	assert.Equal(t, "Purged", purged.Previous.Title)
+}
+
+func TestTodoUseCase_ImportTodosKeepsFieldsMissingFromTheFile(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	due := time.Date(2024, 3, 10, 0, 0, 0, 0, time.Local)
+	existing := entity.NewTodo("Pagar aluguel", "Até dia 10")
+	existing.Priority, existing.Projects, existing.Tags, existing.DueDate = "A", []string{"casa"}, []string{"contas"}, &due
+	repo.Create(existing)
+
+	// Act
+	results, err := useCase.ImportTodos(app_interfaces.Import{Todos: []*entity.Todo{{ID: existing.ID, Title: "Pagar aluguel e luz"}}})
+	stored, _ := useCase.GetTodoByID(existing.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, app_interfaces.ImportUpdated, results[0].Action)
+	assert.Equal(t, "Pagar aluguel e luz", stored.Title)
+	assert.Equal(t, "Até dia 10", stored.Description)
+	assert.Equal(t, "A", stored.Priority)
+	assert.Equal(t, []string{"casa"}, stored.Projects)
+	assert.Equal(t, []string{"contas"}, stored.Tags)
+	assert.True(t, stored.DueDate.Equal(due))
+}
+
+func TestTodoUseCase_ImportTodosKeepsCreatedAtWhenReimportingWithoutIt(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	createdAt := time.Date(2024, 1, 10, 9, 0, 0, 0, time.Local)
+	existing := entity.NewTodo("Pagar aluguel", "")
+	existing.CreatedAt = createdAt
+	repo.Create(existing)
+
+	// Act
+	// Como um checklist Markdown editado, que não guarda a data de criação
+	results, err := useCase.ImportTodos(app_interfaces.Import{Todos: []*entity.Todo{{ID: existing.ID, Title: "Pagar aluguel e luz", Completed: true}}})
+	stored, _ := useCase.GetTodoByID(existing.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, app_interfaces.ImportUpdated, results[0].Action)
+	assert.True(t, stored.CreatedAt.Equal(createdAt), "Expected the original creation date, got %s", stored.CreatedAt)
+	assert.True(t, stored.Completed)
+}
+
+func TestTodoUseCase_ImportTodosMatchesByTitle(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	existing, _ := useCase.CreateTodo("Pagar aluguel", "")
+	batch := app_interfaces.Import{Todos: []*entity.Todo{
+		{Title: " pagar ALUGUEL ", Completed: true},
+		{Title: "Nova tarefa"},
+	}}
+
+	// Act
+	byID, _ := useCase.ImportTodos(app_interfaces.Import{Todos: []*entity.Todo{{Title: "Pagar aluguel"}}, DryRun: true})
+	batch.MatchTitle = true
+	results, err := useCase.ImportTodos(batch)
+	all, _ := useCase.GetAllTodos()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, app_interfaces.ImportCreated, byID[0].Action, "Expected titles to be ignored without MatchTitle")
+	assert.Equal(t, app_interfaces.ImportUpdated, results[0].Action)
+	assert.Equal(t, existing.ID, results[0].Todo.ID)
+	assert.True(t, results[0].Todo.Completed)
+	assert.Equal(t, app_interfaces.ImportCreated, results[1].Action)
+	assert.NotEmpty(t, results[1].Todo.ID, "Expected a generated ID")
+	assert.Len(t, all, 2)
+}
+
+func TestTodoUseCase_ImportTodosDryRunDoesNotWrite(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryTodoRepository()
+	useCase := NewTodoUseCase(repo)
+	existing, _ := useCase.CreateTodo("Old", "")
+
+	// Act
+	results, err := useCase.ImportTodos(app_interfaces.Import{
+		Todos:  []*entity.Todo{{ID: existing.ID, Title: "New"}, {Title: "Created"}, {Title: ""}},
+		DryRun: true,
+	})
+	stored, _ := useCase.GetTodoByID(existing.ID)
+	all, _ := useCase.GetAllTodos()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, app_interfaces.ImportUpdated, results[0].Action)
+	assert.Equal(t, "New", results[0].Todo.Title, "Expected the preview to show the imported data")
+	assert.Equal(t, app_interfaces.ImportCreated, results[1].Action)
+	assert.Error(t, results[2].Err)
+	assert.Equal(t, "Old", stored.Title)
+	assert.Len(t, all, 1)
+}
@@ -0,0 +1,97 @@
+package application
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	app_interfaces "codecademy-yellowbelt2/infrastructure/interface/application"
+	event_interfaces "codecademy-yellowbelt2/infrastructure/interface/event"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+var ErrInvalidWebhookURL = errors.New("webhook url must be an absolute http or https url")
+
+type WebhookUseCase struct {
+	webhookRepo repository.IWebhookRepository
+	sender      event_interfaces.IWebhookSender
+}
+
+func NewWebhookUseCase(webhookRepo repository.IWebhookRepository, sender event_interfaces.IWebhookSender) app_interfaces.IWebhookUseCase {
+	return &WebhookUseCase{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+	}
+}
+
+// AddWebhook cadastra o webhook; sem eventos ele recebe todos e, sem
+// segredo, um é gerado para que as entregas sejam sempre assinadas
+func (uc *WebhookUseCase) AddWebhook(rawURL string, events []string, secret string) (*entity.Webhook, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, ErrInvalidWebhookURL
+	}
+
+	normalized, err := normalizeEventTypes(events)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret == "" {
+		secret, err = generateSecret()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	webhook := entity.NewWebhook(rawURL, normalized, secret)
+	if err := uc.webhookRepo.Create(webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (uc *WebhookUseCase) ListWebhooks() ([]*entity.Webhook, error) {
+	return uc.webhookRepo.GetAll()
+}
+
+func (uc *WebhookUseCase) RemoveWebhook(id string) error {
+	return uc.webhookRepo.Delete(id)
+}
+
+// TestWebhook envia um evento de ping na hora, sem passar pela fila, para
+// conferir URL e segredo
+func (uc *WebhookUseCase) TestWebhook(id string) error {
+	webhook, err := uc.webhookRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	return uc.sender.Send(webhook, entity.NewEvent(entity.EventWebhookPing, "", nil))
+}
+
+func normalizeEventTypes(events []string) ([]string, error) {
+	var normalized []string
+	for _, eventType := range events {
+		eventType = strings.TrimSpace(eventType)
+		if eventType == "" || slices.Contains(normalized, eventType) {
+			continue
+		}
+		if !slices.Contains(entity.EventTypes, eventType) {
+			return nil, fmt.Errorf("unknown event type %q", eventType)
+		}
+		normalized = append(normalized, eventType)
+	}
+	return normalized, nil
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
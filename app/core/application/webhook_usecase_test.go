@@ -0,0 +1,92 @@
+package application
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	eventMock "codecademy-yellowbelt2/infrastructure/interface/event"
+	"codecademy-yellowbelt2/infrastructure/repository"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookUseCase_AddWebhook(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryWebhookRepository()
+	useCase := NewWebhookUseCase(repo, new(eventMock.MockWebhookSender))
+
+	// Act
+	webhook, err := useCase.AddWebhook("https://chat.example.com/hook", []string{" todo.completed ", "todo.completed"}, "")
+	stored, getErr := repo.GetByID(webhook.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, getErr)
+	assert.Equal(t, []string{entity.EventTodoCompleted}, stored.Events)
+	assert.Len(t, stored.Secret, 32, "Expected a generated secret")
+}
+
+func TestWebhookUseCase_AddWebhookKeepsGivenSecret(t *testing.T) {
+	// Arrange
+	useCase := NewWebhookUseCase(repository.NewInMemoryWebhookRepository(), new(eventMock.MockWebhookSender))
+
+	// Act
+	webhook, err := useCase.AddWebhook("http://localhost:8080/hook", nil, "s3cr3t")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t", webhook.Secret)
+	assert.Empty(t, webhook.Events)
+}
+
+func TestWebhookUseCase_AddWebhookRejectsInvalidInput(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryWebhookRepository()
+	useCase := NewWebhookUseCase(repo, new(eventMock.MockWebhookSender))
+
+	// Act
+	_, relativeErr := useCase.AddWebhook("/hook", nil, "")
+	_, schemeErr := useCase.AddWebhook("ftp://example.com/hook", nil, "")
+	_, eventErr := useCase.AddWebhook("https://example.com/hook", []string{"todo.exploded"}, "")
+	webhooks, _ := repo.GetAll()
+
+	// Assert
+	assert.ErrorIs(t, relativeErr, ErrInvalidWebhookURL)
+	assert.ErrorIs(t, schemeErr, ErrInvalidWebhookURL)
+	assert.EqualError(t, eventErr, `unknown event type "todo.exploded"`)
+	assert.Empty(t, webhooks)
+}
+
+func TestWebhookUseCase_RemoveWebhook(t *testing.T) {
+	// Arrange
+	repo := repository.NewInMemoryWebhookRepository()
+	useCase := NewWebhookUseCase(repo, new(eventMock.MockWebhookSender))
+	webhook, _ := useCase.AddWebhook("https://example.com/hook", nil, "")
+
+	// Act
+	err := useCase.RemoveWebhook(webhook.ID)
+	webhooks, _ := useCase.ListWebhooks()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, webhooks)
+	assert.EqualError(t, useCase.RemoveWebhook(webhook.ID), "webhook not found")
+}
+
+func TestWebhookUseCase_TestWebhook(t *testing.T) {
+	// Arrange
+	sender := new(eventMock.MockWebhookSender)
+	useCase := NewWebhookUseCase(repository.NewInMemoryWebhookRepository(), sender)
+	webhook, _ := useCase.AddWebhook("https://example.com/hook", nil, "")
+	sender.On("Send", mock.MatchedBy(func(w *entity.Webhook) bool { return w.ID == webhook.ID }),
+		mock.MatchedBy(func(e *entity.Event) bool { return e.Type == entity.EventWebhookPing })).
+		Return(errors.New("webhook responded with status 404"))
+
+	// Act
+	err := useCase.TestWebhook(webhook.ID)
+
+	// Assert
+	assert.EqualError(t, err, "webhook responded with status 404")
+	sender.AssertExpectations(t)
+}
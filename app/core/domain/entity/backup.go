@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Identificação do arquivo de backup; a versão muda quando o conteúdo muda
+// de um jeito que versões antigas não saberiam restaurar
+const (
+	BackupFormat  = "todo-backup"
+	BackupVersion = 1
+)
+
+// Origem do backup
+const (
+	BackupManual     = "manual"
+	BackupAuto       = "auto"
+	BackupPreRestore = "pre-restore"
+)
+
+var (
+	ErrBackupNotFound    = errors.New("backup not found")
+	ErrBackupCorrupted   = errors.New("backup is corrupted")
+	ErrBackupUnsupported = errors.New("unsupported backup")
+)
+
+// BackupFile é um arquivo guardado no backup, com o tamanho e o SHA-256
+// usados para conferir a integridade antes de restaurar
+type BackupFile struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Backup é o manifesto gravado dentro do arquivo; Name, Path e Size
+// descrevem o arquivo em disco e não fazem parte do manifesto
+type Backup struct {
+	Format    string       `json:"format"`
+	Version   int          `json:"version"`
+	CreatedAt time.Time    `json:"created_at"`
+	Trigger   string       `json:"trigger"`
+	Files     []BackupFile `json:"files"`
+	Dirs      []string     `json:"dirs,omitempty"` // Diretórios, para que os vazios (como contextos sem tarefas) também voltem
+
+	Name string `json:"-"`
+	Path string `json:"-"`
+	Size int64  `json:"-"`
+}
+
+func NewBackup(trigger string) *Backup {
+	return &Backup{
+		Format:    BackupFormat,
+		Version:   BackupVersion,
+		CreatedAt: time.Now(),
+		Trigger:   trigger,
+	}
+}
+
+// CheckVersion recusa arquivos que não são backups ou que vieram de uma
+// versão mais nova do programa
+func (b *Backup) CheckVersion() error {
+	if b.Format != BackupFormat {
+		return fmt.Errorf("%w: not a %s archive", ErrBackupUnsupported, BackupFormat)
+	}
+	if b.Version < 1 || b.Version > BackupVersion {
+		return fmt.Errorf("%w: version %d (this program reads up to %d)", ErrBackupUnsupported, b.Version, BackupVersion)
+	}
+	return nil
+}
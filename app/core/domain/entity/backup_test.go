@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackup_CheckVersion(t *testing.T) {
+	tests := []struct {
+		name   string
+		backup Backup
+		valid  bool
+	}{
+		{"current version", Backup{Format: BackupFormat, Version: BackupVersion}, true},
+		{"other format", Backup{Format: "tar", Version: BackupVersion}, false},
+		{"newer version", Backup{Format: BackupFormat, Version: BackupVersion + 1}, false},
+		{"missing version", Backup{Format: BackupFormat}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := tt.backup.CheckVersion()
+
+			// Assert
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrBackupUnsupported)
+		})
+	}
+}
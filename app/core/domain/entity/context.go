@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+)
+
+// DefaultContext é o contexto que existe desde sempre: o próprio diretório de dados
+const DefaultContext = "default"
+
+var (
+	ErrContextNotFound    = errors.New("context not found")
+	ErrContextExists      = errors.New("context already exists")
+	ErrInvalidContextName = errors.New("context names must start with a letter or digit and use only letters, digits, '-' and '_'")
+	ErrDefaultContext     = errors.New("the default context cannot be renamed or deleted")
+	ErrContextInUse       = errors.New("cannot delete the current context")
+	ErrContextNotEmpty    = errors.New("context still has todos")
+	ErrSameContext        = errors.New("todo is already in this context")
+
+	contextNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,63}$`)
+)
+
+// ValidateContextName garante que o nome pode virar um diretório com segurança
+func ValidateContextName(name string) error {
+	if !contextNamePattern.MatchString(name) {
+		return ErrInvalidContextName
+	}
+	return nil
+}
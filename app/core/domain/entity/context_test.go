@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateContextName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"work", true},
+		{"casa-2026", true},
+		{"side_project", true},
+		{"", false},
+		{"-work", false},
+		{"../work", false},
+		{"my work", false},
+		{"trabalho/cliente", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := ValidateContextName(tt.name)
+
+			// Assert
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidContextName)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package entity
+
+import "time"
+
+const (
+	EventTodoCreated    = "todo.created"
+	EventTodoUpdated    = "todo.updated"
+	EventTodoCompleted  = "todo.completed"
+	EventTodoDeleted    = "todo.deleted"
+	EventTodoRestored   = "todo.restored"
+	EventTodoPurged     = "todo.purged"
+	EventTodoArchived   = "todo.archived"
+	EventTodoUnarchived = "todo.unarchived"
+)
+
+// EventTypes lista os eventos publicados, na ordem do ciclo de vida
+var EventTypes = []string{
+	EventTodoCreated,
+	EventTodoUpdated,
+	EventTodoCompleted,
+	EventTodoDeleted,
+	EventTodoRestored,
+	EventTodoPurged,
+	EventTodoArchived,
+	EventTodoUnarchived,
+}
+
+// Event registra um fato ocorrido com uma tarefa; Todo guarda o estado da
+// tarefa após o fato e fica vazio quando ela deixa de existir
+type Event struct {
+	Sequence  int64     `json:"seq,omitempty"`
+	Type      string    `json:"type"`
+	TodoID    string    `json:"todo_id"`
+	Actor     string    `json:"actor,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	Todo      *Todo     `json:"todo,omitempty"`
+	Previous  *Todo     `json:"previous,omitempty"`
+}
+
+func NewEvent(eventType, todoID string, todo *Todo) *Event {
+	return &Event{
+		Type:      eventType,
+		TodoID:    todoID,
+		Timestamp: time.Now(),
+		Todo:      todo.Clone(),
+	}
+}
+
+func (e *Event) Clone() *Event {
+	if e == nil {
+		return nil
+	}
+	cloned := *e
+	cloned.Todo = e.Todo.Clone()
+	cloned.Previous = e.Previous.Clone()
+	return &cloned
+}
+
+// EventTypeFor classifica a transição de before para after; nil indica que
+// a tarefa não existe naquele lado
+func EventTypeFor(before, after *Todo) string {
+	switch {
+	case after == nil:
+		return EventTodoDeleted
+	case before == nil:
+		return EventTodoCreated
+	case !before.Completed && after.Completed:
+		return EventTodoCompleted
+	default:
+		return EventTodoUpdated
+	}
+}
+
+// EventTypeForAction traduz a ação registrada no histórico para o evento
+// publicado; a exclusão definitiva vira todo.purged
+func EventTypeForAction(action string) string {
+	switch action {
+	case HistoryActionCreated:
+		return EventTodoCreated
+	case HistoryActionCompleted:
+		return EventTodoCompleted
+	case HistoryActionDeleted:
+		return EventTodoDeleted
+	case HistoryActionRestored:
+		return EventTodoRestored
+	case HistoryActionPurged:
+		return EventTodoPurged
+	case HistoryActionArchived:
+		return EventTodoArchived
+	case HistoryActionUnarchived:
+		return EventTodoUnarchived
+	default:
+		return EventTodoUpdated
+	}
+}
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldClassifyEventTypes(t *testing.T) {
+	// Arrange
+	pending := NewTodo("Pending", "")
+	completed := pending.Clone()
+	completed.MarkAsCompleted()
+	renamed := completed.Clone()
+	renamed.Update("Renamed", "")
+
+	// Act & Assert
+	assert.Equal(t, EventTodoCreated, EventTypeFor(nil, pending))
+	assert.Equal(t, EventTodoCompleted, EventTypeFor(pending, completed))
+	assert.Equal(t, EventTodoUpdated, EventTypeFor(completed, renamed))
+	assert.Equal(t, EventTodoDeleted, EventTypeFor(renamed, nil))
+}
+
+func TestShouldSnapshotTodoInEvent(t *testing.T) {
+	// Arrange
+	todo := NewTodo("Original", "")
+
+	// Act
+	event := NewEvent(EventTodoCreated, todo.ID, todo)
+	todo.Update("Changed", "")
+
+	// Assert
+	assert.Equal(t, todo.ID, event.TodoID)
+	assert.Equal(t, "Original", event.Todo.Title)
+	assert.False(t, event.Timestamp.IsZero())
+}
+
+func TestShouldMapHistoryActionsToEventTypes(t *testing.T) {
+	// Act & Assert
+	assert.Equal(t, EventTodoCreated, EventTypeForAction(HistoryActionCreated))
+	assert.Equal(t, EventTodoCompleted, EventTypeForAction(HistoryActionCompleted))
+	assert.Equal(t, EventTodoDeleted, EventTypeForAction(HistoryActionDeleted))
+	assert.Equal(t, EventTodoPurged, EventTypeForAction(HistoryActionPurged))
+	assert.Equal(t, EventTodoArchived, EventTypeForAction(HistoryActionArchived))
+	assert.Equal(t, EventTodoUpdated, EventTypeForAction(HistoryActionUpdated))
+}
+
+func TestShouldCloneEventDeeply(t *testing.T) {
+	// Arrange
+	event := NewEvent(EventTodoUpdated, "1", &Todo{ID: "1", Title: "After"})
+	event.Previous = &Todo{ID: "1", Title: "Before"}
+
+	// Act
+	cloned := event.Clone()
+	cloned.Todo.Title = "Changed"
+	cloned.Previous.Title = "Changed"
+
+	// Assert
+	assert.Equal(t, "After", event.Todo.Title)
+	assert.Equal(t, "Before", event.Previous.Title)
+}
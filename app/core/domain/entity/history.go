@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HistoryActionCreated    = "created"
+	HistoryActionUpdated    = "updated"
+	HistoryActionCompleted  = "completed"
+	HistoryActionDeleted    = "deleted"
+	HistoryActionRestored   = "restored"
+	HistoryActionPurged     = "purged"
+	HistoryActionArchived   = "archived"
+	HistoryActionUnarchived = "unarchived"
+	HistoryActionMoved      = "moved"
+)
+
+type FieldChange struct {
+	Field    string `json:"field"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+// HistoryEntry registra uma alteração de uma tarefa; entradas nunca são editadas
+type HistoryEntry struct {
+	TodoID    string        `json:"todo_id"`
+	Action    string        `json:"action"`
+	Actor     string        `json:"actor"`
+	Timestamp time.Time     `json:"timestamp"`
+	Changes   []FieldChange `json:"changes,omitempty"`
+}
+
+func NewHistoryEntry(todoID, action, actor string, changes []FieldChange) *HistoryEntry {
+	return &HistoryEntry{
+		TodoID:    todoID,
+		Action:    action,
+		Actor:     actor,
+		Timestamp: time.Now(),
+		Changes:   changes,
+	}
+}
+
+// DiffTodos compara os campos editáveis de duas versões de uma tarefa;
+// nil em um dos lados representa uma tarefa inexistente
+func DiffTodos(before, after *Todo) []FieldChange {
+	oldFields := todoFields(before)
+	newFields := todoFields(after)
+
+	var changes []FieldChange
+	for i := range oldFields {
+		if oldFields[i][1] != newFields[i][1] {
+			changes = append(changes, FieldChange{
+				Field:    oldFields[i][0],
+				OldValue: oldFields[i][1],
+				NewValue: newFields[i][1],
+			})
+		}
+	}
+	return changes
+}
+
+func todoFields(todo *Todo) [][2]string {
+	if todo == nil {
+		todo = &Todo{}
+	}
+	return [][2]string{
+		{"title", todo.Title},
+		{"description", todo.Description},
+		{"completed", strconv.FormatBool(todo.Completed)},
+		{"assignees", strings.Join(todo.Assignees, ", ")},
+		{"priority", todo.Priority},
+		{"projects", strings.Join(todo.Projects, ", ")},
+		{"tags", strings.Join(todo.Tags, ", ")},
+		{"due_date", formatOptionalTime(todo.DueDate)},
+		{"deleted_at", formatOptionalTime(todo.DeletedAt)},
+	}
+}
+
+func formatOptionalTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format(time.RFC3339)
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldDiffChangedFields(t *testing.T) {
+	// Arrange
+	before := &Todo{ID: "1", Title: "Old", Description: "Same"}
+	after := &Todo{ID: "1", Title: "New", Description: "Same", Completed: true, Assignees: []string{"alice"}}
+
+	// Act
+	changes := DiffTodos(before, after)
+
+	// Assert
+	assert.Equal(t, []FieldChange{
+		{Field: "title", OldValue: "Old", NewValue: "New"},
+		{Field: "completed", OldValue: "false", NewValue: "true"},
+		{Field: "assignees", OldValue: "", NewValue: "alice"},
+	}, changes)
+}
+
+func TestShouldDiffAgainstMissingTodo(t *testing.T) {
+	// Arrange
+	todo := &Todo{ID: "1", Title: "Created"}
+
+	// Act
+	created := DiffTodos(nil, todo)
+	deleted := DiffTodos(todo, nil)
+
+	// Assert
+	assert.Equal(t, []FieldChange{{Field: "title", OldValue: "", NewValue: "Created"}}, created)
+	assert.Equal(t, []FieldChange{{Field: "title", OldValue: "Created", NewValue: ""}}, deleted)
+}
+
+func TestShouldCreateHistoryEntry(t *testing.T) {
+	// Arrange
+	changes := []FieldChange{{Field: "title", OldValue: "", NewValue: "Test"}}
+
+	// Act
+	entry := NewHistoryEntry("1", HistoryActionCreated, "alice", changes)
+
+	// Assert
+	assert.Equal(t, "1", entry.TodoID)
+	assert.Equal(t, HistoryActionCreated, entry.Action)
+	assert.Equal(t, "alice", entry.Actor)
+	assert.False(t, entry.Timestamp.IsZero())
+	assert.Equal(t, changes, entry.Changes)
+}
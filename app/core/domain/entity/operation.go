@@ -0,0 +1,68 @@
+package entity
+
+import "time"
+
+const (
+	OperationCreate   = "create"
+	OperationUpdate   = "update"
+	OperationComplete = "complete"
+	OperationReopen   = "reopen"
+	OperationDelete   = "delete"
+	OperationAssign   = "assign"
+	OperationUnassign = "unassign"
+	OperationRestore  = "restore"
+	OperationPurge    = "purge"
+)
+
+// Operation guarda o estado completo da tarefa antes e depois de uma alteração,
+// o suficiente para desfazê-la ou refazê-la; nil representa uma tarefa inexistente
+type Operation struct {
+	Type      string    `json:"type"`
+	Before    *Todo     `json:"before,omitempty"`
+	After     *Todo     `json:"after,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type Journal struct {
+	Undo []*Operation `json:"undo"`
+	Redo []*Operation `json:"redo"`
+}
+
+func NewOperation(operationType string, before, after *Todo) *Operation {
+	return &Operation{
+		Type:      operationType,
+		Before:    before,
+		After:     after,
+		Timestamp: time.Now(),
+	}
+}
+
+func (o *Operation) TodoID() string {
+	if o.After != nil {
+		return o.After.ID
+	}
+	if o.Before != nil {
+		return o.Before.ID
+	}
+	return ""
+}
+
+func (o *Operation) Title() string {
+	if o.After != nil {
+		return o.After.Title
+	}
+	if o.Before != nil {
+		return o.Before.Title
+	}
+	return ""
+}
+
+// Record empilha a operação para desfazer, descarta o que poderia ser refeito
+// e mantém no máximo limit operações
+func (j *Journal) Record(operation *Operation, limit int) {
+	j.Undo = append(j.Undo, operation)
+	if limit > 0 && len(j.Undo) > limit {
+		j.Undo = j.Undo[len(j.Undo)-limit:]
+	}
+	j.Redo = nil
+}
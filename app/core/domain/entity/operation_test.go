@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldRecordOperationAndClearRedo(t *testing.T) {
+	// Arrange
+	journal := &Journal{Redo: []*Operation{NewOperation(OperationCreate, nil, &Todo{ID: "old"})}}
+	operation := NewOperation(OperationCreate, nil, &Todo{ID: "1", Title: "New"})
+
+	// Act
+	journal.Record(operation, 10)
+
+	// Assert
+	assert.Equal(t, []*Operation{operation}, journal.Undo)
+	assert.Empty(t, journal.Redo)
+	assert.Equal(t, "1", operation.TodoID())
+	assert.Equal(t, "New", operation.Title())
+}
+
+func TestShouldKeepOnlyLastOperationsWithinLimit(t *testing.T) {
+	// Arrange
+	journal := &Journal{}
+	first := NewOperation(OperationDelete, &Todo{ID: "1"}, nil)
+	second := NewOperation(OperationDelete, &Todo{ID: "2"}, nil)
+	third := NewOperation(OperationDelete, &Todo{ID: "3"}, nil)
+
+	// Act
+	journal.Record(first, 2)
+	journal.Record(second, 2)
+	journal.Record(third, 2)
+
+	// Assert
+	assert.Equal(t, []*Operation{second, third}, journal.Undo)
+	assert.Equal(t, "3", third.TodoID())
+}
@@ -1,18 +1,28 @@
 package entity
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Todo struct {
-	ID          string    `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Completed   bool      `json:"completed"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Completed   bool       `json:"completed"`
+	Assignees   []string   `json:"assignees,omitempty"`
+	Priority    string     `json:"priority,omitempty"` // Uma letra de A (mais alta) a Z
+	Projects    []string   `json:"projects,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
+	Version     int        `json:"version"`
 }
 
 func NewTodo(title, description string) *Todo {
@@ -27,16 +37,83 @@ func NewTodo(title, description string) *Todo {
 	}
 }
 
+// Clone retorna uma cópia profunda da tarefa, sem compartilhar slices ou
+// ponteiros com a original; nil gera nil
+func (t *Todo) Clone() *Todo {
+	if t == nil {
+		return nil
+	}
+	cloned := *t
+	cloned.Assignees = slices.Clone(t.Assignees)
+	cloned.Projects = slices.Clone(t.Projects)
+	cloned.Tags = slices.Clone(t.Tags)
+	cloned.DueDate = cloneTime(t.DueDate)
+	cloned.CompletedAt = cloneTime(t.CompletedAt)
+	cloned.DeletedAt = cloneTime(t.DeletedAt)
+	cloned.ArchivedAt = cloneTime(t.ArchivedAt)
+	return &cloned
+}
+
+// IsValidPriority aceita prioridades vazias ou uma única letra maiúscula
+func IsValidPriority(priority string) bool {
+	return priority == "" || (len(priority) == 1 && priority[0] >= 'A' && priority[0] <= 'Z')
+}
+
+func cloneTime(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+	cloned := *value
+	return &cloned
+}
+
 func (t *Todo) MarkAsCompleted() {
+	now := time.Now()
 	t.Completed = true
-	t.UpdatedAt = time.Now()
+	t.CompletedAt = &now
+	t.UpdatedAt = now
 }
 
 func (t *Todo) MarkAsIncomplete() {
 	t.Completed = false
+	t.CompletedAt = nil
 	t.UpdatedAt = time.Now()
 }
 
+// CompletionTime retorna quando a tarefa foi concluída; tarefas gravadas antes
+// de CompletedAt existir usam a última atualização
+func (t *Todo) CompletionTime() time.Time {
+	if t.CompletedAt != nil {
+		return *t.CompletedAt
+	}
+	return t.UpdatedAt
+}
+
+func (t *Todo) MarkAsArchived() {
+	now := time.Now()
+	t.ArchivedAt = &now
+}
+
+func (t *Todo) MarkAsUnarchived() {
+	t.ArchivedAt = nil
+}
+
+// MarkAsDeleted move a tarefa para a lixeira sem removê-la do armazenamento
+func (t *Todo) MarkAsDeleted() {
+	now := time.Now()
+	t.DeletedAt = &now
+	t.UpdatedAt = now
+}
+
+func (t *Todo) Restore() {
+	t.DeletedAt = nil
+	t.UpdatedAt = time.Now()
+}
+
+func (t *Todo) IsDeleted() bool {
+	return t.DeletedAt != nil
+}
+
 func (t *Todo) Update(title, description string) {
 	if title != "" {
 		t.Title = title
@@ -46,3 +123,31 @@ func (t *Todo) Update(title, description string) {
 	}
 	t.UpdatedAt = time.Now()
 }
+
+func (t *Todo) IsAssignedTo(assignee string) bool {
+	return slices.Contains(t.Assignees, assignee)
+}
+
+// Assign adiciona o responsável à tarefa, retornando false se ele já estava atribuído
+func (t *Todo) Assign(assignee string) bool {
+	if t.IsAssignedTo(assignee) {
+		return false
+	}
+	t.Assignees = append(t.Assignees, assignee)
+	t.UpdatedAt = time.Now()
+	return true
+}
+
+// Unassign remove o responsável da tarefa, retornando false se ele não estava atribuído
+func (t *Todo) Unassign(assignee string) bool {
+	index := slices.Index(t.Assignees, assignee)
+	if index < 0 {
+		return false
+	}
+	t.Assignees = slices.Delete(t.Assignees, index, index+1)
+	if len(t.Assignees) == 0 {
+		t.Assignees = nil
+	}
+	t.UpdatedAt = time.Now()
+	return true
+}
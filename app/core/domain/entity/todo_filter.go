@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusCompleted = "completed"
+)
+
+// TodoFilter seleciona tarefas por atributos; campos vazios não filtram.
+// Com várias tags ou vários projetos, a tarefa precisa ter todos eles
+type TodoFilter struct {
+	Status        string
+	Assignee      string
+	Unassigned    bool
+	TitleContains string
+	Tags          []string
+	Projects      []string
+}
+
+func (f TodoFilter) IsEmpty() bool {
+	return f.Status == "" && f.Assignee == "" && !f.Unassigned && f.TitleContains == "" &&
+		len(f.Tags) == 0 && len(f.Projects) == 0
+}
+
+func (f TodoFilter) Matches(todo *Todo) bool {
+	switch f.Status {
+	case StatusPending:
+		if todo.Completed {
+			return false
+		}
+	case StatusCompleted:
+		if !todo.Completed {
+			return false
+		}
+	}
+
+	if f.Assignee != "" && !todo.IsAssignedTo(f.Assignee) {
+		return false
+	}
+	if f.Unassigned && len(todo.Assignees) > 0 {
+		return false
+	}
+
+	if f.TitleContains != "" && !strings.Contains(strings.ToLower(todo.Title), strings.ToLower(f.TitleContains)) {
+		return false
+	}
+
+	for _, tag := range f.Tags {
+		if !slices.Contains(todo.Tags, tag) {
+			return false
+		}
+	}
+	for _, project := range f.Projects {
+		if !slices.Contains(todo.Projects, project) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldMatchTodosByFilter(t *testing.T) {
+	// Arrange
+	pending := &Todo{Title: "Sprint 12 review", Assignees: []string{"alice"}}
+	done := &Todo{Title: "Deploy", Completed: true}
+
+	// Act & Assert
+	assert.True(t, TodoFilter{}.Matches(pending), "Expected empty filter to match everything")
+	assert.True(t, TodoFilter{Status: StatusPending}.Matches(pending))
+	assert.False(t, TodoFilter{Status: StatusPending}.Matches(done))
+	assert.True(t, TodoFilter{Status: StatusCompleted}.Matches(done))
+	assert.True(t, TodoFilter{Assignee: "alice"}.Matches(pending))
+	assert.False(t, TodoFilter{Assignee: "alice"}.Matches(done))
+	assert.True(t, TodoFilter{Unassigned: true}.Matches(done))
+	assert.False(t, TodoFilter{Unassigned: true}.Matches(pending))
+	assert.True(t, TodoFilter{TitleContains: "sprint 12"}.Matches(pending), "Expected case-insensitive title match")
+	assert.False(t, TodoFilter{TitleContains: "sprint"}.Matches(done))
+}
+
+func TestShouldMatchTodosByTagsAndProjects(t *testing.T) {
+	// Arrange
+	todo := &Todo{Title: "Release", Tags: []string{"work", "urgent"}, Projects: []string{"api"}}
+
+	// Act & Assert
+	assert.True(t, TodoFilter{Tags: []string{"work"}}.Matches(todo))
+	assert.True(t, TodoFilter{Tags: []string{"work", "urgent"}}.Matches(todo))
+	assert.False(t, TodoFilter{Tags: []string{"work", "home"}}.Matches(todo), "Expected every tag to be required")
+	assert.True(t, TodoFilter{Projects: []string{"api"}}.Matches(todo))
+	assert.False(t, TodoFilter{Projects: []string{"web"}}.Matches(todo))
+	assert.False(t, TodoFilter{Tags: []string{"work"}}.Matches(&Todo{Title: "Untagged"}))
+}
+
+func TestShouldReportEmptyFilter(t *testing.T) {
+	// Act & Assert
+	assert.True(t, TodoFilter{}.IsEmpty())
+	assert.False(t, TodoFilter{Status: StatusPending}.IsEmpty())
+	assert.False(t, TodoFilter{Tags: []string{"work"}}.IsEmpty())
+	assert.False(t, TodoFilter{Projects: []string{"api"}}.IsEmpty())
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -65,3 +66,130 @@ func TestShouldMarkTodoAsIncomplete(t *testing.T) {
 	assert.False(t, todo.Completed, "Expected todo to be incomplete")
 	assert.True(t, todo.UpdatedAt.After(originalTime), "Expected UpdatedAt to be updated")
 }
+
+func TestShouldAssignTodo(t *testing.T) {
+	// Arrange
+	todo := NewTodo("Test", "Description")
+	originalTime := todo.UpdatedAt
+
+	// Act
+	time.Sleep(1 * time.Millisecond)
+	assigned := todo.Assign("alice")
+	assignedAgain := todo.Assign("alice")
+
+	// Assert
+	assert.True(t, assigned, "Expected first assignment to change the todo")
+	assert.False(t, assignedAgain, "Expected duplicated assignment to be ignored")
+	assert.Equal(t, []string{"alice"}, todo.Assignees, "Expected alice to be assigned once")
+	assert.True(t, todo.IsAssignedTo("alice"), "Expected todo to be assigned to alice")
+	assert.True(t, todo.UpdatedAt.After(originalTime), "Expected UpdatedAt to be updated")
+}
+
+func TestShouldUnassignTodo(t *testing.T) {
+	// Arrange
+	todo := NewTodo("Test", "Description")
+	todo.Assign("alice")
+	todo.Assign("bob")
+
+	// Act
+	removed := todo.Unassign("alice")
+	removedAgain := todo.Unassign("alice")
+
+	// Assert
+	assert.True(t, removed, "Expected alice to be unassigned")
+	assert.False(t, removedAgain, "Expected unknown assignee to be ignored")
+	assert.Equal(t, []string{"bob"}, todo.Assignees, "Expected only bob to remain assigned")
+	assert.False(t, todo.IsAssignedTo("alice"), "Expected todo not to be assigned to alice")
+}
+
+func TestShouldMoveTodoToTrashAndRestore(t *testing.T) {
+	// Arrange
+	todo := NewTodo("Test", "Description")
+
+	// Act
+	todo.MarkAsDeleted()
+	deleted := todo.IsDeleted()
+	deletedAt := todo.DeletedAt
+	todo.Restore()
+
+	// Assert
+	assert.True(t, deleted, "Expected todo to be in the trash")
+	assert.NotNil(t, deletedAt, "Expected DeletedAt to be set")
+	assert.False(t, todo.IsDeleted(), "Expected todo to be restored")
+	assert.Nil(t, todo.DeletedAt, "Expected DeletedAt to be cleared")
+}
+
+func TestShouldTrackCompletionTime(t *testing.T) {
+	// Arrange
+	todo := NewTodo("Test", "Description")
+
+	// Act
+	todo.MarkAsCompleted()
+	completedAt := todo.CompletedAt
+	todo.MarkAsIncomplete()
+
+	// Assert
+	assert.NotNil(t, completedAt, "Expected CompletedAt to be set on completion")
+	assert.Nil(t, todo.CompletedAt, "Expected CompletedAt to be cleared when reopened")
+}
+
+func TestShouldFallbackToUpdatedAtAsCompletionTime(t *testing.T) {
+	// Arrange
+	updatedAt := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	todo := &Todo{Completed: true, UpdatedAt: updatedAt}
+
+	// Act
+	completionTime := todo.CompletionTime()
+
+	// Assert
+	assert.Equal(t, updatedAt, completionTime, "Expected legacy todos to use UpdatedAt")
+}
+
+func TestShouldCloneTodoWithoutSharingMemory(t *testing.T) {
+	// Arrange
+	completedAt := time.Now()
+	todo := NewTodo("Clone", "Deep copy")
+	todo.Assignees = []string{"alice"}
+	todo.CompletedAt = &completedAt
+
+	// Act
+	clone := todo.Clone()
+	clone.Assignees[0] = "bob"
+	*clone.CompletedAt = completedAt.Add(time.Hour)
+
+	// Assert
+	assert.Equal(t, []string{"alice"}, todo.Assignees, "Expected original assignees to be untouched")
+	assert.True(t, todo.CompletedAt.Equal(completedAt), "Expected original CompletedAt to be untouched")
+	assert.Nil(t, (*Todo)(nil).Clone(), "Expected nil to clone to nil")
+}
+
+// Garante que campos novos com ponteiros, slices ou mapas sejam tratados em Clone
+func TestShouldCloneEveryReferenceField(t *testing.T) {
+	// Arrange
+	todo := &Todo{}
+	value := reflect.ValueOf(todo).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		switch field.Kind() {
+		case reflect.Pointer:
+			field.Set(reflect.New(field.Type().Elem()))
+		case reflect.Slice:
+			field.Set(reflect.MakeSlice(field.Type(), 1, 1))
+		case reflect.Map, reflect.Chan, reflect.Func, reflect.Interface:
+			t.Fatalf("field %s has kind %s: teach Todo.Clone and this test how to copy it", value.Type().Field(i).Name, field.Kind())
+		}
+	}
+
+	// Act
+	clone := reflect.ValueOf(todo.Clone()).Elem()
+
+	// Assert
+	for i := 0; i < value.NumField(); i++ {
+		name := value.Type().Field(i).Name
+		switch value.Field(i).Kind() {
+		case reflect.Pointer, reflect.Slice:
+			assert.NotEqual(t, value.Field(i).Pointer(), clone.Field(i).Pointer(), "Expected %s to be deep copied", name)
+		}
+		assert.Equal(t, value.Field(i).Interface(), clone.Field(i).Interface(), "Expected %s to be copied", name)
+	}
+}
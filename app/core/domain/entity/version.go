@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrVersionConflict = errors.New("todo version conflict")
+
+// VersionConflictError indica que a tarefa foi alterada por outro cliente
+// desde que a versão informada foi lida
+type VersionConflictError struct {
+	ID       string
+	Expected int
+	Current  int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("todo %s version conflict: expected %d, current %d", e.ID, e.Expected, e.Current)
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
+// CheckVersion compara a versão da tarefa com a versão armazenada
+func (t *Todo) CheckVersion(current int) error {
+	if t.Version != current {
+		return &VersionConflictError{ID: t.ID, Expected: t.Version, Current: current}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventWebhookPing é enviado por "webhook test" e não faz parte de EventTypes
+const EventWebhookPing = "webhook.ping"
+
+// Webhook recebe por HTTP os eventos em Events (todos, se vazio), assinados
+// com Secret quando informado
+type Webhook struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events,omitempty"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewWebhook(url string, events []string, secret string) *Webhook {
+	return &Webhook{
+		ID:        uuid.New().String(),
+		URL:       url,
+		Events:    events,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (w *Webhook) Clone() *Webhook {
+	if w == nil {
+		return nil
+	}
+	cloned := *w
+	cloned.Events = slices.Clone(w.Events)
+	return &cloned
+}
+
+func (w *Webhook) Accepts(eventType string) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, eventType)
+}
+
+// WebhookDelivery é uma entrega ainda não confirmada pelo destino; Payload
+// guarda o corpo exato enviado para que as novas tentativas sejam idênticas
+type WebhookDelivery struct {
+	ID            string          `json:"id"`
+	WebhookID     string          `json:"webhook_id"`
+	EventType     string          `json:"event_type"`
+	Payload       json.RawMessage `json:"payload"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	LastError     string          `json:"last_error,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+func NewWebhookDelivery(id, webhookID, eventType string, payload []byte) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:            id,
+		WebhookID:     webhookID,
+		EventType:     eventType,
+		Payload:       payload,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
+
+func (d *WebhookDelivery) IsDue(now time.Time) bool {
+	return !d.NextAttemptAt.After(now)
+}
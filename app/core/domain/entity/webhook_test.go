@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldAcceptAllEventsWhenWebhookHasNoFilter(t *testing.T) {
+	// Arrange
+	webhook := NewWebhook("https://example.com/hook", nil, "")
+
+	// Act & Assert
+	assert.NotEmpty(t, webhook.ID)
+	assert.True(t, webhook.Accepts(EventTodoCreated))
+	assert.True(t, webhook.Accepts(EventTodoPurged))
+}
+
+func TestShouldAcceptOnlyFilteredEvents(t *testing.T) {
+	// Arrange
+	webhook := NewWebhook("https://example.com/hook", []string{EventTodoCompleted}, "secret")
+
+	// Act & Assert
+	assert.True(t, webhook.Accepts(EventTodoCompleted))
+	assert.False(t, webhook.Accepts(EventTodoCreated))
+}
+
+func TestShouldTellWhenDeliveryIsDue(t *testing.T) {
+	// Arrange
+	delivery := NewWebhookDelivery("d1", "w1", EventTodoCreated, []byte(`{}`))
+	delivery.NextAttemptAt = time.Now().Add(time.Minute)
+
+	// Act & Assert
+	assert.False(t, delivery.IsDue(time.Now()))
+	assert.True(t, delivery.IsDue(time.Now().Add(2*time.Minute)))
+}
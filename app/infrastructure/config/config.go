@@ -0,0 +1,32 @@
+package config
+
+import (
+	"os"
+	"os/user"
+	"strings"
+	"time"
+)
+
+const (
+	StorageFile   = "file"
+	StorageEvents = "events"
+
+	DefaultHookTimeout = 10 * time.Second
+
+	DefaultBackupRetention = 7
+)
+
+// CurrentUser identifica quem está usando a CLI quando o arquivo de
+// configuração não define user: TODO_USER tem prioridade, seguido pelo
+// usuário do sistema operacional
+func CurrentUser() string {
+	if name := strings.TrimSpace(os.Getenv("TODO_USER")); name != "" {
+		return name
+	}
+
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+
+	return strings.TrimSpace(os.Getenv("USER"))
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldUseTodoUserEnvAsCurrentUser(t *testing.T) {
+	// Arrange
+	t.Setenv("TODO_USER", "  alice ")
+
+	// Act
+	current := CurrentUser()
+
+	// Assert
+	assert.Equal(t, "alice", current)
+}
+
+func TestShouldFallbackToSystemUserWhenTodoUserIsEmpty(t *testing.T) {
+	// Arrange
+	t.Setenv("TODO_USER", "")
+
+	// Act
+	current := CurrentUser()
+
+	// Assert
+	assert.NotEmpty(t, current)
+}
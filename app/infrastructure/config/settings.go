@@ -0,0 +1,314 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/spf13/pflag"
+)
+
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+
+	// Convenções de data: dia/mês em 24h (pt) ou mês/dia em 12h (en)
+	DateLocalePortuguese = "pt"
+	DateLocaleEnglish    = "en"
+
+	// DefaultProfile é o perfil usado quando nenhum outro é escolhido
+	DefaultProfile = "default"
+)
+
+var (
+	StorageBackends = []string{StorageFile, StorageEvents}
+	OutputFormats   = []string{OutputText, OutputJSON}
+	DateLocales     = []string{DateLocalePortuguese, DateLocaleEnglish}
+
+	// dateFormats é o formato de data padrão de cada convenção; as mensagens
+	// continuam em português, o date_locale só muda como as datas aparecem
+	dateFormats = map[string]string{
+		DateLocalePortuguese: "02/01/2006 15:04",
+		DateLocaleEnglish:    "01/02/2006 03:04 PM",
+	}
+)
+
+// Profile reúne as opções que podem ser definidas no arquivo, na raiz ou em
+// [profiles.<nome>]. Os dias são ponteiros porque zero desativa a manutenção
+// automática e precisa sobrescrever um valor da raiz
+type Profile struct {
+	Storage    string `toml:"storage"`
+	DataDir    string `toml:"data_dir"`
+	Output     string `toml:"output"`
+	DateLocale string `toml:"date_locale"`
+	DateFormat string `toml:"date_format"`
+	User       string `toml:"user"` // Quem usa a CLI, em "me" e no histórico; padrão: usuário do sistema
+
+	AutoArchiveDays *int          `toml:"auto_archive_days"` // Arquivar as concluídas há mais dias que isso
+	AutoBackupDays  *int          `toml:"auto_backup_days"`  // Intervalo entre backups automáticos
+	BackupKeep      int           `toml:"backup_keep"`       // Backups automáticos mantidos
+	HookTimeout     time.Duration `toml:"hook_timeout"`      // Tempo máximo de cada hook (ex: "30s")
+}
+
+type file struct {
+	Profile
+	DefaultProfile string             `toml:"profile"`
+	Profiles       map[string]Profile `toml:"profiles"`
+}
+
+// Settings é a configuração final, já com perfil, variáveis de ambiente e
+// flags aplicados (nessa ordem de prioridade crescente)
+type Settings struct {
+	Profile
+	ProfileName  string
+	ProfileNames []string // Perfis definidos no arquivo, em ordem alfabética
+	ConfigFile   string   // Caminho do arquivo de configuração, mesmo que não exista
+}
+
+// Flags define as flags globais que alteram a configuração; a CLI as registra
+// na raiz para que o cobra as aceite
+func Flags() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("config", pflag.ContinueOnError)
+	flags.String("config", "", "Arquivo de configuração (padrão: $XDG_CONFIG_HOME/todo/config.toml)")
+	flags.String("profile", "", "Perfil do arquivo de configuração a usar")
+	flags.String("data-dir", "", "Diretório onde as tarefas são guardadas")
+	flags.String("storage", "", fmt.Sprintf("Armazenamento das tarefas (%s)", strings.Join(StorageBackends, ", ")))
+	flags.StringP("output", "o", "", fmt.Sprintf("Formato de saída (%s)", strings.Join(OutputFormats, ", ")))
+	flags.String("date-format", "", "Formato das datas, no layout do Go (ex: 2006-01-02 15:04)")
+	return flags
+}
+
+// Load monta a configuração a partir do arquivo, das variáveis TODO_* e das
+// flags globais presentes em args (as demais flags são ignoradas)
+func Load(args []string) (*Settings, error) {
+	flags := Flags()
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+	flags.Usage = func() {}
+	if err := flags.Parse(args); err != nil && !errors.Is(err, pflag.ErrHelp) {
+		return nil, err
+	}
+	flag := func(name string) string {
+		value, _ := flags.GetString(name)
+		return strings.TrimSpace(value)
+	}
+
+	settings := &Settings{ConfigFile: firstNonEmpty(flag("config"), env("TODO_CONFIG"), defaultConfigFile())}
+
+	var parsed file
+	metadata, err := toml.DecodeFile(settings.ConfigFile, &parsed)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("invalid config file %s: %w", settings.ConfigFile, err)
+	}
+	if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+		return nil, fmt.Errorf("unknown config key %q in %s", undecoded[0].String(), settings.ConfigFile)
+	}
+
+	settings.Profile = parsed.Profile
+	for name := range parsed.Profiles {
+		settings.ProfileNames = append(settings.ProfileNames, name)
+	}
+	slices.Sort(settings.ProfileNames)
+	settings.DataDir = firstNonEmpty(settings.DataDir, defaultDataDir())
+
+	settings.ProfileName = firstNonEmpty(flag("profile"), env("TODO_PROFILE"), parsed.DefaultProfile, DefaultProfile)
+	profile, ok := parsed.Profiles[settings.ProfileName]
+	if !ok && settings.ProfileName != DefaultProfile {
+		return nil, fmt.Errorf("unknown profile %q", settings.ProfileName)
+	}
+	if ok && settings.ProfileName != DefaultProfile {
+		// Cada perfil tem o próprio armazenamento, mesmo sem data_dir
+		profile.DataDir = firstNonEmpty(profile.DataDir, filepath.Join(settings.DataDir, "profiles", settings.ProfileName))
+	}
+	settings.override(profile)
+
+	settings.override(Profile{
+		Storage:    env("TODO_STORAGE"),
+		DataDir:    env("TODO_DATA_DIR"),
+		Output:     env("TODO_OUTPUT"),
+		DateLocale: env("TODO_DATE_LOCALE"),
+		DateFormat: os.Getenv("TODO_DATE_FORMAT"),
+		User:       env("TODO_USER"),
+
+		AutoArchiveDays: envDays("TODO_AUTO_ARCHIVE_DAYS"),
+		AutoBackupDays:  envDays("TODO_AUTO_BACKUP_DAYS"),
+		BackupKeep:      envPositive("TODO_BACKUP_KEEP"),
+		HookTimeout:     envDuration("TODO_HOOK_TIMEOUT"),
+	})
+	dateFormat, _ := flags.GetString("date-format")
+	settings.override(Profile{
+		Storage:    flag("storage"),
+		DataDir:    flag("data-dir"),
+		Output:     flag("output"),
+		DateFormat: dateFormat,
+	})
+
+	settings.Storage = strings.ToLower(firstNonEmpty(settings.Storage, StorageFile))
+	settings.Output = strings.ToLower(firstNonEmpty(settings.Output, OutputText))
+	settings.DateLocale = strings.ToLower(firstNonEmpty(settings.DateLocale, DateLocalePortuguese))
+	settings.DataDir = expandHome(settings.DataDir)
+	settings.User = firstNonEmpty(settings.User, CurrentUser())
+
+	if err := settings.validate(); err != nil {
+		return nil, err
+	}
+	settings.DateFormat = firstNonEmpty(settings.DateFormat, dateFormats[settings.DateLocale])
+	if settings.BackupKeep == 0 {
+		settings.BackupKeep = DefaultBackupRetention
+	}
+	if settings.HookTimeout == 0 {
+		settings.HookTimeout = DefaultHookTimeout
+	}
+	return settings, nil
+}
+
+// AutoArchiveAfter é há quanto tempo uma tarefa precisa estar concluída para
+// ser arquivada automaticamente; zero desativa
+func (s *Settings) AutoArchiveAfter() time.Duration {
+	return days(s.AutoArchiveDays)
+}
+
+// AutoBackupInterval é de quanto em quanto tempo um backup automático é
+// feito antes dos comandos que alteram tarefas; zero desativa
+func (s *Settings) AutoBackupInterval() time.Duration {
+	return days(s.AutoBackupDays)
+}
+
+func days(value *int) time.Duration {
+	if value == nil {
+		return 0
+	}
+	return time.Duration(*value) * 24 * time.Hour
+}
+
+// override aplica os valores preenchidos de other
+func (s *Settings) override(other Profile) {
+	s.Storage = firstNonEmpty(strings.TrimSpace(other.Storage), s.Storage)
+	s.DataDir = firstNonEmpty(strings.TrimSpace(other.DataDir), s.DataDir)
+	s.Output = firstNonEmpty(strings.TrimSpace(other.Output), s.Output)
+	s.DateLocale = firstNonEmpty(strings.TrimSpace(other.DateLocale), s.DateLocale)
+	s.DateFormat = firstNonEmpty(other.DateFormat, s.DateFormat)
+	s.User = firstNonEmpty(strings.TrimSpace(other.User), s.User)
+	if other.AutoArchiveDays != nil {
+		s.AutoArchiveDays = other.AutoArchiveDays
+	}
+	if other.AutoBackupDays != nil {
+		s.AutoBackupDays = other.AutoBackupDays
+	}
+	if other.BackupKeep != 0 {
+		s.BackupKeep = other.BackupKeep
+	}
+	if other.HookTimeout != 0 {
+		s.HookTimeout = other.HookTimeout
+	}
+}
+
+func (s *Settings) validate() error {
+	if !slices.Contains(StorageBackends, s.Storage) {
+		return fmt.Errorf("unknown storage backend %q (expected %s)", s.Storage, strings.Join(StorageBackends, ", "))
+	}
+	if !slices.Contains(OutputFormats, s.Output) {
+		return fmt.Errorf("unknown output format %q (expected %s)", s.Output, strings.Join(OutputFormats, ", "))
+	}
+	if !slices.Contains(DateLocales, s.DateLocale) {
+		return fmt.Errorf("unknown date locale %q (expected %s)", s.DateLocale, strings.Join(DateLocales, ", "))
+	}
+	if s.AutoArchiveDays != nil && *s.AutoArchiveDays < 0 {
+		return fmt.Errorf("invalid auto_archive_days %d (expected 0 or more)", *s.AutoArchiveDays)
+	}
+	if s.AutoBackupDays != nil && *s.AutoBackupDays < 0 {
+		return fmt.Errorf("invalid auto_backup_days %d (expected 0 or more)", *s.AutoBackupDays)
+	}
+	if s.BackupKeep < 0 {
+		return fmt.Errorf("invalid backup_keep %d (expected 1 or more)", s.BackupKeep)
+	}
+	if s.HookTimeout < 0 {
+		return fmt.Errorf("invalid hook_timeout %s (expected a positive duration)", s.HookTimeout)
+	}
+	return nil
+}
+
+// defaultConfigFile segue o XDG: $XDG_CONFIG_HOME/todo/config.toml ou ~/.config/todo/config.toml
+func defaultConfigFile() string {
+	if dir := env("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "todo", "config.toml")
+	}
+	return filepath.Join(homeDir(), ".config", "todo", "config.toml")
+}
+
+// defaultDataDir mantém ~/.todo-cli, onde as tarefas sempre ficaram, e só usa
+// $XDG_DATA_HOME/todo quando essa variável está definida e ~/.todo-cli não existe
+func defaultDataDir() string {
+	legacy := filepath.Join(homeDir(), ".todo-cli")
+	if dir := env("XDG_DATA_HOME"); dir != "" {
+		if _, err := os.Stat(legacy); errors.Is(err, os.ErrNotExist) {
+			return filepath.Join(dir, "todo")
+		}
+	}
+	return legacy
+}
+
+func expandHome(path string) string {
+	if path == "~" {
+		return homeDir()
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		return filepath.Join(homeDir(), rest)
+	}
+	return path
+}
+
+func homeDir() string {
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		return "."
+	}
+	return dir
+}
+
+func env(name string) string {
+	return strings.TrimSpace(os.Getenv(name))
+}
+
+// envDays, envPositive e envDuration ignoram valores inválidos, como antes do
+// arquivo de configuração; dias negativos desativam
+func envDays(name string) *int {
+	value, err := strconv.Atoi(env(name))
+	if err != nil {
+		return nil
+	}
+	value = max(value, 0)
+	return &value
+}
+
+func envPositive(name string) int {
+	value, err := strconv.Atoi(env(name))
+	if err != nil || value <= 0 {
+		return 0
+	}
+	return value
+}
+
+func envDuration(name string) time.Duration {
+	value, err := time.ParseDuration(env(name))
+	if err != nil || value <= 0 {
+		return 0
+	}
+	return value
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
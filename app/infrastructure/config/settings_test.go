@@ -0,0 +1,387 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// isolate aponta HOME e as variáveis XDG para um diretório temporário e grava
+// o arquivo de configuração, quando informado
+func isolate(t *testing.T, content string) string {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	for _, name := range []string{"XDG_CONFIG_HOME", "XDG_DATA_HOME", "TODO_CONFIG", "TODO_PROFILE", "TODO_STORAGE", "TODO_DATA_DIR", "TODO_OUTPUT", "TODO_DATE_LOCALE", "TODO_DATE_FORMAT", "TODO_USER",
+		"TODO_AUTO_ARCHIVE_DAYS", "TODO_AUTO_BACKUP_DAYS", "TODO_BACKUP_KEEP", "TODO_HOOK_TIMEOUT"} {
+		t.Setenv(name, "")
+	}
+
+	if content != "" {
+		filename := filepath.Join(home, ".config", "todo", "config.toml")
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filename), 0755))
+		assert.NoError(t, os.WriteFile(filename, []byte(content), 0644))
+	}
+	return home
+}
+
+func TestShouldUseDefaultsWithoutConfigFile(t *testing.T) {
+	// Arrange
+	home := isolate(t, "")
+
+	// Act
+	settings, err := Load(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, StorageFile, settings.Storage)
+	assert.Equal(t, filepath.Join(home, ".todo-cli"), settings.DataDir)
+	assert.Equal(t, OutputText, settings.Output)
+	assert.Equal(t, DateLocalePortuguese, settings.DateLocale)
+	assert.Equal(t, "02/01/2006 15:04", settings.DateFormat)
+	assert.Equal(t, DefaultProfile, settings.ProfileName)
+	assert.Equal(t, filepath.Join(home, ".config", "todo", "config.toml"), settings.ConfigFile)
+}
+
+func TestShouldReadStorageBackendFromEnv(t *testing.T) {
+	// Arrange
+	isolate(t, "")
+	t.Setenv("TODO_STORAGE", " Events ")
+
+	// Act
+	settings, err := Load(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, StorageEvents, settings.Storage)
+}
+
+func TestShouldReadConfigFile(t *testing.T) {
+	// Arrange
+	home := isolate(t, `
+storage = "events"
+data_dir = "~/tarefas"
+output = "json"
+date_locale = "en"
+`)
+
+	// Act
+	settings, err := Load(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, StorageEvents, settings.Storage)
+	assert.Equal(t, filepath.Join(home, "tarefas"), settings.DataDir)
+	assert.Equal(t, OutputJSON, settings.Output)
+	assert.Equal(t, DateLocaleEnglish, settings.DateLocale)
+	assert.Equal(t, "01/02/2006 03:04 PM", settings.DateFormat)
+}
+
+func TestShouldApplyProfileWithItsOwnStore(t *testing.T) {
+	// Arrange
+	home := isolate(t, `
+output = "json"
+
+[profiles.work]
+storage = "events"
+
+[profiles.home]
+data_dir = "/srv/todos"
+`)
+
+	// Act
+	work, workErr := Load([]string{"list", "--profile", "work"})
+	t.Setenv("TODO_PROFILE", "home")
+	personal, personalErr := Load([]string{"list"})
+
+	// Assert
+	assert.NoError(t, workErr)
+	assert.Equal(t, "work", work.ProfileName)
+	assert.Equal(t, StorageEvents, work.Storage)
+	assert.Equal(t, OutputJSON, work.Output)
+	assert.Equal(t, filepath.Join(home, ".todo-cli", "profiles", "work"), work.DataDir)
+	assert.NoError(t, personalErr)
+	assert.Equal(t, "/srv/todos", personal.DataDir)
+}
+
+func TestShouldUseDefaultProfileFromConfigFile(t *testing.T) {
+	// Arrange
+	isolate(t, `
+profile = "work"
+
+[profiles.work]
+data_dir = "/srv/work"
+`)
+
+	// Act
+	settings, err := Load(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "work", settings.ProfileName)
+	assert.Equal(t, "/srv/work", settings.DataDir)
+}
+
+func TestShouldLetEnvAndFlagsOverrideConfigFile(t *testing.T) {
+	// Arrange
+	isolate(t, `
+storage = "events"
+output = "json"
+date_format = "2006-01-02"
+`)
+	t.Setenv("TODO_OUTPUT", "text")
+	t.Setenv("TODO_DATA_DIR", "/from/env")
+
+	// Act
+	settings, err := Load([]string{"list", "--assignee", "me", "--storage=file", "--data-dir", "/from/flag", "-y"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, StorageFile, settings.Storage)
+	assert.Equal(t, OutputText, settings.Output)
+	assert.Equal(t, "/from/flag", settings.DataDir)
+	assert.Equal(t, "2006-01-02", settings.DateFormat)
+}
+
+func TestShouldReadUserFromConfigFileWithEnvOverride(t *testing.T) {
+	// Arrange
+	isolate(t, `
+user = "alice"
+
+[profiles.work]
+user = "alice.silva"
+`)
+
+	// Act
+	fromFile, fileErr := Load(nil)
+	fromProfile, profileErr := Load([]string{"--profile", "work"})
+	t.Setenv("TODO_USER", " bob ")
+	fromEnv, envErr := Load([]string{"--profile", "work"})
+
+	// Assert
+	assert.NoError(t, fileErr)
+	assert.NoError(t, profileErr)
+	assert.NoError(t, envErr)
+	assert.Equal(t, "alice", fromFile.User)
+	assert.Equal(t, "alice.silva", fromProfile.User)
+	assert.Equal(t, "bob", fromEnv.User)
+}
+
+func TestShouldFallbackToSystemUserWithoutUserKey(t *testing.T) {
+	// Arrange
+	isolate(t, "")
+
+	// Act
+	settings, err := Load(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, CurrentUser(), settings.User)
+}
+
+func TestShouldLetDateFormatFlagOverrideConfigFile(t *testing.T) {
+	// Arrange
+	isolate(t, `date_format = "2006-01-02"`)
+	t.Setenv("TODO_DATE_FORMAT", "02.01.2006")
+
+	// Act
+	settings, err := Load([]string{"list", "--date-format", "Jan 2 15:04"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Jan 2 15:04", settings.DateFormat)
+}
+
+func TestShouldHonorXDGDirectories(t *testing.T) {
+	// Arrange
+	home := isolate(t, "")
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "cfg"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+
+	// Act
+	settings, err := Load(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, "cfg", "todo", "config.toml"), settings.ConfigFile)
+	assert.Equal(t, filepath.Join(home, "data", "todo"), settings.DataDir)
+}
+
+func TestShouldKeepLegacyDataDirWhenItExists(t *testing.T) {
+	// Arrange
+	home := isolate(t, "")
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+	assert.NoError(t, os.Mkdir(filepath.Join(home, ".todo-cli"), 0755))
+
+	// Act
+	settings, err := Load(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".todo-cli"), settings.DataDir)
+}
+
+func TestShouldRejectInvalidConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		args    []string
+		message string
+	}{
+		{"unknown profile", "", []string{"--profile", "work"}, `unknown profile "work"`},
+		{"unknown key", `colour = "red"`, nil, `unknown config key "colour"`},
+		{"invalid toml", `storage = `, nil, "invalid config file"},
+		{"invalid storage", `storage = "sqlite"`, nil, `unknown storage backend "sqlite"`},
+		{"invalid output", "", []string{"-o", "xml"}, `unknown output format "xml"`},
+		{"invalid date locale", `date_locale = "fr"`, nil, `unknown date locale "fr"`},
+		{"negative auto archive days", `auto_archive_days = -1`, nil, "invalid auto_archive_days -1"},
+		{"negative backup keep", `backup_keep = -2`, nil, "invalid backup_keep -2"},
+		{"invalid hook timeout", `hook_timeout = "soon"`, nil, "invalid config file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			isolate(t, tt.content)
+
+			// Act
+			_, err := Load(tt.args)
+
+			// Assert
+			assert.ErrorContains(t, err, tt.message)
+		})
+	}
+}
+
+func TestShouldReadMaintenanceFromConfigFileAndProfile(t *testing.T) {
+	// Arrange
+	isolate(t, `
+auto_archive_days = 30
+auto_backup_days = 1
+backup_keep = 3
+hook_timeout = "30s"
+
+[profiles.work]
+auto_archive_days = 0
+`)
+
+	// Act
+	root, rootErr := Load(nil)
+	work, workErr := Load([]string{"--profile", "work"})
+
+	// Assert
+	assert.NoError(t, rootErr)
+	assert.Equal(t, 30*24*time.Hour, root.AutoArchiveAfter())
+	assert.Equal(t, 24*time.Hour, root.AutoBackupInterval())
+	assert.Equal(t, 3, root.BackupKeep)
+	assert.Equal(t, 30*time.Second, root.HookTimeout)
+	assert.NoError(t, workErr)
+	assert.Zero(t, work.AutoArchiveAfter())
+	assert.Equal(t, 24*time.Hour, work.AutoBackupInterval())
+}
+
+func TestShouldLetEnvOverrideMaintenanceFromConfigFile(t *testing.T) {
+	// Arrange
+	isolate(t, `
+auto_archive_days = 30
+auto_backup_days = 1
+backup_keep = 3
+hook_timeout = "30s"
+`)
+	t.Setenv("TODO_AUTO_ARCHIVE_DAYS", "0")
+	t.Setenv("TODO_AUTO_BACKUP_DAYS", "7")
+	t.Setenv("TODO_BACKUP_KEEP", "10")
+	t.Setenv("TODO_HOOK_TIMEOUT", "1m")
+
+	// Act
+	settings, err := Load(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Zero(t, settings.AutoArchiveAfter())
+	assert.Equal(t, 7*24*time.Hour, settings.AutoBackupInterval())
+	assert.Equal(t, 10, settings.BackupKeep)
+	assert.Equal(t, time.Minute, settings.HookTimeout)
+}
+
+func TestShouldReadAutoArchiveDays(t *testing.T) {
+	// Arrange
+	isolate(t, "")
+	t.Setenv("TODO_AUTO_ARCHIVE_DAYS", "30")
+
+	// Act
+	settings, err := Load(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 30*24*time.Hour, settings.AutoArchiveAfter())
+}
+
+func TestShouldDisableAutoArchiveWhenNotConfigured(t *testing.T) {
+	// Arrange
+	isolate(t, "")
+
+	// Act
+	settings, err := Load(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Zero(t, settings.AutoArchiveAfter())
+}
+
+func TestShouldReadHookTimeout(t *testing.T) {
+	// Arrange
+	isolate(t, "")
+	t.Setenv("TODO_HOOK_TIMEOUT", "30s")
+
+	// Act
+	settings, err := Load(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, settings.HookTimeout)
+}
+
+func TestShouldUseDefaultHookTimeoutWhenInvalid(t *testing.T) {
+	// Arrange
+	isolate(t, "")
+	t.Setenv("TODO_HOOK_TIMEOUT", "soon")
+
+	// Act
+	settings, err := Load(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultHookTimeout, settings.HookTimeout)
+}
+
+func TestShouldReadAutoBackupDaysAndRetention(t *testing.T) {
+	// Arrange
+	isolate(t, "")
+	t.Setenv("TODO_AUTO_BACKUP_DAYS", "1")
+	t.Setenv("TODO_BACKUP_KEEP", "3")
+
+	// Act
+	settings, err := Load(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, settings.AutoBackupInterval())
+	assert.Equal(t, 3, settings.BackupKeep)
+}
+
+func TestShouldDisableAutoBackupAndKeepDefaultRetentionWhenNotConfigured(t *testing.T) {
+	// Arrange
+	isolate(t, "")
+	t.Setenv("TODO_BACKUP_KEEP", "todos")
+
+	// Act
+	settings, err := Load(nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Zero(t, settings.AutoBackupInterval())
+	assert.Equal(t, DefaultBackupRetention, settings.BackupKeep)
+}
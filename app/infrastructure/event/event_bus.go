@@ -0,0 +1,159 @@
+package event
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	event_interfaces "codecademy-yellowbelt2/infrastructure/interface/event"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+)
+
+// DefaultQueueSize é quantos eventos um assinante assíncrono acumula antes
+// de Publish passar a esperar por ele
+const DefaultQueueSize = 100
+
+// ErrorHandler é chamado quando um assinante falha ou entra em pânico
+type ErrorHandler func(subscriber string, event *entity.Event, err error)
+
+// EventBus entrega os eventos publicados aos assinantes no próprio processo.
+// Assinantes síncronos rodam dentro de Publish; os assíncronos recebem os
+// eventos em ordem por uma fila própria
+type EventBus struct {
+	mutex         sync.RWMutex
+	subscriptions []*subscription
+	onError       ErrorHandler
+	closed        bool
+	workers       sync.WaitGroup
+}
+
+type subscription struct {
+	name       string
+	subscriber event_interfaces.ISubscriber
+	eventTypes []string
+	queueSize  int
+	queue      chan *entity.Event
+}
+
+var _ event_interfaces.IEventPublisher = (*EventBus)(nil)
+
+type Option func(*EventBus)
+
+// WithErrorHandler substitui o log padrão das falhas de assinantes
+func WithErrorHandler(handler ErrorHandler) Option {
+	return func(b *EventBus) {
+		b.onError = handler
+	}
+}
+
+type SubscribeOption func(*subscription)
+
+// Async entrega os eventos em uma goroutine própria do assinante, com uma
+// fila de até queueSize eventos
+func Async(queueSize int) SubscribeOption {
+	return func(s *subscription) {
+		if queueSize <= 0 {
+			queueSize = DefaultQueueSize
+		}
+		s.queueSize = queueSize
+	}
+}
+
+// OnlyTypes restringe o assinante aos tipos de evento informados
+func OnlyTypes(eventTypes ...string) SubscribeOption {
+	return func(s *subscription) {
+		s.eventTypes = eventTypes
+	}
+}
+
+func NewEventBus(opts ...Option) *EventBus {
+	b := &EventBus{
+		onError: func(subscriber string, event *entity.Event, err error) {
+			log.Printf("event subscriber %s failed on %s: %v", subscriber, event.Type, err)
+		},
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Subscribe registra o assinante; name identifica o assinante nos erros
+func (b *EventBus) Subscribe(name string, subscriber event_interfaces.ISubscriber, opts ...SubscribeOption) {
+	s := &subscription{name: name, subscriber: subscriber}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if s.queueSize > 0 {
+		s.queue = make(chan *entity.Event, s.queueSize)
+		b.workers.Add(1)
+		go func() {
+			defer b.workers.Done()
+			for event := range s.queue {
+				b.deliver(s, event)
+			}
+		}()
+	}
+	b.subscriptions = append(b.subscriptions, s)
+}
+
+// Publish entrega uma cópia do evento a cada assinante interessado; falhas
+// de um assinante não impedem a entrega aos demais
+func (b *EventBus) Publish(event *entity.Event) {
+	b.mutex.RLock()
+	if b.closed {
+		b.mutex.RUnlock()
+		return
+	}
+
+	var synchronous []*subscription
+	for _, s := range b.subscriptions {
+		if len(s.eventTypes) > 0 && !slices.Contains(s.eventTypes, event.Type) {
+			continue
+		}
+		if s.queue != nil {
+			s.queue <- event.Clone()
+		} else {
+			synchronous = append(synchronous, s)
+		}
+	}
+	b.mutex.RUnlock()
+
+	// Síncronos rodam fora do bloqueio para poderem publicar ou assinar
+	for _, s := range synchronous {
+		b.deliver(s, event.Clone())
+	}
+}
+
+// Close para de aceitar eventos e espera os assinantes assíncronos
+// esvaziarem suas filas
+func (b *EventBus) Close() {
+	b.mutex.Lock()
+	if !b.closed {
+		b.closed = true
+		for _, s := range b.subscriptions {
+			if s.queue != nil {
+				close(s.queue)
+			}
+		}
+	}
+	b.mutex.Unlock()
+
+	b.workers.Wait()
+}
+
+func (b *EventBus) deliver(s *subscription, event *entity.Event) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			b.onError(s.name, event, fmt.Errorf("subscriber panicked: %v", recovered))
+		}
+	}()
+
+	if err := s.subscriber.Handle(event); err != nil {
+		b.onError(s.name, event, err)
+	}
+}
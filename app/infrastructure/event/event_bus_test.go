@@ -0,0 +1,131 @@
+package event
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	event_interfaces "codecademy-yellowbelt2/infrastructure/interface/event"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingSubscriber struct {
+	mutex  sync.Mutex
+	events []*entity.Event
+}
+
+func (r *recordingSubscriber) Handle(event *entity.Event) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *recordingSubscriber) types() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	types := make([]string, 0, len(r.events))
+	for _, event := range r.events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestShouldDeliverEventsSynchronously(t *testing.T) {
+	// Arrange
+	bus := NewEventBus()
+	subscriber := &recordingSubscriber{}
+	bus.Subscribe("recorder", subscriber)
+
+	// Act
+	bus.Publish(entity.NewEvent(entity.EventTodoCreated, "1", &entity.Todo{ID: "1"}))
+
+	// Assert
+	assert.Equal(t, []string{entity.EventTodoCreated}, subscriber.types())
+}
+
+func TestShouldDeliverEventsAsynchronouslyInOrder(t *testing.T) {
+	// Arrange
+	bus := NewEventBus()
+	subscriber := &recordingSubscriber{}
+	bus.Subscribe("recorder", subscriber, Async(1))
+
+	// Act
+	bus.Publish(entity.NewEvent(entity.EventTodoCreated, "1", nil))
+	bus.Publish(entity.NewEvent(entity.EventTodoUpdated, "1", nil))
+	bus.Publish(entity.NewEvent(entity.EventTodoCompleted, "1", nil))
+	bus.Close()
+
+	// Assert
+	assert.Equal(t, []string{entity.EventTodoCreated, entity.EventTodoUpdated, entity.EventTodoCompleted}, subscriber.types())
+}
+
+func TestShouldIsolateFailingSubscribers(t *testing.T) {
+	// Arrange
+	var failures []string
+	bus := NewEventBus(WithErrorHandler(func(subscriber string, event *entity.Event, err error) {
+		failures = append(failures, subscriber+": "+err.Error())
+	}))
+	subscriber := &recordingSubscriber{}
+	bus.Subscribe("failing", event_interfaces.SubscriberFunc(func(*entity.Event) error {
+		return errors.New("boom")
+	}))
+	bus.Subscribe("panicking", event_interfaces.SubscriberFunc(func(*entity.Event) error {
+		panic("kaboom")
+	}))
+	bus.Subscribe("recorder", subscriber)
+
+	// Act
+	bus.Publish(entity.NewEvent(entity.EventTodoDeleted, "1", nil))
+
+	// Assert
+	assert.Equal(t, []string{"failing: boom", "panicking: subscriber panicked: kaboom"}, failures)
+	assert.Equal(t, []string{entity.EventTodoDeleted}, subscriber.types())
+}
+
+func TestShouldFilterEventsByType(t *testing.T) {
+	// Arrange
+	bus := NewEventBus()
+	subscriber := &recordingSubscriber{}
+	bus.Subscribe("completions", subscriber, OnlyTypes(entity.EventTodoCompleted))
+
+	// Act
+	bus.Publish(entity.NewEvent(entity.EventTodoCreated, "1", nil))
+	bus.Publish(entity.NewEvent(entity.EventTodoCompleted, "1", nil))
+
+	// Assert
+	assert.Equal(t, []string{entity.EventTodoCompleted}, subscriber.types())
+}
+
+func TestShouldGiveEachSubscriberItsOwnCopy(t *testing.T) {
+	// Arrange
+	bus := NewEventBus()
+	subscriber := &recordingSubscriber{}
+	bus.Subscribe("mutator", event_interfaces.SubscriberFunc(func(event *entity.Event) error {
+		event.Todo.Title = "mutated"
+		return nil
+	}))
+	bus.Subscribe("recorder", subscriber)
+
+	// Act
+	bus.Publish(entity.NewEvent(entity.EventTodoCreated, "1", &entity.Todo{ID: "1", Title: "Original"}))
+
+	// Assert
+	assert.Equal(t, "Original", subscriber.events[0].Todo.Title)
+}
+
+func TestShouldIgnoreEventsPublishedAfterClose(t *testing.T) {
+	// Arrange
+	bus := NewEventBus()
+	subscriber := &recordingSubscriber{}
+	bus.Subscribe("recorder", subscriber, Async(0))
+	bus.Close()
+
+	// Act
+	bus.Publish(entity.NewEvent(entity.EventTodoCreated, "1", nil))
+	bus.Close()
+
+	// Assert
+	assert.Empty(t, subscriber.types())
+}
@@ -0,0 +1,91 @@
+package hook
+
+import (
+	"bytes"
+	"codecademy-yellowbelt2/core/domain/entity"
+	hook_interfaces "codecademy-yellowbelt2/infrastructure/interface/hook"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ScriptRunner executa os hooks como arquivos executáveis de um diretório,
+// no estilo dos hooks do git: a tarefa vai em JSON pela entrada padrão e um
+// código de saída diferente de zero veta a operação
+type ScriptRunner struct {
+	dir     string
+	timeout time.Duration
+}
+
+var _ hook_interfaces.IHookRunner = (*ScriptRunner)(nil)
+
+func NewScriptRunner(dir string, timeout time.Duration) *ScriptRunner {
+	return &ScriptRunner{
+		dir:     dir,
+		timeout: timeout,
+	}
+}
+
+func (r *ScriptRunner) Run(name string, todo *entity.Todo) (*entity.Todo, error) {
+	path := filepath.Join(r.dir, name)
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Arquivos sem permissão de execução são ignorados, como no git
+	if info.IsDir() || info.Mode()&0111 == 0 {
+		return nil, nil
+	}
+
+	input, err := json.Marshal(todo)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(), "TODO_HOOK="+name)
+	// Processos filhos que herdam a saída não podem prender a CLI após o timeout
+	cmd.WaitDelay = time.Second
+
+	err = cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("hook %s timed out after %s", name, r.timeout)
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = exitErr.Error()
+		}
+		return nil, &hook_interfaces.HookRejectedError{Hook: name, Message: message}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	output := bytes.TrimSpace(stdout.Bytes())
+	if len(output) == 0 {
+		return nil, nil
+	}
+	var modified entity.Todo
+	if err := json.Unmarshal(output, &modified); err != nil {
+		return nil, fmt.Errorf("hook %s wrote invalid JSON: %w", name, err)
+	}
+	return &modified, nil
+}
@@ -0,0 +1,91 @@
+package hook
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	hook_interfaces "codecademy-yellowbelt2/infrastructure/interface/hook"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeHook(t *testing.T, dir, name, script string, mode os.FileMode) {
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script+"\n"), mode))
+}
+
+func TestShouldIgnoreMissingAndNonExecutableHooks(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	writeHook(t, dir, hook_interfaces.PreCreate, "exit 1", 0644)
+	runner := NewScriptRunner(dir, time.Second)
+
+	// Act
+	missing, missingErr := runner.Run(hook_interfaces.PreDelete, &entity.Todo{})
+	disabled, disabledErr := runner.Run(hook_interfaces.PreCreate, &entity.Todo{})
+
+	// Assert
+	assert.NoError(t, missingErr)
+	assert.Nil(t, missing)
+	assert.NoError(t, disabledErr)
+	assert.Nil(t, disabled)
+}
+
+func TestShouldPassTodoOnStdinAndReadModifiedTodo(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	writeHook(t, dir, hook_interfaces.PreCreate, `sed 's/"title":"\([^"]*\)"/"title":"[\1]"/'`, 0755)
+	runner := NewScriptRunner(dir, time.Second)
+
+	// Act
+	modified, err := runner.Run(hook_interfaces.PreCreate, &entity.Todo{Title: "Hooked", Description: "Kept"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "[Hooked]", modified.Title)
+	assert.Equal(t, "Kept", modified.Description)
+}
+
+func TestShouldRejectOperationWhenHookFails(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	writeHook(t, dir, hook_interfaces.PreComplete, `echo "$TODO_HOOK: tests are red" >&2; exit 1`, 0755)
+	runner := NewScriptRunner(dir, time.Second)
+
+	// Act
+	_, err := runner.Run(hook_interfaces.PreComplete, &entity.Todo{ID: "1"})
+
+	// Assert
+	var rejected *hook_interfaces.HookRejectedError
+	assert.ErrorAs(t, err, &rejected)
+	assert.EqualError(t, err, "hook pre-complete rejected the operation: pre-complete: tests are red")
+}
+
+func TestShouldStopHookAfterTimeout(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	writeHook(t, dir, hook_interfaces.PreDelete, "sleep 5", 0755)
+	runner := NewScriptRunner(dir, 100*time.Millisecond)
+
+	// Act
+	start := time.Now()
+	_, err := runner.Run(hook_interfaces.PreDelete, &entity.Todo{ID: "1"})
+
+	// Assert
+	assert.EqualError(t, err, "hook pre-delete timed out after 100ms")
+	assert.Less(t, time.Since(start), 3*time.Second)
+}
+
+func TestShouldReportInvalidHookOutput(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	writeHook(t, dir, hook_interfaces.PreUpdate, "echo not-json", 0755)
+	runner := NewScriptRunner(dir, time.Second)
+
+	// Act
+	_, err := runner.Run(hook_interfaces.PreUpdate, &entity.Todo{ID: "1"})
+
+	// Assert
+	assert.ErrorContains(t, err, "hook pre-update wrote invalid JSON")
+}
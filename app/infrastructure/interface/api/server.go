@@ -0,0 +1,229 @@
+package api
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	app_interfaces "codecademy-yellowbelt2/infrastructure/interface/application"
+	hook_interfaces "codecademy-yellowbelt2/infrastructure/interface/hook"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultHeartbeat é o intervalo dos comentários enviados para manter a
+// conexão SSE aberta através de proxies
+const DefaultHeartbeat = 15 * time.Second
+
+// Server expõe as tarefas por HTTP: leitura e edição em /todos/{id}, com a
+// versão no ETag, e o stream de alterações em /events (Server-Sent Events)
+type Server struct {
+	todoUseCase app_interfaces.ITodoUseCase
+	heartbeat   time.Duration
+}
+
+type Option func(*Server)
+
+// WithHeartbeat define o intervalo dos comentários de keep-alive do /events
+func WithHeartbeat(interval time.Duration) Option {
+	return func(s *Server) {
+		s.heartbeat = interval
+	}
+}
+
+func NewServer(todoUseCase app_interfaces.ITodoUseCase, opts ...Option) *Server {
+	s := &Server{
+		todoUseCase: todoUseCase,
+		heartbeat:   DefaultHeartbeat,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /todos/{id}", s.getTodo)
+	mux.HandleFunc("PATCH /todos/{id}", s.updateTodo)
+	mux.HandleFunc("GET /events", s.events)
+	return mux
+}
+
+// todoPatch são os campos aceitos no PATCH; vazios ficam como estão
+type todoPatch struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+func (p todoPatch) validate() error {
+	if p.Title == "" && p.Description == "" {
+		return errors.New("nothing to update: provide title or description")
+	}
+	if p.Title != "" && strings.TrimSpace(p.Title) == "" {
+		return errors.New("title cannot be blank")
+	}
+	return nil
+}
+
+func (s *Server) getTodo(w http.ResponseWriter, r *http.Request) {
+	todo, err := s.todoUseCase.GetTodoByID(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeTodo(w, http.StatusOK, todo)
+}
+
+// updateTodo altera título e descrição; com If-Match a alteração só acontece
+// se a tarefa ainda estiver na versão do ETag, senão responde 412 com o ETag
+// atual para que o cliente recarregue e tente de novo ou mescle. Um hook que
+// veta a alteração responde 422
+func (s *Server) updateTodo(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	var patch todoPatch
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		http.Error(w, fmt.Sprintf("invalid body: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err := patch.validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	version, conditional, err := parseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := s.todoUseCase.GetTodoByID(id); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	var todo *entity.Todo
+	if conditional {
+		todo, err = s.todoUseCase.UpdateTodoIfVersion(id, version, patch.Title, patch.Description)
+	} else {
+		todo, err = s.todoUseCase.UpdateTodo(id, patch.Title, patch.Description)
+	}
+	if err != nil {
+		var conflict *entity.VersionConflictError
+		var rejected *hook_interfaces.HookRejectedError
+		switch {
+		case errors.As(err, &conflict):
+			w.Header().Set("ETag", etag(conflict.Current))
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		case errors.As(err, &rejected):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		case s.missing(id):
+			// Removida por outro processo depois da verificação acima
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	writeTodo(w, http.StatusOK, todo)
+}
+
+// missing indica se a tarefa não existe mais; os repositórios não têm um erro
+// próprio para isso, então a tarefa é procurada de novo
+func (s *Server) missing(id string) bool {
+	_, err := s.todoUseCase.GetTodoByID(id)
+	return err != nil
+}
+
+func writeTodo(w http.ResponseWriter, status int, todo *entity.Todo) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", etag(todo.Version))
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(todo)
+}
+
+func etag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// parseIfMatch lê a versão de um If-Match com um único ETag; ausente ou "*"
+// não restringem a alteração
+func parseIfMatch(header string) (version int, conditional bool, err error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, false, nil
+	}
+	unquoted, err := strconv.Unquote(header)
+	if err == nil {
+		version, err = strconv.Atoi(unquoted)
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid If-Match %s: expected a single ETag like %s", header, etag(1))
+	}
+	return version, true, nil
+}
+
+// events envia cada alteração como um evento SSE cujo nome é o tipo do
+// evento de domínio; ?types=a,b restringe os tipos enviados
+func (s *Server) events(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	var types []string
+	if filter := r.URL.Query().Get("types"); filter != "" {
+		types = strings.Split(filter, ",")
+	}
+
+	changes, err := s.todoUseCase.WatchTodos(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(s.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		case event, open := <-changes:
+			if !open {
+				return
+			}
+			if len(types) > 0 && !slices.Contains(types, event.Type) {
+				continue
+			}
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event *entity.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if event.Sequence > 0 {
+		fmt.Fprintf(w, "id: %d\n", event.Sequence)
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
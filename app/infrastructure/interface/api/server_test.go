@@ -0,0 +1,260 @@
+package api
+
+import (
+	"bufio"
+	"codecademy-yellowbelt2/core/application"
+	"codecademy-yellowbelt2/core/domain/entity"
+	app_interfaces "codecademy-yellowbelt2/infrastructure/interface/application"
+	hookMock "codecademy-yellowbelt2/infrastructure/interface/hook"
+	"codecademy-yellowbelt2/infrastructure/repository"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// readSSE lê do stream até encontrar n eventos, ignorando comentários
+func readSSE(t *testing.T, reader *bufio.Reader, n int) []map[string]string {
+	var events []map[string]string
+	current := map[string]string{}
+	for len(events) < n {
+		line, err := reader.ReadString('\n')
+		if !assert.NoError(t, err) {
+			return events
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "":
+			if len(current) > 0 {
+				events = append(events, current)
+				current = map[string]string{}
+			}
+		case strings.HasPrefix(line, ":"):
+		default:
+			field, value, _ := strings.Cut(line, ": ")
+			current[field] = value
+		}
+	}
+	return events
+}
+
+func TestShouldStreamChangesAsServerSentEvents(t *testing.T) {
+	// Arrange
+	useCase := application.NewTodoUseCase(repository.NewInMemoryTodoRepository())
+	server := httptest.NewServer(NewServer(useCase).Handler())
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/events?types=todo.created,todo.completed")
+	assert.NoError(t, err)
+	defer response.Body.Close()
+	reader := bufio.NewReader(response.Body)
+	reader.ReadString('\n') // Comentário inicial: a assinatura já está ativa
+
+	// Act
+	todo, _ := useCase.CreateTodo("Streamed", "")
+	useCase.UpdateTodo(todo.ID, "Renamed", "")
+	useCase.CompleteTodo(todo.ID)
+	events := readSSE(t, reader, 2)
+
+	// Assert
+	assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+	assert.Equal(t, entity.EventTodoCreated, events[0]["event"])
+	assert.Equal(t, entity.EventTodoCompleted, events[1]["event"])
+	var completed entity.Event
+	assert.NoError(t, json.Unmarshal([]byte(events[1]["data"]), &completed))
+	assert.Equal(t, "Renamed", completed.Todo.Title)
+	assert.True(t, completed.Todo.Completed)
+}
+
+func TestShouldSendHeartbeatComments(t *testing.T) {
+	// Arrange
+	useCase := application.NewTodoUseCase(repository.NewInMemoryTodoRepository())
+	server := httptest.NewServer(NewServer(useCase, WithHeartbeat(10*time.Millisecond)).Handler())
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/events")
+	assert.NoError(t, err)
+	defer response.Body.Close()
+	reader := bufio.NewReader(response.Body)
+
+	// Act
+	reader.ReadString('\n')
+	reader.ReadString('\n')
+	ping, err := reader.ReadString('\n')
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, ": ping\n", ping)
+}
+
+func TestShouldReportStoresThatCannotBeWatched(t *testing.T) {
+	// Arrange
+	mockUseCase := new(app_interfaces.MockTodoUseCase)
+	mockUseCase.On("WatchTodos", mock.Anything).Return(nil, errors.New("todo store does not support watching"))
+	recorder := httptest.NewRecorder()
+
+	// Act
+	NewServer(mockUseCase).Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusNotImplemented, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "todo store does not support watching")
+}
+
+func TestShouldReturnTodoWithVersionAsETag(t *testing.T) {
+	// Arrange
+	useCase := application.NewTodoUseCase(repository.NewInMemoryTodoRepository())
+	todo, _ := useCase.CreateTodo("Versioned", "")
+	useCase.UpdateTodo(todo.ID, "Renamed", "")
+	recorder := httptest.NewRecorder()
+
+	// Act
+	NewServer(useCase).Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/todos/"+todo.ID, nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var got entity.Todo
+	if !assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got)) {
+		return
+	}
+	assert.Equal(t, "Renamed", got.Title)
+	assert.Equal(t, etag(got.Version), recorder.Header().Get("ETag"))
+}
+
+func TestShouldUpdateTodoWhenIfMatchIsCurrent(t *testing.T) {
+	// Arrange
+	useCase := application.NewTodoUseCase(repository.NewInMemoryTodoRepository())
+	todo, _ := useCase.CreateTodo("Original", "")
+	request := httptest.NewRequest(http.MethodPatch, "/todos/"+todo.ID, strings.NewReader(`{"title":"Edited"}`))
+	request.Header.Set("If-Match", etag(todo.Version))
+	recorder := httptest.NewRecorder()
+
+	// Act
+	NewServer(useCase).Handler().ServeHTTP(recorder, request)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	got, _ := useCase.GetTodoByID(todo.ID)
+	assert.Equal(t, "Edited", got.Title)
+	assert.Equal(t, etag(got.Version), recorder.Header().Get("ETag"))
+}
+
+func TestShouldRejectStaleIfMatchWithPreconditionFailed(t *testing.T) {
+	// Arrange
+	useCase := application.NewTodoUseCase(repository.NewInMemoryTodoRepository())
+	todo, _ := useCase.CreateTodo("Original", "")
+	stale := etag(todo.Version)
+	current, _ := useCase.UpdateTodo(todo.ID, "Changed elsewhere", "")
+	request := httptest.NewRequest(http.MethodPatch, "/todos/"+todo.ID, strings.NewReader(`{"title":"Edited"}`))
+	request.Header.Set("If-Match", stale)
+	recorder := httptest.NewRecorder()
+
+	// Act
+	NewServer(useCase).Handler().ServeHTTP(recorder, request)
+
+	// Assert
+	assert.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+	assert.Equal(t, etag(current.Version), recorder.Header().Get("ETag"))
+	got, _ := useCase.GetTodoByID(todo.ID)
+	assert.Equal(t, "Changed elsewhere", got.Title)
+}
+
+func TestShouldRejectMalformedIfMatch(t *testing.T) {
+	// Arrange
+	useCase := application.NewTodoUseCase(repository.NewInMemoryTodoRepository())
+	todo, _ := useCase.CreateTodo("Original", "")
+	request := httptest.NewRequest(http.MethodPatch, "/todos/"+todo.ID, strings.NewReader(`{"title":"Edited"}`))
+	request.Header.Set("If-Match", "W/\"1\"")
+	recorder := httptest.NewRecorder()
+
+	// Act
+	NewServer(useCase).Handler().ServeHTTP(recorder, request)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestShouldRejectEmptyOrBlankPatch(t *testing.T) {
+	// Arrange
+	useCase := application.NewTodoUseCase(repository.NewInMemoryTodoRepository())
+	todo, _ := useCase.CreateTodo("Original", "")
+	handler := NewServer(useCase).Handler()
+	empty := httptest.NewRecorder()
+	blank := httptest.NewRecorder()
+
+	// Act
+	handler.ServeHTTP(empty, httptest.NewRequest(http.MethodPatch, "/todos/"+todo.ID, strings.NewReader(`{}`)))
+	handler.ServeHTTP(blank, httptest.NewRequest(http.MethodPatch, "/todos/"+todo.ID, strings.NewReader(`{"title":"  "}`)))
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, empty.Code)
+	assert.Equal(t, http.StatusBadRequest, blank.Code)
+	got, _ := useCase.GetTodoByID(todo.ID)
+	assert.Equal(t, "Original", got.Title)
+}
+
+func TestShouldRejectUpdateVetoedByHookWithUnprocessableEntity(t *testing.T) {
+	// Arrange
+	runner := new(hookMock.MockHookRunner)
+	runner.On("Run", hookMock.PreCreate, mock.Anything).Return(nil, nil)
+	runner.On("Run", hookMock.PostCreate, mock.Anything).Return(nil, nil)
+	runner.On("Run", hookMock.PreUpdate, mock.Anything).Return(nil, &hookMock.HookRejectedError{Hook: hookMock.PreUpdate, Message: "frozen"})
+	useCase := application.NewHookedTodoUseCase(application.NewTodoUseCase(repository.NewInMemoryTodoRepository()), runner)
+	todo, _ := useCase.CreateTodo("Original", "")
+	recorder := httptest.NewRecorder()
+
+	// Act
+	NewServer(useCase).Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPatch, "/todos/"+todo.ID, strings.NewReader(`{"title":"Edited"}`)))
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "frozen")
+}
+
+func TestShouldReturnNotFoundWhenTodoIsDeletedDuringUpdate(t *testing.T) {
+	// Arrange
+	useCase := new(app_interfaces.MockTodoUseCase)
+	useCase.On("GetTodoByID", "1").Return(&entity.Todo{ID: "1", Version: 1}, nil).Once()
+	useCase.On("GetTodoByID", "1").Return(nil, errors.New("todo not found"))
+	useCase.On("UpdateTodo", "1", "Edited", "").Return(nil, errors.New("todo not found"))
+	recorder := httptest.NewRecorder()
+
+	// Act
+	NewServer(useCase).Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPatch, "/todos/1", strings.NewReader(`{"title":"Edited"}`)))
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	useCase.AssertExpectations(t)
+}
+
+func TestShouldReturnInternalErrorWhenStoreFails(t *testing.T) {
+	// Arrange
+	useCase := new(app_interfaces.MockTodoUseCase)
+	useCase.On("GetTodoByID", "1").Return(&entity.Todo{ID: "1", Version: 1}, nil)
+	useCase.On("UpdateTodo", "1", "Edited", "").Return(nil, errors.New("disk full"))
+	recorder := httptest.NewRecorder()
+
+	// Act
+	NewServer(useCase).Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPatch, "/todos/1", strings.NewReader(`{"title":"Edited"}`)))
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
+
+func TestShouldReturnNotFoundForUnknownTodo(t *testing.T) {
+	// Arrange
+	useCase := application.NewTodoUseCase(repository.NewInMemoryTodoRepository())
+	recorder := httptest.NewRecorder()
+
+	// Act
+	NewServer(useCase).Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/todos/missing", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
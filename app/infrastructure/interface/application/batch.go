@@ -0,0 +1,30 @@
+package application
+
+import "codecademy-yellowbelt2/core/domain/entity"
+
+// Batch descreve uma mesma ação aplicada a várias tarefas de uma só vez
+type Batch struct {
+	Action      string
+	IDs         []string
+	Title       string
+	Description string
+	DryRun      bool
+}
+
+// BatchResult é o resultado individual de cada tarefa do lote; Err preenchido
+// indica que apenas aquela tarefa falhou
+type BatchResult struct {
+	ID   string
+	Todo *entity.Todo
+	Err  error
+}
+
+func CountBatchFailures(results []BatchResult) int {
+	failures := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failures++
+		}
+	}
+	return failures
+}
@@ -0,0 +1,55 @@
+package application
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// IBackupUseCase cria, confere e restaura backups completos dos dados
+type IBackupUseCase interface {
+	CreateBackup(to string) (*entity.Backup, error)
+	ListBackups() ([]*entity.Backup, error)
+	VerifyBackup(ref string) (*entity.Backup, error)
+	// RestoreBackup devolve o backup restaurado e o feito antes da troca, com os dados substituídos
+	RestoreBackup(ref string) (restored *entity.Backup, previous *entity.Backup, err error)
+	// AutoBackup cria um backup automático se o último tiver mais de interval
+	// e mantém só os keep mais recentes; devolve nil quando ainda não era hora
+	AutoBackup(interval time.Duration, keep int) (*entity.Backup, error)
+}
+
+type MockBackupUseCase struct {
+	mock.Mock
+}
+
+func (m *MockBackupUseCase) CreateBackup(to string) (*entity.Backup, error) {
+	args := m.Called(to)
+	backup, _ := args.Get(0).(*entity.Backup)
+	return backup, args.Error(1)
+}
+
+func (m *MockBackupUseCase) ListBackups() ([]*entity.Backup, error) {
+	args := m.Called()
+	backups, _ := args.Get(0).([]*entity.Backup)
+	return backups, args.Error(1)
+}
+
+func (m *MockBackupUseCase) VerifyBackup(ref string) (*entity.Backup, error) {
+	args := m.Called(ref)
+	backup, _ := args.Get(0).(*entity.Backup)
+	return backup, args.Error(1)
+}
+
+func (m *MockBackupUseCase) RestoreBackup(ref string) (*entity.Backup, *entity.Backup, error) {
+	args := m.Called(ref)
+	restored, _ := args.Get(0).(*entity.Backup)
+	previous, _ := args.Get(1).(*entity.Backup)
+	return restored, previous, args.Error(2)
+}
+
+func (m *MockBackupUseCase) AutoBackup(interval time.Duration, keep int) (*entity.Backup, error) {
+	args := m.Called(interval, keep)
+	backup, _ := args.Get(0).(*entity.Backup)
+	return backup, args.Error(1)
+}
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// IContextUseCase gerencia os contextos (áreas de trabalho com tarefas
+// separadas) e move tarefas entre eles
+type IContextUseCase interface {
+	ListContexts() ([]string, error)
+	CurrentContext() (string, error)
+	CreateContext(name string) error
+	DeleteContext(name string, force bool) error
+	RenameContext(oldName, newName string) error
+	UseContext(name string) error
+	MoveTodo(id, to string) (*entity.Todo, error)
+}
+
+type MockContextUseCase struct {
+	mock.Mock
+}
+
+func (m *MockContextUseCase) ListContexts() ([]string, error) {
+	args := m.Called()
+	names, _ := args.Get(0).([]string)
+	return names, args.Error(1)
+}
+
+func (m *MockContextUseCase) CurrentContext() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockContextUseCase) CreateContext(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func (m *MockContextUseCase) DeleteContext(name string, force bool) error {
+	args := m.Called(name, force)
+	return args.Error(0)
+}
+
+func (m *MockContextUseCase) RenameContext(oldName, newName string) error {
+	args := m.Called(oldName, newName)
+	return args.Error(0)
+}
+
+func (m *MockContextUseCase) UseContext(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func (m *MockContextUseCase) MoveTodo(id, to string) (*entity.Todo, error) {
+	args := m.Called(id, to)
+	todo, _ := args.Get(0).(*entity.Todo)
+	return todo, args.Error(1)
+}
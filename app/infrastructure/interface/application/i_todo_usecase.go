@@ -2,6 +2,9 @@ package application
 
 import (
 	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -11,8 +14,27 @@ type ITodoUseCase interface {
 	GetTodoByID(id string) (*entity.Todo, error)
 	GetAllTodos() ([]*entity.Todo, error)
 	UpdateTodo(id, title, description string) (*entity.Todo, error)
+	UpdateTodoIfVersion(id string, version int, title, description string) (*entity.Todo, error)
 	CompleteTodo(id string) (*entity.Todo, error)
+	ReopenTodo(id string) (*entity.Todo, error)
 	DeleteTodo(id string) error
+	GetDeletedTodos() ([]*entity.Todo, error)
+	RestoreTodo(id string) (*entity.Todo, error)
+	PurgeTodos(olderThan time.Duration) ([]*entity.Todo, error)
+	ArchiveTodos(completedBefore time.Time) ([]*entity.Todo, error)
+	GetArchivedTodos() ([]*entity.Todo, error)
+	UnarchiveTodo(id string) (*entity.Todo, error)
+	AssignTodo(id string, assignees ...string) (*entity.Todo, error)
+	UnassignTodo(id string, assignees ...string) (*entity.Todo, error)
+	GetTodosByAssignee(assignee string) ([]*entity.Todo, error)
+	FilterTodos(filter entity.TodoFilter) ([]*entity.Todo, error)
+	RunBatch(batch Batch) ([]BatchResult, error)
+	ImportTodos(batch Import) ([]ImportResult, error)
+	GetTodoHistory(id string) ([]*entity.HistoryEntry, error)
+	Undo() (*entity.Operation, error)
+	Redo() (*entity.Operation, error)
+	CompactStore() (repository.CompactResult, error)
+	WatchTodos(ctx context.Context) (<-chan *entity.Event, error)
 }
 
 type MockTodoUseCase struct {
@@ -43,13 +65,134 @@ func (m *MockTodoUseCase) UpdateTodo(id, title, description string) (*entity.Tod
 	return todo, args.Error(1)
 }
 
+func (m *MockTodoUseCase) UpdateTodoIfVersion(id string, version int, title, description string) (*entity.Todo, error) {
+	args := m.Called(id, version, title, description)
+	todo, _ := args.Get(0).(*entity.Todo)
+	return todo, args.Error(1)
+}
+
 func (m *MockTodoUseCase) CompleteTodo(id string) (*entity.Todo, error) {
 	args := m.Called(id)
 	todo, _ := args.Get(0).(*entity.Todo)
 	return todo, args.Error(1)
 }
 
+func (m *MockTodoUseCase) ReopenTodo(id string) (*entity.Todo, error) {
+	args := m.Called(id)
+	todo, _ := args.Get(0).(*entity.Todo)
+	return todo, args.Error(1)
+}
+
 func (m *MockTodoUseCase) DeleteTodo(id string) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
+
+func (m *MockTodoUseCase) GetDeletedTodos() ([]*entity.Todo, error) {
+	args := m.Called()
+	todos, _ := args.Get(0).([]*entity.Todo)
+	return todos, args.Error(1)
+}
+
+func (m *MockTodoUseCase) RestoreTodo(id string) (*entity.Todo, error) {
+	args := m.Called(id)
+	todo, _ := args.Get(0).(*entity.Todo)
+	return todo, args.Error(1)
+}
+
+func (m *MockTodoUseCase) PurgeTodos(olderThan time.Duration) ([]*entity.Todo, error) {
+	args := m.Called(olderThan)
+	todos, _ := args.Get(0).([]*entity.Todo)
+	return todos, args.Error(1)
+}
+
+func (m *MockTodoUseCase) ArchiveTodos(completedBefore time.Time) ([]*entity.Todo, error) {
+	args := m.Called(completedBefore)
+	todos, _ := args.Get(0).([]*entity.Todo)
+	return todos, args.Error(1)
+}
+
+func (m *MockTodoUseCase) GetArchivedTodos() ([]*entity.Todo, error) {
+	args := m.Called()
+	todos, _ := args.Get(0).([]*entity.Todo)
+	return todos, args.Error(1)
+}
+
+func (m *MockTodoUseCase) UnarchiveTodo(id string) (*entity.Todo, error) {
+	args := m.Called(id)
+	todo, _ := args.Get(0).(*entity.Todo)
+	return todo, args.Error(1)
+}
+
+func (m *MockTodoUseCase) AssignTodo(id string, assignees ...string) (*entity.Todo, error) {
+	args := m.Called(id, assignees)
+	todo, _ := args.Get(0).(*entity.Todo)
+	return todo, args.Error(1)
+}
+
+func (m *MockTodoUseCase) UnassignTodo(id string, assignees ...string) (*entity.Todo, error) {
+	args := m.Called(id, assignees)
+	todo, _ := args.Get(0).(*entity.Todo)
+	return todo, args.Error(1)
+}
+
+func (m *MockTodoUseCase) GetTodosByAssignee(assignee string) ([]*entity.Todo, error) {
+	args := m.Called(assignee)
+	todos, _ := args.Get(0).([]*entity.Todo)
+	return todos, args.Error(1)
+}
+
+func (m *MockTodoUseCase) FilterTodos(filter entity.TodoFilter) ([]*entity.Todo, error) {
+	args := m.Called(filter)
+	todos, _ := args.Get(0).([]*entity.Todo)
+	return todos, args.Error(1)
+}
+
+func (m *MockTodoUseCase) RunBatch(batch Batch) ([]BatchResult, error) {
+	args := m.Called(batch)
+	results, _ := args.Get(0).([]BatchResult)
+	return results, args.Error(1)
+}
+
+func (m *MockTodoUseCase) ImportTodos(batch Import) ([]ImportResult, error) {
+	args := m.Called(batch)
+	results, _ := args.Get(0).([]ImportResult)
+	return results, args.Error(1)
+}
+
+func (m *MockTodoUseCase) GetTodoHistory(id string) ([]*entity.HistoryEntry, error) {
+	args := m.Called(id)
+	entries, _ := args.Get(0).([]*entity.HistoryEntry)
+	return entries, args.Error(1)
+}
+
+func (m *MockTodoUseCase) Undo() (*entity.Operation, error) {
+	args := m.Called()
+	operation, _ := args.Get(0).(*entity.Operation)
+	return operation, args.Error(1)
+}
+
+func (m *MockTodoUseCase) Redo() (*entity.Operation, error) {
+	args := m.Called()
+	operation, _ := args.Get(0).(*entity.Operation)
+	return operation, args.Error(1)
+}
+
+func (m *MockTodoUseCase) CompactStore() (repository.CompactResult, error) {
+	args := m.Called()
+	result, _ := args.Get(0).(repository.CompactResult)
+	return result, args.Error(1)
+}
+
+func (m *MockTodoUseCase) WatchTodos(ctx context.Context) (<-chan *entity.Event, error) {
+	args := m.Called(ctx)
+	changes, _ := args.Get(0).(<-chan *entity.Event)
+	return changes, args.Error(1)
+}
+
+// IHookToggle é implementado pelos use cases que executam hooks e permite
+// desligá-los em uma invocação (ou em uma linha do shell)
+type IHookToggle interface {
+	DisableHooks()
+	EnableHooks()
+}
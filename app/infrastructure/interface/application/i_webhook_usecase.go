@@ -0,0 +1,40 @@
+package application
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type IWebhookUseCase interface {
+	AddWebhook(url string, events []string, secret string) (*entity.Webhook, error)
+	ListWebhooks() ([]*entity.Webhook, error)
+	RemoveWebhook(id string) error
+	TestWebhook(id string) error
+}
+
+type MockWebhookUseCase struct {
+	mock.Mock
+}
+
+func (m *MockWebhookUseCase) AddWebhook(url string, events []string, secret string) (*entity.Webhook, error) {
+	args := m.Called(url, events, secret)
+	webhook, _ := args.Get(0).(*entity.Webhook)
+	return webhook, args.Error(1)
+}
+
+func (m *MockWebhookUseCase) ListWebhooks() ([]*entity.Webhook, error) {
+	args := m.Called()
+	webhooks, _ := args.Get(0).([]*entity.Webhook)
+	return webhooks, args.Error(1)
+}
+
+func (m *MockWebhookUseCase) RemoveWebhook(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookUseCase) TestWebhook(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,38 @@
+package application
+
+import "codecademy-yellowbelt2/core/domain/entity"
+
+// Resultado de cada tarefa importada
+const (
+	ImportCreated   = "created"
+	ImportUpdated   = "updated"
+	ImportUnchanged = "unchanged"
+)
+
+// Import descreve tarefas lidas de um arquivo para gravar de uma só vez;
+// tarefas sem ID recebem um novo, a menos que MatchTitle encontre uma
+// existente com o mesmo título
+type Import struct {
+	Todos      []*entity.Todo
+	MatchTitle bool
+	DryRun     bool
+}
+
+// ImportResult é o resultado individual de cada tarefa importada; Err
+// preenchido indica que apenas aquela tarefa falhou
+type ImportResult struct {
+	Todo   *entity.Todo
+	Action string
+	Err    error
+}
+
+// CountImportResults conta os resultados com a ação informada
+func CountImportResults(results []ImportResult, action string) int {
+	count := 0
+	for _, result := range results {
+		if result.Err == nil && result.Action == action {
+			count++
+		}
+	}
+	return count
+}
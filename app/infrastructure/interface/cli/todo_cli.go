@@ -1,36 +1,166 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"codecademy-yellowbelt2/core/domain/entity"
 	app_interfaces "codecademy-yellowbelt2/infrastructure/interface/application"
+	"codecademy-yellowbelt2/infrastructure/interface/transfer"
 )
 
 type TodoCLI struct {
-	todoUseCase app_interfaces.ITodoUseCase
+	todoUseCase    app_interfaces.ITodoUseCase
+	webhookUseCase app_interfaces.IWebhookUseCase
+	contextUseCase app_interfaces.IContextUseCase
+	backupUseCase  app_interfaces.IBackupUseCase
+	formats        map[string]transfer.ITodoFormat
+	currentUser    string
+	shellHistory   string
+	outputFormat   string
+	dateFormat     string
+	inShell        bool
+	listed         []string // IDs da última listagem, na ordem numerada
+
+	autoArchiveAfter   time.Duration
+	autoBackupInterval time.Duration
+	autoBackupKeep     int
+	mutated            bool
+}
+
+type Option func(*TodoCLI)
+
+// WithCurrentUser define quem é "me" nos comandos que aceitam responsáveis
+func WithCurrentUser(name string) Option {
+	return func(cli *TodoCLI) {
+		cli.currentUser = name
+	}
+}
+
+// WithWebhooks habilita os comandos de gerenciamento de webhooks
+func WithWebhooks(webhookUseCase app_interfaces.IWebhookUseCase) Option {
+	return func(cli *TodoCLI) {
+		cli.webhookUseCase = webhookUseCase
+	}
 }
 
-func NewTodoCLI(todoUseCase app_interfaces.ITodoUseCase) *TodoCLI {
-	return &TodoCLI{
-		todoUseCase: todoUseCase,
+// WithContexts habilita os comandos de contexto e o "todo move"
+func WithContexts(contextUseCase app_interfaces.IContextUseCase) Option {
+	return func(cli *TodoCLI) {
+		cli.contextUseCase = contextUseCase
 	}
 }
 
+// WithBackups habilita os comandos de backup
+func WithBackups(backupUseCase app_interfaces.IBackupUseCase) Option {
+	return func(cli *TodoCLI) {
+		cli.backupUseCase = backupUseCase
+	}
+}
+
+// WithFormat registra um formato de arquivo aceito por import e export
+func WithFormat(name string, format transfer.ITodoFormat) Option {
+	return func(cli *TodoCLI) {
+		if cli.formats == nil {
+			cli.formats = make(map[string]transfer.ITodoFormat)
+		}
+		cli.formats[name] = format
+	}
+}
+
+// WithShellHistory define o arquivo do histórico do "todo shell"
+func WithShellHistory(filename string) Option {
+	return func(cli *TodoCLI) {
+		cli.shellHistory = filename
+	}
+}
+
+// WithOutputFormat define o formato padrão de --output (text ou json)
+func WithOutputFormat(format string) Option {
+	return func(cli *TodoCLI) {
+		cli.outputFormat = format
+	}
+}
+
+// WithDateFormat define o layout (no formato do pacote time) das datas exibidas
+func WithDateFormat(layout string) Option {
+	return func(cli *TodoCLI) {
+		cli.dateFormat = layout
+	}
+}
+
+func NewTodoCLI(todoUseCase app_interfaces.ITodoUseCase, opts ...Option) *TodoCLI {
+	cli := &TodoCLI{
+		todoUseCase:  todoUseCase,
+		outputFormat: outputText,
+		dateFormat:   defaultDateFormat,
+	}
+	for _, opt := range opts {
+		opt(cli)
+	}
+	return cli
+}
+
 func (cli *TodoCLI) GetRootCommand() *cobra.Command {
+	// Os grupos (context, webhook, backup) têm PersistentPreRunE próprio; sem
+	// isto, o da raiz deixaria de rodar nos subcomandos deles
+	cobra.EnableTraverseRunHooks = true
+
 	rootCmd := &cobra.Command{
 		Use:   "todo",
 		Short: "Todo List CLI - Gerenciador de tarefas",
 		Long:  "Uma ferramenta de linha de comando para gerenciar sua lista de tarefas",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if noHooks, _ := cmd.Flags().GetBool("no-hooks"); noHooks {
+				if toggle, ok := cli.todoUseCase.(app_interfaces.IHookToggle); ok {
+					toggle.DisableHooks()
+				}
+			}
+			if _, err := outputFormat(cmd); err != nil {
+				return err
+			}
+			cli.beforeCommand(cmd)
+			return nil
+		},
 	}
 
+	rootCmd.PersistentFlags().Bool("no-hooks", false, "Não executar os hooks de ~/.todo-cli/hooks nesta invocação")
+	rootCmd.PersistentFlags().StringP("output", "o", cli.outputFormat, "Formato de saída de list e show (text ou json)")
+
 	rootCmd.AddCommand(cli.createCommand())
 	rootCmd.AddCommand(cli.listCommand())
 	rootCmd.AddCommand(cli.showCommand())
 	rootCmd.AddCommand(cli.updateCommand())
 	rootCmd.AddCommand(cli.completeCommand())
 	rootCmd.AddCommand(cli.deleteCommand())
+	rootCmd.AddCommand(cli.assignCommand())
+	rootCmd.AddCommand(cli.historyCommand())
+	rootCmd.AddCommand(cli.undoCommand())
+	rootCmd.AddCommand(cli.redoCommand())
+	rootCmd.AddCommand(cli.trashCommand())
+	rootCmd.AddCommand(cli.archiveCommand())
+	rootCmd.AddCommand(cli.unarchiveCommand())
+	rootCmd.AddCommand(cli.storeCommand())
+	rootCmd.AddCommand(cli.webhookCommand())
+	rootCmd.AddCommand(cli.contextCommand())
+	rootCmd.AddCommand(cli.moveCommand())
+	rootCmd.AddCommand(cli.importCommand())
+	rootCmd.AddCommand(cli.exportCommand())
+	rootCmd.AddCommand(cli.backupCommand())
+	rootCmd.AddCommand(cli.watchCommand())
+	rootCmd.AddCommand(cli.serveCommand())
+	rootCmd.AddCommand(cli.tuiCommand())
+	rootCmd.AddCommand(cli.shellCommand())
+	rootCmd.AddCommand(cli.completionCommand())
+
+	// O comando completion acima substitui o padrão do cobra
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	cli.registerCompletions(rootCmd)
 
 	return rootCmd
 }
@@ -64,16 +194,37 @@ func (cli *TodoCLI) createCommand() *cobra.Command {
 }
 
 func (cli *TodoCLI) listCommand() *cobra.Command {
-	return &cobra.Command{
+	var assignee string
+	var archived bool
+
+	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "Listar todas as tarefas",
 		Run: func(cmd *cobra.Command, args []string) {
-			todos, err := cli.todoUseCase.GetAllTodos()
+			var todos []*entity.Todo
+			var err error
+			if archived {
+				todos, err = cli.todoUseCase.GetArchivedTodos()
+			} else if cmd.Flags().Changed("assignee") {
+				name, resolveErr := cli.resolveAssignee(assignee)
+				if resolveErr != nil {
+					fmt.Printf("Erro ao listar tarefas: %v\n", resolveErr)
+					return
+				}
+				todos, err = cli.todoUseCase.GetTodosByAssignee(name)
+			} else {
+				todos, err = cli.todoUseCase.GetAllTodos()
+			}
 			if err != nil {
 				fmt.Printf("Erro ao listar tarefas: %v\n", err)
 				return
 			}
 
+			cli.remember(todos)
+			if wantsJSON(cmd) {
+				printJSON(nonNil(todos))
+				return
+			}
 			if len(todos) == 0 {
 				fmt.Println("📝 Nenhuma tarefa encontrada!")
 				return
@@ -89,11 +240,22 @@ func (cli *TodoCLI) listCommand() *cobra.Command {
 				if todo.Description != "" {
 					fmt.Printf("   📄 %s\n", todo.Description)
 				}
+				if len(todo.Assignees) > 0 {
+					fmt.Printf("   👤 %s\n", strings.Join(todo.Assignees, ", "))
+				}
+				if labels := cli.todoLabels(todo); labels != "" {
+					fmt.Printf("   📌 %s\n", labels)
+				}
 				fmt.Printf("   🆔 ID: %s\n", todo.ID)
 				fmt.Println()
 			}
 		},
 	}
+
+	cmd.Flags().StringVar(&assignee, "assignee", "", "Filtrar por responsável (me, nome ou none)")
+	cmd.Flags().BoolVar(&archived, "archived", false, "Listar as tarefas arquivadas")
+
+	return cmd
 }
 
 func (cli *TodoCLI) showCommand() *cobra.Command {
@@ -109,6 +271,10 @@ func (cli *TodoCLI) showCommand() *cobra.Command {
 				fmt.Printf("❌ Tarefa não encontrada: %v\n", err)
 				return
 			}
+			if wantsJSON(cmd) {
+				printJSON(todo)
+				return
+			}
 
 			status := "⏳ Pendente"
 			if todo.Completed {
@@ -121,18 +287,81 @@ func (cli *TodoCLI) showCommand() *cobra.Command {
 				fmt.Printf("📄 Descrição: %s\n", todo.Description)
 			}
 			fmt.Printf("📊 Status: %s\n", status)
-			fmt.Printf("📅 Criada em: %s\n", todo.CreatedAt.Format("02/01/2006 15:04"))
-			fmt.Printf("🔄 Atualizada em: %s\n", todo.UpdatedAt.Format("02/01/2006 15:04"))
+			if len(todo.Assignees) > 0 {
+				fmt.Printf("👤 Responsáveis: %s\n", strings.Join(todo.Assignees, ", "))
+			}
+			if todo.Priority != "" {
+				fmt.Printf("🔺 Prioridade: %s\n", todo.Priority)
+			}
+			if len(todo.Projects) > 0 {
+				fmt.Printf("📁 Projetos: %s\n", strings.Join(todo.Projects, ", "))
+			}
+			if len(todo.Tags) > 0 {
+				fmt.Printf("🏷️  Tags: %s\n", strings.Join(todo.Tags, ", "))
+			}
+			if todo.DueDate != nil {
+				fmt.Printf("📆 Prazo: %s\n", cli.formatDay(*todo.DueDate))
+			}
+			fmt.Printf("📅 Criada em: %s\n", cli.formatDate(todo.CreatedAt))
+			fmt.Printf("🔄 Atualizada em: %s\n", cli.formatDate(todo.UpdatedAt))
+			fmt.Printf("🔢 Versão: %d\n", todo.Version)
+			if todo.ArchivedAt != nil {
+				fmt.Printf("📦 Arquivada em: %s\n", cli.formatDate(*todo.ArchivedAt))
+			}
 		},
 	}
 }
 
 func (cli *TodoCLI) updateCommand() *cobra.Command {
-	return &cobra.Command{
+	var selection bulkSelection
+	var title, description string
+	var ifVersion int
+
+	// Com --title ou --description, todos os argumentos posicionais são IDs
+	byFlags := func(cmd *cobra.Command) bool {
+		return cmd.Flags().Changed("title") || cmd.Flags().Changed("description")
+	}
+
+	cmd := &cobra.Command{
 		Use:   "update [id] [title] [description]",
-		Short: "Atualizar uma tarefa existente",
-		Args:  cobra.RangeArgs(2, 3),
-		Run: func(cmd *cobra.Command, args []string) {
+		Short: "Atualizar uma ou mais tarefas existentes",
+		Long: "Atualiza uma tarefa pelos argumentos posicionais (update <id> <título> [descrição]) ou várias\n" +
+			"tarefas com --title e/ou --description, escolhidas por IDs (update <id>... --title X), filtros ou --stdin.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if byFlags(cmd) {
+				return nil
+			}
+			return selection.args(cobra.RangeArgs(2, 3))(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if selection.isBulk() || byFlags(cmd) {
+				if cmd.Flags().Changed("if-version") {
+					fmt.Println("❌ --if-version só pode ser usado ao atualizar uma única tarefa")
+					return nil
+				}
+				if title == "" && description == "" {
+					fmt.Println("❌ Informe --title e/ou --description para atualizar em lote")
+					return nil
+				}
+				if len(args) == 0 && !selection.isBulk() {
+					fmt.Println("❌ Informe os IDs das tarefas, filtros ou --stdin")
+					return nil
+				}
+				// Um argumento que não é tarefa costuma ser um título posicional
+				// misturado com as flags; nada é alterado nesse caso
+				for _, id := range args {
+					if _, err := cli.todoUseCase.GetTodoByID(id); err != nil {
+						fmt.Printf("❌ %q não é o ID de uma tarefa (%v): com --title/--description, todos os argumentos são IDs (não misture título ou descrição posicionais com as flags)\n", id, err)
+						return nil
+					}
+				}
+				return cli.runBatch(cmd, app_interfaces.Batch{
+					Action:      entity.OperationUpdate,
+					Title:       title,
+					Description: description,
+				}, args, &selection)
+			}
+
 			id := args[0]
 			title := args[1]
 			description := ""
@@ -140,10 +369,20 @@ func (cli *TodoCLI) updateCommand() *cobra.Command {
 				description = args[2]
 			}
 
-			todo, err := cli.todoUseCase.UpdateTodo(id, title, description)
+			var todo *entity.Todo
+			var err error
+			if cmd.Flags().Changed("if-version") {
+				todo, err = cli.todoUseCase.UpdateTodoIfVersion(id, ifVersion, title, description)
+			} else {
+				todo, err = cli.todoUseCase.UpdateTodo(id, title, description)
+			}
 			if err != nil {
+				if errors.Is(err, entity.ErrVersionConflict) {
+					printVersionConflict(err)
+					return nil
+				}
 				fmt.Printf("❌ Erro ao atualizar tarefa: %v\n", err)
-				return
+				return nil
 			}
 
 			fmt.Printf("✅ Tarefa atualizada com sucesso!\n")
@@ -151,44 +390,452 @@ func (cli *TodoCLI) updateCommand() *cobra.Command {
 			if todo.Description != "" {
 				fmt.Printf("📄 Descrição: %s\n", todo.Description)
 			}
+			fmt.Printf("🔢 Versão: %d\n", todo.Version)
+			return nil
 		},
 	}
+
+	selection.register(cmd)
+	cmd.Flags().StringVar(&title, "title", "", "Novo título (os argumentos passam a ser IDs)")
+	cmd.Flags().StringVar(&description, "description", "", "Nova descrição (os argumentos passam a ser IDs)")
+	cmd.Flags().IntVar(&ifVersion, "if-version", 0, "Atualizar apenas se a tarefa ainda estiver nesta versão")
+
+	return cmd
 }
 
 func (cli *TodoCLI) completeCommand() *cobra.Command {
-	return &cobra.Command{
-		Use:   "complete [id]",
-		Short: "Marcar uma tarefa como concluída",
-		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
+	var selection bulkSelection
+
+	cmd := &cobra.Command{
+		Use:   "complete [id...]",
+		Short: "Marcar uma ou mais tarefas como concluídas",
+		Args:  selection.args(cobra.MinimumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 || selection.isBulk() {
+				return cli.runBatch(cmd, app_interfaces.Batch{Action: entity.OperationComplete}, args, &selection)
+			}
+
 			id := args[0]
 
 			todo, err := cli.todoUseCase.CompleteTodo(id)
 			if err != nil {
 				fmt.Printf("❌ Erro ao completar tarefa: %v\n", err)
-				return
+				return nil
 			}
 
 			fmt.Printf("✅ Tarefa '%s' marcada como concluída!\n", todo.Title)
+			return nil
 		},
 	}
+
+	selection.register(cmd)
+
+	return cmd
 }
 
 func (cli *TodoCLI) deleteCommand() *cobra.Command {
+	var selection bulkSelection
+
+	cmd := &cobra.Command{
+		Use:   "delete [id...]",
+		Short: "Deletar uma ou mais tarefas",
+		Args:  selection.args(cobra.MinimumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 || selection.isBulk() {
+				return cli.runBatch(cmd, app_interfaces.Batch{Action: entity.OperationDelete}, args, &selection)
+			}
+
+			id := args[0]
+
+			err := cli.todoUseCase.DeleteTodo(id)
+			if err != nil {
+				fmt.Printf("❌ Erro ao deletar tarefa: %v\n", err)
+				return nil
+			}
+
+			fmt.Println("🗑️  Tarefa deletada com sucesso!")
+			fmt.Printf("♻️  Para recuperá-la use: todo trash restore %s\n", id)
+			return nil
+		},
+	}
+
+	selection.register(cmd)
+
+	return cmd
+}
+
+func (cli *TodoCLI) trashCommand() *cobra.Command {
+	trashCmd := &cobra.Command{
+		Use:   "trash",
+		Short: "Gerenciar tarefas deletadas",
+	}
+
+	trashCmd.AddCommand(cli.trashListCommand())
+	trashCmd.AddCommand(cli.trashRestoreCommand())
+	trashCmd.AddCommand(cli.trashPurgeCommand())
+
+	return trashCmd
+}
+
+func (cli *TodoCLI) trashListCommand() *cobra.Command {
 	return &cobra.Command{
-		Use:   "delete [id]",
-		Short: "Deletar uma tarefa",
+		Use:   "list",
+		Short: "Listar tarefas na lixeira",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			todos, err := cli.todoUseCase.GetDeletedTodos()
+			if err != nil {
+				fmt.Printf("Erro ao listar lixeira: %v\n", err)
+				return
+			}
+
+			cli.remember(todos)
+			if wantsJSON(cmd) {
+				printJSON(nonNil(todos))
+				return
+			}
+			if len(todos) == 0 {
+				fmt.Println("🗑️  A lixeira está vazia!")
+				return
+			}
+
+			fmt.Printf("🗑️  Tarefas na lixeira: %d\n\n", len(todos))
+			for i, todo := range todos {
+				fmt.Printf("%d. %s\n", i+1, todo.Title)
+				fmt.Printf("   🕒 Deletada em: %s\n", cli.formatDate(*todo.DeletedAt))
+				fmt.Printf("   🆔 ID: %s\n", todo.ID)
+				fmt.Println()
+			}
+		},
+	}
+}
+
+func (cli *TodoCLI) trashRestoreCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore [id]",
+		Short: "Restaurar uma tarefa da lixeira",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			id := args[0]
 
-			err := cli.todoUseCase.DeleteTodo(id)
+			todo, err := cli.todoUseCase.RestoreTodo(id)
 			if err != nil {
-				fmt.Printf("❌ Erro ao deletar tarefa: %v\n", err)
+				fmt.Printf("❌ Erro ao restaurar tarefa: %v\n", err)
 				return
 			}
 
-			fmt.Println("🗑️  Tarefa deletada com sucesso!")
+			fmt.Printf("♻️  Tarefa '%s' restaurada com sucesso!\n", todo.Title)
+		},
+	}
+}
+
+func (cli *TodoCLI) trashPurgeCommand() *cobra.Command {
+	var olderThan string
+
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Remover definitivamente as tarefas da lixeira",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			var age time.Duration
+			if olderThan != "" {
+				parsed, err := parseAge(olderThan)
+				if err != nil {
+					fmt.Printf("❌ Valor inválido para --older-than: %v\n", err)
+					return
+				}
+				age = parsed
+			}
+
+			purged, err := cli.todoUseCase.PurgeTodos(age)
+			if err != nil {
+				fmt.Printf("❌ Erro ao esvaziar lixeira: %v\n", err)
+				return
+			}
+
+			fmt.Printf("🔥 %d tarefa(s) removida(s) definitivamente\n", len(purged))
+		},
+	}
+
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "Remover apenas tarefas deletadas há mais tempo que isso (ex: 30d, 12h)")
+
+	return cmd
+}
+
+func (cli *TodoCLI) archiveCommand() *cobra.Command {
+	var completedBefore string
+
+	cmd := &cobra.Command{
+		Use:   "archive",
+		Short: "Arquivar tarefas concluídas",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			var cutoff time.Time
+			if completedBefore != "" {
+				parsed, err := parseDate(completedBefore)
+				if err != nil {
+					fmt.Printf("❌ Valor inválido para --completed-before: %v\n", err)
+					return
+				}
+				cutoff = parsed
+			}
+
+			archived, err := cli.todoUseCase.ArchiveTodos(cutoff)
+			if err != nil {
+				fmt.Printf("❌ Erro ao arquivar tarefas: %v\n", err)
+				return
+			}
+
+			fmt.Printf("📦 %d tarefa(s) arquivada(s)\n", len(archived))
+		},
+	}
+
+	cmd.Flags().StringVar(&completedBefore, "completed-before", "", "Arquivar apenas tarefas concluídas antes desta data (AAAA-MM-DD)")
+
+	return cmd
+}
+
+func (cli *TodoCLI) unarchiveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unarchive [id]",
+		Short: "Devolver uma tarefa arquivada para a lista",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			id := args[0]
+
+			todo, err := cli.todoUseCase.UnarchiveTodo(id)
+			if err != nil {
+				fmt.Printf("❌ Erro ao desarquivar tarefa: %v\n", err)
+				return
+			}
+
+			fmt.Printf("📤 Tarefa '%s' desarquivada com sucesso!\n", todo.Title)
+		},
+	}
+}
+
+// parseDate aceita datas no formato ISO (2006-01-02) ou no formato exibido pela CLI (02/01/2006)
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "02/01/2006"} {
+		if date, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("data inválida: %s", value)
+}
+
+// parseAge aceita as unidades de time.ParseDuration e também dias (ex: 30d)
+func parseAge(value string) (time.Duration, error) {
+	if days, found := strings.CutSuffix(value, "d"); found {
+		count, err := strconv.Atoi(days)
+		if err != nil || count < 0 {
+			return 0, fmt.Errorf("duração inválida: %s", value)
+		}
+		return time.Duration(count) * 24 * time.Hour, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("duração inválida: %s", value)
+	}
+	return duration, nil
+}
+
+func (cli *TodoCLI) assignCommand() *cobra.Command {
+	var remove bool
+
+	cmd := &cobra.Command{
+		Use:   "assign [id] [assignee...]",
+		Short: "Atribuir responsáveis a uma tarefa",
+		Long:  "Atribui (ou remove, com --remove) responsáveis de uma tarefa. Use \"me\" para o usuário atual.",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			id := args[0]
+
+			names := make([]string, 0, len(args)-1)
+			for _, arg := range args[1:] {
+				name, err := cli.resolveAssignee(arg)
+				if err != nil || name == "" {
+					fmt.Printf("❌ Responsável inválido: %s\n", arg)
+					return
+				}
+				names = append(names, name)
+			}
+
+			var todo *entity.Todo
+			var err error
+			if remove {
+				todo, err = cli.todoUseCase.UnassignTodo(id, names...)
+			} else {
+				todo, err = cli.todoUseCase.AssignTodo(id, names...)
+			}
+			if err != nil {
+				fmt.Printf("❌ Erro ao atribuir tarefa: %v\n", err)
+				return
+			}
+
+			if len(todo.Assignees) == 0 {
+				fmt.Printf("👤 Tarefa '%s' está sem responsáveis\n", todo.Title)
+				return
+			}
+			fmt.Printf("👤 Responsáveis de '%s': %s\n", todo.Title, strings.Join(todo.Assignees, ", "))
+		},
+	}
+
+	cmd.Flags().BoolVar(&remove, "remove", false, "Remover os responsáveis informados")
+
+	return cmd
+}
+
+func (cli *TodoCLI) historyCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "history [id]",
+		Short: "Exibir o histórico de alterações de uma tarefa",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			id := args[0]
+
+			entries, err := cli.todoUseCase.GetTodoHistory(id)
+			if err != nil {
+				fmt.Printf("❌ Erro ao buscar histórico: %v\n", err)
+				return
+			}
+
+			if len(entries) == 0 {
+				fmt.Println("📜 Nenhum histórico encontrado para esta tarefa!")
+				return
+			}
+
+			fmt.Printf("📜 Histórico da tarefa %s\n\n", id)
+			for _, entry := range entries {
+				actor := entry.Actor
+				if actor == "" {
+					actor = "desconhecido"
+				}
+				fmt.Printf("%s  %s por %s\n", cli.formatDate(entry.Timestamp), historyActionLabel(entry.Action), actor)
+				for _, change := range entry.Changes {
+					if change.OldValue != "" {
+						fmt.Printf("   - %s: %s\n", change.Field, change.OldValue)
+					}
+					if change.NewValue != "" {
+						fmt.Printf("   + %s: %s\n", change.Field, change.NewValue)
+					}
+				}
+				fmt.Println()
+			}
+		},
+	}
+}
+
+// printVersionConflict explica ao usuário que outra pessoa alterou a tarefa
+// e como recarregá-la antes de tentar novamente
+func printVersionConflict(err error) {
+	var conflict *entity.VersionConflictError
+	if !errors.As(err, &conflict) {
+		fmt.Printf("⚠️  Conflito de versão: %v\n", err)
+		return
+	}
+	fmt.Printf("⚠️  Conflito de versão: a tarefa %s está na versão %d (você informou %d)\n", conflict.ID, conflict.Current, conflict.Expected)
+	fmt.Printf("💡 Revise as alterações com 'todo show %s' e tente novamente com --if-version %d\n", conflict.ID, conflict.Current)
+}
+
+func historyActionLabel(action string) string {
+	switch action {
+	case entity.HistoryActionCreated:
+		return "✨ Criada"
+	case entity.HistoryActionUpdated:
+		return "✏️  Atualizada"
+	case entity.HistoryActionCompleted:
+		return "✅ Concluída"
+	case entity.HistoryActionDeleted:
+		return "🗑️  Deletada"
+	case entity.HistoryActionRestored:
+		return "♻️  Restaurada"
+	case entity.HistoryActionPurged:
+		return "🔥 Removida definitivamente"
+	case entity.HistoryActionArchived:
+		return "📦 Arquivada"
+	case entity.HistoryActionUnarchived:
+		return "📤 Desarquivada"
+	case entity.HistoryActionMoved:
+		return "🚚 Movida"
+	default:
+		return action
+	}
+}
+
+func (cli *TodoCLI) undoCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "undo",
+		Short: "Desfazer a última operação",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			operation, err := cli.todoUseCase.Undo()
+			if err != nil {
+				fmt.Printf("❌ Erro ao desfazer: %v\n", err)
+				return
+			}
+
+			fmt.Printf("↩️  Desfeito: %s de '%s'\n", operationLabel(operation.Type), operation.Title())
+			fmt.Printf("🆔 ID: %s\n", operation.TodoID())
 		},
 	}
 }
+
+func (cli *TodoCLI) redoCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "redo",
+		Short: "Refazer a última operação desfeita",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			operation, err := cli.todoUseCase.Redo()
+			if err != nil {
+				fmt.Printf("❌ Erro ao refazer: %v\n", err)
+				return
+			}
+
+			fmt.Printf("↪️  Refeito: %s de '%s'\n", operationLabel(operation.Type), operation.Title())
+			fmt.Printf("🆔 ID: %s\n", operation.TodoID())
+		},
+	}
+}
+
+func operationLabel(operationType string) string {
+	switch operationType {
+	case entity.OperationCreate:
+		return "criação"
+	case entity.OperationUpdate:
+		return "atualização"
+	case entity.OperationComplete:
+		return "conclusão"
+	case entity.OperationReopen:
+		return "reabertura"
+	case entity.OperationDelete:
+		return "remoção"
+	case entity.OperationAssign:
+		return "atribuição"
+	case entity.OperationUnassign:
+		return "remoção de responsáveis"
+	case entity.OperationRestore:
+		return "restauração"
+	case entity.OperationPurge:
+		return "remoção definitiva"
+	default:
+		return operationType
+	}
+}
+
+// resolveAssignee traduz os apelidos "me" e "none" usados pelos comandos
+func (cli *TodoCLI) resolveAssignee(name string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "me":
+		if cli.currentUser == "" {
+			return "", errors.New("usuário atual não configurado (defina user no arquivo de configuração ou TODO_USER)")
+		}
+		return cli.currentUser, nil
+	case "none", "":
+		return "", nil
+	default:
+		return strings.TrimSpace(name), nil
+	}
+}
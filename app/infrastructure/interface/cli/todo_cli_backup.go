@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+)
+
+func (cli *TodoCLI) backupCommand() *cobra.Command {
+	backupCmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Criar, listar e restaurar backups completos dos dados",
+		Long: "Um backup guarda todos os dados (tarefas de todos os contextos, histórico, arquivo morto,\n" +
+			"webhooks, hooks) e o arquivo de configuração, com um manifesto versionado e o SHA-256\n" +
+			"de cada arquivo, conferidos antes de qualquer restauração",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if cli.backupUseCase == nil {
+				return fmt.Errorf("backups are not enabled")
+			}
+			return nil
+		},
+	}
+
+	backupCmd.AddCommand(cli.backupCreateCommand())
+	backupCmd.AddCommand(cli.backupListCommand())
+	backupCmd.AddCommand(cli.backupVerifyCommand())
+	backupCmd.AddCommand(cli.backupRestoreCommand())
+
+	return backupCmd
+}
+
+func (cli *TodoCLI) backupCreateCommand() *cobra.Command {
+	var to string
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Criar um backup agora",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			backup, err := cli.backupUseCase.CreateBackup(to)
+			if err != nil {
+				fmt.Printf("❌ Erro ao criar backup: %v\n", err)
+				return
+			}
+
+			fmt.Println("💾 Backup criado com sucesso!")
+			fmt.Printf("Arquivo: %s\n", backup.Path)
+			fmt.Printf("Conteúdo: %d arquivo(s), %s\n", len(backup.Files), formatSize(backup.Size))
+		},
+	}
+
+	cmd.Flags().StringVar(&to, "to", "", "Arquivo ou diretório de destino (padrão: pasta backups do diretório de dados)")
+	return cmd
+}
+
+func (cli *TodoCLI) backupListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Listar os backups, do mais recente ao mais antigo",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			backups, err := cli.backupUseCase.ListBackups()
+			if err != nil {
+				fmt.Printf("❌ Erro ao listar backups: %v\n", err)
+				return
+			}
+
+			if len(backups) == 0 {
+				fmt.Println("💾 Nenhum backup encontrado! Crie um com 'todo backup create'")
+				return
+			}
+
+			fmt.Printf("💾 Total de backups: %d\n\n", len(backups))
+			for _, backup := range backups {
+				fmt.Printf("• %s\n", backup.Name)
+				if backup.Format == "" {
+					fmt.Printf("   ⚠️  Manifesto ilegível (%s); confira com 'todo backup verify %s'\n", formatSize(backup.Size), backup.Name)
+					continue
+				}
+				fmt.Printf("   📅 %s · %s · %d arquivo(s), %s\n",
+					cli.formatDate(backup.CreatedAt), backupTriggerLabel(backup.Trigger), len(backup.Files), formatSize(backup.Size))
+			}
+		},
+	}
+}
+
+func (cli *TodoCLI) backupVerifyCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "verify [nome|arquivo]",
+		Short:             "Conferir a integridade de um backup sem restaurá-lo",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cli.completeBackups,
+		Run: func(cmd *cobra.Command, args []string) {
+			backup, err := cli.backupUseCase.VerifyBackup(args[0])
+			if err != nil {
+				fmt.Printf("❌ Backup inválido: %v\n", err)
+				return
+			}
+
+			fmt.Printf("✅ Backup íntegro: %d arquivo(s) conferido(s), criado em %s\n", len(backup.Files), cli.formatDate(backup.CreatedAt))
+		},
+	}
+}
+
+func (cli *TodoCLI) backupRestoreCommand() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:               "restore [nome|arquivo]",
+		Short:             "Substituir os dados atuais pelos de um backup",
+		Long:              "Confere o backup inteiro e guarda os dados atuais em um novo backup antes de substituí-los",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cli.completeBackups,
+		Run: func(cmd *cobra.Command, args []string) {
+			backup, err := cli.backupUseCase.VerifyBackup(args[0])
+			if err != nil {
+				fmt.Printf("❌ Backup inválido, nada foi alterado: %v\n", err)
+				return
+			}
+			prompt := fmt.Sprintf("⚠️  Os dados atuais serão substituídos pelos do backup de %s. Continuar? [s/N]: ", cli.formatDate(backup.CreatedAt))
+			if !yes && !confirm(cmd, prompt) {
+				fmt.Println("🚫 Restauração cancelada (use --yes para confirmar sem perguntar)")
+				return
+			}
+
+			restored, previous, err := cli.backupUseCase.RestoreBackup(args[0])
+			if err != nil {
+				fmt.Printf("❌ Erro ao restaurar backup: %v\n", err)
+				if previous != nil {
+					fmt.Printf("💡 Os dados anteriores estão em %s\n", previous.Name)
+				}
+				return
+			}
+
+			fmt.Printf("♻️  Backup restaurado: %d arquivo(s) de %s\n", len(restored.Files), cli.formatDate(restored.CreatedAt))
+			fmt.Printf("💡 Os dados anteriores foram guardados em %s; para voltar: todo backup restore %s\n", previous.Name, previous.Name)
+			if cli.inShell {
+				// O shell já abriu os arquivos de antes da restauração
+				fmt.Println("💡 Saia e abra o shell novamente para usar os dados restaurados")
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Não pedir confirmação")
+	return cmd
+}
+
+func backupTriggerLabel(trigger string) string {
+	switch trigger {
+	case entity.BackupAuto:
+		return "automático"
+	case entity.BackupPreRestore:
+		return "antes de restaurar"
+	default:
+		return "manual"
+	}
+}
+
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
+
+// completeBackups sugere os backups da pasta de backups, sem impedir que o
+// shell complete o caminho de um arquivo
+func (cli *TodoCLI) completeBackups(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	backups, err := cli.backupUseCase.ListBackups()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	var names []string
+	for _, backup := range backups {
+		names = append(names, backup.Name+"\t"+cli.formatDate(backup.CreatedAt))
+	}
+	return names, cobra.ShellCompDirectiveDefault
+}
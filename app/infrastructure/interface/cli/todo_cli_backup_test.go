@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/application"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestShouldCreateBackupAtGivenPath(t *testing.T) {
+	// Arrange
+	mockBackups := new(application.MockBackupUseCase)
+	cli := NewTodoCLI(new(application.MockTodoUseCase), WithBackups(mockBackups))
+	mockBackups.On("CreateBackup", "/mnt/pendrive/").Return(&entity.Backup{
+		Path:  "/mnt/pendrive/todo-backup-20240301-120000-manual.tar.gz",
+		Files: make([]entity.BackupFile, 4),
+		Size:  2048,
+	}, nil)
+
+	cmd := cli.backupCommand()
+	cmd.SetArgs([]string{"create", "--to", "/mnt/pendrive/"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "💾 Backup criado com sucesso!")
+	assert.Contains(t, output, "Arquivo: /mnt/pendrive/todo-backup-20240301-120000-manual.tar.gz")
+	assert.Contains(t, output, "Conteúdo: 4 arquivo(s), 2.0 KB")
+	mockBackups.AssertExpectations(t)
+}
+
+func TestShouldListBackupsFlaggingUnreadableOnes(t *testing.T) {
+	// Arrange
+	mockBackups := new(application.MockBackupUseCase)
+	cli := NewTodoCLI(new(application.MockTodoUseCase), WithBackups(mockBackups))
+	mockBackups.On("ListBackups").Return([]*entity.Backup{
+		{Name: "todo-backup-20240302-080000-auto.tar.gz", Format: entity.BackupFormat, Trigger: entity.BackupAuto,
+			CreatedAt: time.Date(2024, 3, 2, 8, 0, 0, 0, time.Local), Files: make([]entity.BackupFile, 3), Size: 900},
+		{Name: "todo-backup-velho.tar.gz", Size: 10},
+	}, nil)
+
+	cmd := cli.backupCommand()
+	cmd.SetArgs([]string{"list"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "💾 Total de backups: 2")
+	assert.Contains(t, output, "   📅 02/03/2024 08:00 · automático · 3 arquivo(s), 900 B")
+	assert.Contains(t, output, "⚠️  Manifesto ilegível (10 B); confira com 'todo backup verify todo-backup-velho.tar.gz'")
+}
+
+func TestShouldNotRestoreInvalidBackup(t *testing.T) {
+	// Arrange
+	mockBackups := new(application.MockBackupUseCase)
+	cli := NewTodoCLI(new(application.MockTodoUseCase), WithBackups(mockBackups))
+	mockBackups.On("VerifyBackup", "velho").Return(nil, entity.ErrBackupCorrupted)
+
+	cmd := cli.backupCommand()
+	cmd.SetArgs([]string{"restore", "velho", "--yes"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "❌ Backup inválido, nada foi alterado: backup is corrupted")
+	mockBackups.AssertNotCalled(t, "RestoreBackup", mock.Anything)
+}
+
+func TestShouldAskBeforeRestoringBackup(t *testing.T) {
+	// Arrange
+	mockBackups := new(application.MockBackupUseCase)
+	cli := NewTodoCLI(new(application.MockTodoUseCase), WithBackups(mockBackups))
+	backup := &entity.Backup{Name: "b1.tar.gz", CreatedAt: time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local), Files: make([]entity.BackupFile, 2)}
+	previous := &entity.Backup{Name: "todo-backup-20240305-090000-pre-restore.tar.gz"}
+	mockBackups.On("VerifyBackup", "b1.tar.gz").Return(backup, nil)
+	mockBackups.On("RestoreBackup", "b1.tar.gz").Return(backup, previous, nil)
+
+	// Act
+	refused := captureOutput(func() {
+		cmd := cli.backupCommand()
+		cmd.SetIn(strings.NewReader("n\n"))
+		cmd.SetArgs([]string{"restore", "b1.tar.gz"})
+		cmd.Execute()
+	})
+	accepted := captureOutput(func() {
+		cmd := cli.backupCommand()
+		cmd.SetIn(strings.NewReader("s\n"))
+		cmd.SetArgs([]string{"restore", "b1.tar.gz"})
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, refused, "substituídos pelos do backup de 01/03/2024 12:00")
+	assert.Contains(t, refused, "🚫 Restauração cancelada")
+	assert.Contains(t, accepted, "♻️  Backup restaurado: 2 arquivo(s) de 01/03/2024 12:00")
+	assert.Contains(t, accepted, "todo backup restore todo-backup-20240305-090000-pre-restore.tar.gz")
+	mockBackups.AssertNumberOfCalls(t, "RestoreBackup", 1)
+}
@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+	app_interfaces "codecademy-yellowbelt2/infrastructure/interface/application"
+)
+
+// Acima deste número de tarefas o lote pede confirmação (a menos que --yes seja usado)
+const bulkConfirmThreshold = 10
+
+var (
+	errBulkCancelled = errors.New("bulk operation cancelled")
+	errBulkNeedsYes  = errors.New("bulk operation on ids from stdin requires --yes")
+)
+
+// bulkSelection reúne as formas de escolher várias tarefas: IDs posicionais,
+// filtros e IDs lidos da entrada padrão
+type bulkSelection struct {
+	status   string
+	assignee string
+	match    string
+	tags     []string
+	projects []string
+	stdin    bool
+	dryRun   bool
+	yes      bool
+}
+
+func (s *bulkSelection) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&s.status, "status", "", "Selecionar por status (pending ou completed)")
+	cmd.Flags().StringVar(&s.assignee, "assignee", "", "Selecionar por responsável (me, nome ou none)")
+	cmd.Flags().StringVar(&s.match, "match", "", "Selecionar tarefas cujo título contém o texto")
+	cmd.Flags().StringSliceVar(&s.tags, "tag", nil, "Selecionar tarefas com a tag (repita para exigir várias)")
+	cmd.Flags().StringSliceVar(&s.projects, "project", nil, "Selecionar tarefas do projeto (repita para exigir vários)")
+	cmd.Flags().BoolVar(&s.stdin, "stdin", false, "Ler IDs da entrada padrão (um ou mais por linha)")
+	cmd.Flags().BoolVar(&s.dryRun, "dry-run", false, "Apenas mostrar o que seria feito")
+	cmd.Flags().BoolVarP(&s.yes, "yes", "y", false, "Não pedir confirmação")
+}
+
+func (s *bulkSelection) hasFilter() bool {
+	return s.status != "" || s.assignee != "" || s.match != "" || len(s.tags) > 0 || len(s.projects) > 0
+}
+
+func (s *bulkSelection) isBulk() bool {
+	return s.hasFilter() || s.stdin || s.dryRun
+}
+
+// args só aplica a validação posicional original quando o comando não está em modo lote
+func (s *bulkSelection) args(single cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if s.isBulk() {
+			return nil
+		}
+		return single(cmd, args)
+	}
+}
+
+func (cli *TodoCLI) resolveSelection(cmd *cobra.Command, args []string, selection *bulkSelection) ([]string, error) {
+	ids := append([]string{}, args...)
+
+	if selection.stdin {
+		scanner := bufio.NewScanner(cmd.InOrStdin())
+		for scanner.Scan() {
+			ids = append(ids, strings.Fields(scanner.Text())...)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	if selection.hasFilter() {
+		filter := entity.TodoFilter{
+			TitleContains: selection.match,
+			Tags:          trimLabels(selection.tags, "@"),
+			Projects:      trimLabels(selection.projects, "+"),
+		}
+
+		switch selection.status {
+		case "":
+		case entity.StatusPending, entity.StatusCompleted:
+			filter.Status = selection.status
+		default:
+			return nil, fmt.Errorf("status inválido: %s", selection.status)
+		}
+
+		if selection.assignee != "" {
+			name, err := cli.resolveAssignee(selection.assignee)
+			if err != nil {
+				return nil, err
+			}
+			filter.Assignee = name
+			filter.Unassigned = name == ""
+		}
+
+		todos, err := cli.todoUseCase.FilterTodos(filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, todo := range todos {
+			ids = append(ids, todo.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+// trimLabels aceita tags e projetos também na notação do todo.txt (@tag, +projeto)
+func trimLabels(labels []string, prefix string) []string {
+	if len(labels) == 0 {
+		return nil
+	}
+	trimmed := make([]string, 0, len(labels))
+	for _, label := range labels {
+		trimmed = append(trimmed, strings.TrimPrefix(strings.TrimSpace(label), prefix))
+	}
+	return trimmed
+}
+
+// runBatch só devolve erro quando a operação é cancelada ou recusada, para
+// que o processo termine com status diferente de zero; as demais falhas são
+// mostradas por tarefa
+func (cli *TodoCLI) runBatch(cmd *cobra.Command, batch app_interfaces.Batch, args []string, selection *bulkSelection) error {
+	ids, err := cli.resolveSelection(cmd, args, selection)
+	if err != nil {
+		fmt.Printf("❌ Erro ao selecionar tarefas: %v\n", err)
+		return nil
+	}
+	if len(ids) == 0 {
+		fmt.Println("📝 Nenhuma tarefa selecionada!")
+		return nil
+	}
+	batch.IDs = ids
+
+	needsConfirmation := len(ids) > bulkConfirmThreshold && !selection.yes
+	if needsConfirmation && selection.stdin && !selection.dryRun {
+		// A entrada padrão já foi consumida pelos IDs e não tem como responder à pergunta
+		fmt.Printf("❌ %d tarefas lidas da entrada padrão: use --yes para confirmar ou --dry-run para conferir antes\n", len(ids))
+		cmd.SilenceUsage = true
+		return errBulkNeedsYes
+	}
+
+	if selection.dryRun || needsConfirmation {
+		preview := batch
+		preview.DryRun = true
+		results, err := cli.todoUseCase.RunBatch(preview)
+		if err != nil {
+			fmt.Printf("❌ Erro ao simular operação: %v\n", err)
+			return nil
+		}
+		printBatchPreview(results)
+
+		if selection.dryRun {
+			return nil
+		}
+		if !confirm(cmd, fmt.Sprintf("⚠️  %d tarefas serão afetadas. Continuar? [s/N]: ", len(results))) {
+			fmt.Println("🚫 Operação cancelada (use --yes para confirmar sem perguntar)")
+			cmd.SilenceUsage = true
+			return errBulkCancelled
+		}
+	}
+
+	results, err := cli.todoUseCase.RunBatch(batch)
+	if err != nil {
+		fmt.Printf("❌ Erro ao executar operação em lote: %v\n", err)
+		return nil
+	}
+	printBatchReport(results)
+	return nil
+}
+
+func printBatchPreview(results []app_interfaces.BatchResult) {
+	fmt.Printf("🔍 Simulação: %d tarefa(s) selecionada(s)\n", len(results))
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Printf("   ❌ %s: %v\n", result.ID, result.Err)
+			continue
+		}
+		fmt.Printf("   • %s - %s\n", result.ID, result.Todo.Title)
+	}
+	fmt.Println()
+}
+
+func printBatchReport(results []app_interfaces.BatchResult) {
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Printf("❌ %s: %v\n", result.ID, result.Err)
+			continue
+		}
+		fmt.Printf("✅ %s - %s\n", result.ID, result.Todo.Title)
+	}
+
+	failures := app_interfaces.CountBatchFailures(results)
+	fmt.Printf("\n📊 Resultado: %d sucesso(s), %d falha(s)\n", len(results)-failures, failures)
+}
+
+func confirm(cmd *cobra.Command, prompt string) bool {
+	fmt.Print(prompt)
+
+	answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "s", "sim", "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
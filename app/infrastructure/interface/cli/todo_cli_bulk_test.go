@@ -0,0 +1,320 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/application"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestShouldCompleteManyTodosByID(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	results := []application.BatchResult{
+		{ID: "1", Todo: &entity.Todo{ID: "1", Title: "A"}},
+		{ID: "2", Err: errors.New("todo not found")},
+	}
+	mockUseCase.On("RunBatch", application.Batch{Action: entity.OperationComplete, IDs: []string{"1", "2"}}).Return(results, nil)
+
+	cmd := cli.completeCommand()
+	cmd.SetArgs([]string{"1", "2"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "✅ 1 - A")
+	assert.Contains(t, output, "❌ 2: todo not found")
+	assert.Contains(t, output, "📊 Resultado: 1 sucesso(s), 1 falha(s)")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldDeleteTodosSelectedByFilter(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase, WithCurrentUser("alice"))
+	filter := entity.TodoFilter{Status: entity.StatusCompleted, Assignee: "alice"}
+	mockUseCase.On("FilterTodos", filter).Return([]*entity.Todo{{ID: "1"}, {ID: "2"}}, nil)
+	results := []application.BatchResult{
+		{ID: "1", Todo: &entity.Todo{ID: "1", Title: "A"}},
+		{ID: "2", Todo: &entity.Todo{ID: "2", Title: "B"}},
+	}
+	mockUseCase.On("RunBatch", application.Batch{Action: entity.OperationDelete, IDs: []string{"1", "2"}}).Return(results, nil)
+
+	cmd := cli.deleteCommand()
+	cmd.SetArgs([]string{"--status", "completed", "--assignee", "me"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "📊 Resultado: 2 sucesso(s), 0 falha(s)")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldCompleteTodosSelectedByTagAndProject(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	filter := entity.TodoFilter{Tags: []string{"work", "urgent"}, Projects: []string{"api"}}
+	mockUseCase.On("FilterTodos", filter).Return([]*entity.Todo{{ID: "1"}}, nil)
+	results := []application.BatchResult{{ID: "1", Todo: &entity.Todo{ID: "1", Title: "A"}}}
+	mockUseCase.On("RunBatch", application.Batch{Action: entity.OperationComplete, IDs: []string{"1"}}).Return(results, nil)
+
+	cmd := cli.completeCommand()
+	cmd.SetArgs([]string{"--tag", "work", "--tag", "@urgent", "--project", "+api"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "📊 Resultado: 1 sucesso(s), 0 falha(s)")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldReadBulkIDsFromStdin(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	results := []application.BatchResult{
+		{ID: "1", Todo: &entity.Todo{ID: "1", Title: "A"}},
+		{ID: "2", Todo: &entity.Todo{ID: "2", Title: "B"}},
+		{ID: "3", Todo: &entity.Todo{ID: "3", Title: "C"}},
+	}
+	mockUseCase.On("RunBatch", application.Batch{Action: entity.OperationComplete, IDs: []string{"1", "2", "3"}}).Return(results, nil)
+
+	cmd := cli.completeCommand()
+	cmd.SetIn(strings.NewReader("1\n2 3\n"))
+	cmd.SetArgs([]string{"--stdin"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "📊 Resultado: 3 sucesso(s), 0 falha(s)")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldPreviewBulkOperationOnDryRun(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	results := []application.BatchResult{{ID: "1", Todo: &entity.Todo{ID: "1", Title: "A"}}}
+	mockUseCase.On("RunBatch", application.Batch{Action: entity.OperationDelete, IDs: []string{"1"}, DryRun: true}).Return(results, nil)
+
+	cmd := cli.deleteCommand()
+	cmd.SetArgs([]string{"1", "--dry-run"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "🔍 Simulação: 1 tarefa(s) selecionada(s)")
+	assert.Contains(t, output, "   • 1 - A")
+	assert.NotContains(t, output, "📊 Resultado")
+	mockUseCase.AssertNumberOfCalls(t, "RunBatch", 1)
+}
+
+func TestShouldAskConfirmationAboveThreshold(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	ids := make([]string, 0, bulkConfirmThreshold+1)
+	results := make([]application.BatchResult, 0, bulkConfirmThreshold+1)
+	for i := 0; i <= bulkConfirmThreshold; i++ {
+		id := fmt.Sprint(i)
+		ids = append(ids, id)
+		results = append(results, application.BatchResult{ID: id, Todo: &entity.Todo{ID: id}})
+	}
+	mockUseCase.On("RunBatch", mock.MatchedBy(func(batch application.Batch) bool { return batch.DryRun })).Return(results, nil)
+
+	cmd := cli.completeCommand()
+	cmd.SetIn(strings.NewReader("n\n"))
+	cmd.SetArgs(ids)
+
+	// Act
+	var err error
+	output := captureOutput(func() {
+		err = cmd.Execute()
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, errBulkCancelled)
+	assert.Contains(t, output, fmt.Sprintf("⚠️  %d tarefas serão afetadas. Continuar? [s/N]", bulkConfirmThreshold+1))
+	assert.Contains(t, output, "🚫 Operação cancelada")
+	mockUseCase.AssertNumberOfCalls(t, "RunBatch", 1)
+}
+
+func TestShouldRequireYesForManyIDsFromStdin(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	ids := make([]string, 0, bulkConfirmThreshold+1)
+	for i := 0; i <= bulkConfirmThreshold; i++ {
+		ids = append(ids, fmt.Sprint(i))
+	}
+
+	cmd := cli.completeCommand()
+	cmd.SetIn(strings.NewReader(strings.Join(ids, "\n")))
+	cmd.SetArgs([]string{"--stdin"})
+
+	// Act
+	var err error
+	output := captureOutput(func() {
+		err = cmd.Execute()
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, errBulkNeedsYes)
+	assert.Contains(t, output, "use --yes")
+	assert.NotContains(t, output, "Continuar?")
+	mockUseCase.AssertNotCalled(t, "RunBatch", mock.Anything)
+}
+
+func TestShouldRunBulkOperationAfterConfirmation(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	ids := make([]string, 0, bulkConfirmThreshold+1)
+	results := make([]application.BatchResult, 0, bulkConfirmThreshold+1)
+	for i := 0; i <= bulkConfirmThreshold; i++ {
+		id := fmt.Sprint(i)
+		ids = append(ids, id)
+		results = append(results, application.BatchResult{ID: id, Todo: &entity.Todo{ID: id}})
+	}
+	mockUseCase.On("RunBatch", mock.AnythingOfType("application.Batch")).Return(results, nil)
+
+	cmd := cli.completeCommand()
+	cmd.SetIn(strings.NewReader("s\n"))
+	cmd.SetArgs(ids)
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, fmt.Sprintf("📊 Resultado: %d sucesso(s), 0 falha(s)", bulkConfirmThreshold+1))
+	mockUseCase.AssertNumberOfCalls(t, "RunBatch", 2)
+}
+
+func TestShouldUpdateManyTodosWithFlags(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("FilterTodos", entity.TodoFilter{TitleContains: "sprint"}).Return([]*entity.Todo{{ID: "1"}}, nil)
+	results := []application.BatchResult{{ID: "1", Todo: &entity.Todo{ID: "1", Title: "Sprint"}}}
+	mockUseCase.On("RunBatch", application.Batch{Action: entity.OperationUpdate, IDs: []string{"1"}, Description: "sprint-12"}).Return(results, nil)
+
+	cmd := cli.updateCommand()
+	cmd.SetArgs([]string{"--match", "sprint", "--description", "sprint-12"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "✅ 1 - Sprint")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldRequireFieldsForBulkUpdate(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+
+	cmd := cli.updateCommand()
+	cmd.SetArgs([]string{"--match", "sprint"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "❌ Informe --title e/ou --description para atualizar em lote")
+	mockUseCase.AssertNotCalled(t, "RunBatch", mock.Anything)
+}
+
+func TestShouldRejectInvalidBulkStatus(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+
+	cmd := cli.completeCommand()
+	cmd.SetArgs([]string{"--status", "someday"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "❌ Erro ao selecionar tarefas: status inválido: someday")
+	mockUseCase.AssertNotCalled(t, "FilterTodos", mock.Anything)
+}
+
+func TestShouldUpdateManyTodosByIDWithTitleFlag(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("GetTodoByID", "1").Return(&entity.Todo{ID: "1"}, nil)
+	mockUseCase.On("GetTodoByID", "2").Return(&entity.Todo{ID: "2"}, nil)
+	results := []application.BatchResult{
+		{ID: "1", Todo: &entity.Todo{ID: "1", Title: "Revisar"}},
+		{ID: "2", Todo: &entity.Todo{ID: "2", Title: "Revisar"}},
+	}
+	mockUseCase.On("RunBatch", application.Batch{Action: entity.OperationUpdate, IDs: []string{"1", "2"}, Title: "Revisar"}).Return(results, nil)
+
+	cmd := cli.updateCommand()
+	cmd.SetArgs([]string{"1", "2", "--title", "Revisar"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "📊 Resultado: 2 sucesso(s), 0 falha(s)")
+	mockUseCase.AssertNotCalled(t, "UpdateTodo", mock.Anything, mock.Anything, mock.Anything)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldRejectPositionalTitleMixedWithFlags(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("GetTodoByID", "1").Return(&entity.Todo{ID: "1"}, nil)
+	mockUseCase.On("GetTodoByID", "Novo título").Return(nil, errors.New("todo not found"))
+
+	cmd := cli.updateCommand()
+	cmd.SetArgs([]string{"1", "Novo título", "--description", "detalhes"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, `❌ "Novo título" não é o ID de uma tarefa (todo not found)`)
+	mockUseCase.AssertNotCalled(t, "RunBatch", mock.Anything)
+	mockUseCase.AssertNotCalled(t, "UpdateTodo", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,234 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/transfer"
+)
+
+// todoIDArgs indica quantos argumentos posicionais de cada comando são IDs de
+// tarefa (-1 para todos); serve à completação e aos números do "todo shell"
+var todoIDArgs = map[string]int{
+	"todo show":          1,
+	"todo update":        1,
+	"todo complete":      -1,
+	"todo delete":        -1,
+	"todo assign":        1,
+	"todo history":       1,
+	"todo unarchive":     1,
+	"todo trash restore": 1,
+	"todo move":          -1,
+}
+
+// idsOnlyFlags fazem todos os argumentos posicionais do comando serem IDs
+var idsOnlyFlags = map[string][]string{
+	"todo update": {"title", "description"},
+}
+
+// idsOnly indica se algum desses flags aparece em args (ainda não interpretados)
+func idsOnly(path string, args []string) bool {
+	for _, arg := range args {
+		for _, name := range idsOnlyFlags[path] {
+			if arg == "--"+name || strings.HasPrefix(arg, "--"+name+"=") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+func (cli *TodoCLI) completionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion [bash|zsh|fish|powershell]",
+		Short: "Gerar o script de autocompletar para o shell",
+		Long: `Gera o script de autocompletar, que sugere comandos, flags e os IDs das tarefas existentes.
+
+  bash:        source <(todo completion bash)
+  zsh:         todo completion zsh > "${fpath[1]}/_todo"
+  fish:        todo completion fish > ~/.config/fish/completions/todo.fish
+  powershell:  todo completion powershell | Out-String | Invoke-Expression`,
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root := cmd.Root()
+			out := cmd.OutOrStdout()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(out, true)
+			case "zsh":
+				return root.GenZshCompletion(out)
+			case "fish":
+				return root.GenFishCompletion(out, true)
+			default:
+				return root.GenPowerShellCompletionWithDesc(out)
+			}
+		},
+	}
+}
+
+// registerCompletions liga a completação dinâmica aos comandos que recebem
+// IDs de tarefa e às flags com valores conhecidos
+func (cli *TodoCLI) registerCompletions(cmd *cobra.Command) {
+	if slots, ok := todoIDArgs[cmd.CommandPath()]; ok {
+		cmd.ValidArgsFunction = cli.completeTodoIDs(slots, cli.completionSource(cmd.CommandPath()))
+	}
+	if cmd.Flags().Lookup("status") != nil {
+		cmd.RegisterFlagCompletionFunc("status", cobra.FixedCompletions(
+			[]string{entity.StatusPending, entity.StatusCompleted}, cobra.ShellCompDirectiveNoFileComp))
+	}
+	if cmd.Flags().Lookup("assignee") != nil {
+		cmd.RegisterFlagCompletionFunc("assignee", cli.completeAssignees)
+	}
+	if cmd.Flags().Lookup("tag") != nil {
+		cmd.RegisterFlagCompletionFunc("tag", cli.completeLabels(func(todo *entity.Todo) []string { return todo.Tags }))
+	}
+	if cmd.Flags().Lookup("project") != nil {
+		cmd.RegisterFlagCompletionFunc("project", cli.completeLabels(func(todo *entity.Todo) []string { return todo.Projects }))
+	}
+	if cmd.Flags().Lookup("output") != nil {
+		cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(
+			[]string{outputText, outputJSON}, cobra.ShellCompDirectiveNoFileComp))
+	}
+	if cmd.Flags().Lookup("format") != nil {
+		cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return cli.formatNames(), cobra.ShellCompDirectiveNoFileComp
+		})
+	}
+	if cmd.Flags().Lookup("encoding") != nil {
+		cmd.RegisterFlagCompletionFunc("encoding", cobra.FixedCompletions(transfer.Encodings, cobra.ShellCompDirectiveNoFileComp))
+	}
+	if cmd.Flags().Lookup("dedup") != nil {
+		cmd.RegisterFlagCompletionFunc("dedup", cobra.FixedCompletions(
+			[]string{dedupByID, dedupByTitle}, cobra.ShellCompDirectiveNoFileComp))
+	}
+	// O --to do backup é um caminho, completado pelo próprio shell
+	if cmd.Flags().Lookup("to") != nil && cmd.CommandPath() == "todo move" {
+		cmd.RegisterFlagCompletionFunc("to", cli.completeContexts(-1))
+	}
+	if cmd.Flags().Lookup("events") != nil {
+		cmd.RegisterFlagCompletionFunc("events", completeEventTypes)
+	}
+
+	for _, sub := range cmd.Commands() {
+		cli.registerCompletions(sub)
+	}
+}
+
+// completionSource escolhe de onde vêm as tarefas sugeridas para cada comando
+func (cli *TodoCLI) completionSource(path string) func() ([]*entity.Todo, error) {
+	switch path {
+	case "todo trash restore":
+		return cli.todoUseCase.GetDeletedTodos
+	case "todo unarchive":
+		return cli.todoUseCase.GetArchivedTodos
+	case "todo complete":
+		return func() ([]*entity.Todo, error) {
+			return cli.todoUseCase.FilterTodos(entity.TodoFilter{Status: entity.StatusPending})
+		}
+	default:
+		return cli.todoUseCase.GetAllTodos
+	}
+}
+
+func changedAny(cmd *cobra.Command, names []string) bool {
+	for _, name := range names {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// completeTodoIDs sugere os IDs com o título como descrição, sem repetir os já digitados
+func (cli *TodoCLI) completeTodoIDs(slots int, source func() ([]*entity.Todo, error)) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if slots >= 0 && len(args) >= slots && !changedAny(cmd, idsOnlyFlags[cmd.CommandPath()]) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		todos, err := source()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		typed := make(map[string]bool, len(args))
+		for _, arg := range args {
+			typed[arg] = true
+		}
+
+		var suggestions []string
+		for _, todo := range todos {
+			if typed[todo.ID] || !strings.HasPrefix(todo.ID, toComplete) {
+				continue
+			}
+			suggestions = append(suggestions, fmt.Sprintf("%s\t%s", todo.ID, todo.Title))
+		}
+		return suggestions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func (cli *TodoCLI) completeAssignees(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	todos, err := cli.todoUseCase.GetAllTodos()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	names := distinctValues(todos, func(todo *entity.Todo) []string { return todo.Assignees })
+
+	suggestions := []string{"none\tSem responsável"}
+	if cli.currentUser != "" {
+		suggestions = append([]string{"me\t" + cli.currentUser}, suggestions...)
+	}
+	return append(suggestions, names...), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeLabels sugere as tags ou os projetos já usados nas tarefas
+func (cli *TodoCLI) completeLabels(labels func(todo *entity.Todo) []string) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		todos, err := cli.todoUseCase.GetAllTodos()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return distinctValues(todos, labels), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// distinctValues junta, sem repetir e em ordem alfabética, os valores de um campo das tarefas
+func distinctValues(todos []*entity.Todo, field func(todo *entity.Todo) []string) []string {
+	seen := make(map[string]bool)
+	var values []string
+	for _, todo := range todos {
+		for _, value := range field(todo) {
+			if !seen[value] {
+				seen[value] = true
+				values = append(values, value)
+			}
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// completeEventTypes completa listas separadas por vírgula, sugerindo apenas
+// os eventos que ainda não foram escolhidos
+func completeEventTypes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	chosen := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		chosen = toComplete[:i+1]
+	}
+
+	var suggestions []string
+	for _, eventType := range entity.EventTypes {
+		if !strings.Contains(","+chosen, ","+eventType+",") {
+			suggestions = append(suggestions, chosen+eventType)
+		}
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
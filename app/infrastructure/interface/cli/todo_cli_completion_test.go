@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/application"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// complete executa o comando oculto do cobra usado pelos scripts de autocompletar
+func complete(cli *TodoCLI, args ...string) []string {
+	var out bytes.Buffer
+	rootCmd := cli.GetRootCommand()
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs(append([]string{"__complete"}, args...))
+	rootCmd.Execute()
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	return lines[:len(lines)-1] // A última linha é a diretiva
+}
+
+func TestShouldCompleteTodoIDsWithTitles(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("GetAllTodos").Return([]*entity.Todo{
+		{ID: "a1", Title: "Comprar pão"},
+		{ID: "b2", Title: "Estudar Go"},
+	}, nil)
+
+	// Act
+	all := complete(cli, "show", "")
+	prefixed := complete(cli, "delete", "a1", "")
+	done := complete(cli, "show", "a1", "")
+
+	// Assert
+	assert.Equal(t, []string{"a1\tComprar pão", "b2\tEstudar Go"}, all)
+	assert.Equal(t, []string{"b2\tEstudar Go"}, prefixed)
+	assert.Empty(t, done)
+}
+
+func TestShouldCompleteOnlyPendingTodosForComplete(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("FilterTodos", entity.TodoFilter{Status: entity.StatusPending}).Return([]*entity.Todo{
+		{ID: "a1", Title: "Comprar pão"},
+	}, nil)
+
+	// Act
+	suggestions := complete(cli, "complete", "")
+
+	// Assert
+	assert.Equal(t, []string{"a1\tComprar pão"}, suggestions)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldCompleteDeletedTodosForTrashRestore(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("GetDeletedTodos").Return([]*entity.Todo{{ID: "c3", Title: "Velha"}}, nil)
+
+	// Act
+	suggestions := complete(cli, "trash", "restore", "")
+
+	// Assert
+	assert.Equal(t, []string{"c3\tVelha"}, suggestions)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldCompleteFlagValues(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase, WithCurrentUser("ana"))
+	mockUseCase.On("GetAllTodos").Return([]*entity.Todo{
+		{ID: "a1", Assignees: []string{"bruno", "ana"}},
+		{ID: "b2", Assignees: []string{"ana"}},
+	}, nil)
+
+	// Act
+	status := complete(cli, "complete", "--status", "")
+	assignees := complete(cli, "list", "--assignee", "")
+	events := complete(cli, "webhook", "add", "http://example.com", "--events", "todo.created,")
+
+	// Assert
+	assert.Equal(t, []string{"pending", "completed"}, status)
+	assert.Equal(t, []string{"me\tana", "none\tSem responsável", "ana", "bruno"}, assignees)
+	assert.NotContains(t, events, "todo.created,todo.created")
+	assert.Contains(t, events, "todo.created,todo.completed")
+}
+
+func TestShouldCompleteTagsAndProjectsFromExistingTodos(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("GetAllTodos").Return([]*entity.Todo{
+		{ID: "a1", Tags: []string{"urgente", "casa"}, Projects: []string{"mudanca"}},
+		{ID: "b2", Tags: []string{"casa"}, Projects: []string{"api"}},
+	}, nil)
+
+	// Act
+	tags := complete(cli, "complete", "--tag", "")
+	projects := complete(cli, "delete", "--project", "")
+
+	// Assert
+	assert.Equal(t, []string{"casa", "urgente"}, tags)
+	assert.Equal(t, []string{"api", "mudanca"}, projects)
+}
+
+func TestShouldGenerateCompletionScripts(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		t.Run(shell, func(t *testing.T) {
+			// Arrange
+			var out bytes.Buffer
+			rootCmd := NewTodoCLI(new(application.MockTodoUseCase)).GetRootCommand()
+			rootCmd.SetOut(&out)
+			rootCmd.SetArgs([]string{"completion", shell})
+
+			// Act
+			err := rootCmd.Execute()
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Contains(t, out.String(), "__complete")
+		})
+	}
+}
+
+func TestShouldRejectUnknownCompletionShell(t *testing.T) {
+	// Arrange
+	rootCmd := NewTodoCLI(new(application.MockTodoUseCase)).GetRootCommand()
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"completion", "tcsh"})
+
+	// Act
+	err := rootCmd.Execute()
+
+	// Assert
+	assert.Error(t, err)
+}
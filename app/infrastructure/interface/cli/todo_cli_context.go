@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+)
+
+func (cli *TodoCLI) contextCommand() *cobra.Command {
+	contextCmd := &cobra.Command{
+		Use:   "context",
+		Short: "Gerenciar contextos (áreas de trabalho com tarefas separadas)",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if cli.contextUseCase == nil {
+				return fmt.Errorf("contexts are not enabled")
+			}
+			return nil
+		},
+	}
+
+	contextCmd.AddCommand(cli.contextListCommand())
+	contextCmd.AddCommand(cli.contextCurrentCommand())
+	contextCmd.AddCommand(cli.contextCreateCommand())
+	contextCmd.AddCommand(cli.contextUseCommand())
+	contextCmd.AddCommand(cli.contextRenameCommand())
+	contextCmd.AddCommand(cli.contextDeleteCommand())
+
+	return contextCmd
+}
+
+func (cli *TodoCLI) contextListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Listar os contextos, marcando o atual",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			names, err := cli.contextUseCase.ListContexts()
+			if err != nil {
+				fmt.Printf("❌ Erro ao listar contextos: %v\n", err)
+				return
+			}
+			current, err := cli.contextUseCase.CurrentContext()
+			if err != nil {
+				fmt.Printf("❌ Erro ao ler o contexto atual: %v\n", err)
+				return
+			}
+
+			fmt.Printf("🗂️  Total de contextos: %d\n\n", len(names))
+			for _, name := range names {
+				if name == current {
+					fmt.Printf("* %s\n", name)
+					continue
+				}
+				fmt.Printf("  %s\n", name)
+			}
+		},
+	}
+}
+
+// contextCurrentCommand imprime só o nome, para ser usado em prompts do shell
+func (cli *TodoCLI) contextCurrentCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "current",
+		Short: "Mostrar o nome do contexto atual",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			current, err := cli.contextUseCase.CurrentContext()
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), current)
+			return nil
+		},
+	}
+}
+
+func (cli *TodoCLI) contextCreateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "create [nome]",
+		Short: "Criar um contexto",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cli.contextUseCase.CreateContext(args[0]); err != nil {
+				fmt.Printf("❌ Erro ao criar contexto: %v\n", err)
+				return
+			}
+
+			fmt.Printf("✅ Contexto %s criado! Use 'todo context use %s' para trocar para ele\n", args[0], args[0])
+		},
+	}
+}
+
+func (cli *TodoCLI) contextUseCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "use [nome]",
+		Short:             "Trocar o contexto atual",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cli.completeContexts(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cli.contextUseCase.UseContext(args[0]); err != nil {
+				fmt.Printf("❌ Erro ao trocar de contexto: %v\n", err)
+				return
+			}
+
+			fmt.Printf("🔀 Contexto atual: %s\n", args[0])
+			if cli.inShell {
+				// O shell já abriu os arquivos do contexto anterior
+				fmt.Println("💡 Saia e abra o shell novamente para usar as tarefas do novo contexto")
+			}
+		},
+	}
+}
+
+func (cli *TodoCLI) contextRenameCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "rename [nome] [novo-nome]",
+		Short:             "Renomear um contexto",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: cli.completeContexts(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cli.contextUseCase.RenameContext(args[0], args[1]); err != nil {
+				fmt.Printf("❌ Erro ao renomear contexto: %v\n", err)
+				return
+			}
+
+			fmt.Printf("✏️  Contexto %s renomeado para %s\n", args[0], args[1])
+		},
+	}
+}
+
+func (cli *TodoCLI) contextDeleteCommand() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:               "delete [nome]",
+		Short:             "Apagar um contexto e suas tarefas",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: cli.completeContexts(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cli.contextUseCase.DeleteContext(args[0], force)
+			if errors.Is(err, entity.ErrContextNotEmpty) {
+				fmt.Printf("⚠️  Não foi possível apagar o contexto %s: %v\n", args[0], err)
+				fmt.Printf("💡 Mova as tarefas com 'todo move' ou apague tudo com 'todo context delete %s --force'\n", args[0])
+				return
+			}
+			if err != nil {
+				fmt.Printf("❌ Erro ao apagar contexto: %v\n", err)
+				return
+			}
+
+			fmt.Printf("🗑️  Contexto %s apagado!\n", args[0])
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Apagar mesmo que o contexto ainda tenha tarefas")
+
+	return cmd
+}
+
+func (cli *TodoCLI) moveCommand() *cobra.Command {
+	var to string
+
+	cmd := &cobra.Command{
+		Use:   "move [id...]",
+		Short: "Mover tarefas do contexto atual para outro, mantendo ID e histórico",
+		Args:  cobra.MinimumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if cli.contextUseCase == nil {
+				return fmt.Errorf("contexts are not enabled")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			for _, id := range args {
+				todo, err := cli.contextUseCase.MoveTodo(id, to)
+				if err != nil {
+					fmt.Printf("❌ Erro ao mover tarefa %s: %v\n", id, err)
+					continue
+				}
+
+				fmt.Printf("🚚 Tarefa movida para %s: %s (%s)\n", to, todo.Title, todo.ID)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&to, "to", "", "Contexto de destino")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+// completeContexts sugere os nomes dos contextos enquanto houver menos de
+// slots argumentos (-1 para sempre, como nas flags)
+func (cli *TodoCLI) completeContexts(slots int) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if (slots >= 0 && len(args) >= slots) || cli.contextUseCase == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		names, err := cli.contextUseCase.ListContexts()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
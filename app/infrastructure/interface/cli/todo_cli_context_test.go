@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/application"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldListContextsMarkingTheCurrentOne(t *testing.T) {
+	// Arrange
+	mockContexts := new(application.MockContextUseCase)
+	cli := NewTodoCLI(new(application.MockTodoUseCase), WithContexts(mockContexts))
+	mockContexts.On("ListContexts").Return([]string{"default", "personal", "work"}, nil)
+	mockContexts.On("CurrentContext").Return("work", nil)
+
+	cmd := cli.contextCommand()
+	cmd.SetArgs([]string{"list"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "🗂️  Total de contextos: 3")
+	assert.Contains(t, output, "  default\n  personal\n* work\n")
+	mockContexts.AssertExpectations(t)
+}
+
+func TestShouldPrintOnlyTheCurrentContextName(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+	mockContexts := new(application.MockContextUseCase)
+	cli := NewTodoCLI(new(application.MockTodoUseCase), WithContexts(mockContexts))
+	mockContexts.On("CurrentContext").Return("personal", nil)
+
+	cmd := cli.contextCommand()
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"current"})
+
+	// Act
+	err := cmd.Execute()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "personal\n", out.String())
+}
+
+func TestShouldSuggestForceWhenDeletingContextWithTodos(t *testing.T) {
+	// Arrange
+	mockContexts := new(application.MockContextUseCase)
+	cli := NewTodoCLI(new(application.MockTodoUseCase), WithContexts(mockContexts))
+	mockContexts.On("DeleteContext", "work", false).Return(fmt.Errorf("%w: 2 todos", entity.ErrContextNotEmpty))
+	mockContexts.On("DeleteContext", "work", true).Return(nil)
+
+	// Act
+	refused := captureOutput(func() {
+		cmd := cli.contextCommand()
+		cmd.SetArgs([]string{"delete", "work"})
+		cmd.Execute()
+	})
+	forced := captureOutput(func() {
+		cmd := cli.contextCommand()
+		cmd.SetArgs([]string{"delete", "work", "--force"})
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, refused, "context still has todos: 2 todos")
+	assert.Contains(t, refused, "todo context delete work --force")
+	assert.Contains(t, forced, "🗑️  Contexto work apagado!")
+	mockContexts.AssertExpectations(t)
+}
+
+func TestShouldMoveTodosToAnotherContext(t *testing.T) {
+	// Arrange
+	mockContexts := new(application.MockContextUseCase)
+	cli := NewTodoCLI(new(application.MockTodoUseCase), WithContexts(mockContexts))
+	mockContexts.On("MoveTodo", "a1", "work").Return(&entity.Todo{ID: "a1", Title: "Relatório"}, nil)
+	mockContexts.On("MoveTodo", "b2", "work").Return(nil, errors.New("todo not found"))
+
+	cmd := cli.moveCommand()
+	cmd.SetArgs([]string{"a1", "b2", "--to", "work"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "🚚 Tarefa movida para work: Relatório (a1)")
+	assert.Contains(t, output, "❌ Erro ao mover tarefa b2: todo not found")
+	mockContexts.AssertExpectations(t)
+}
+
+func TestShouldRequireMoveDestination(t *testing.T) {
+	// Arrange
+	cli := NewTodoCLI(new(application.MockTodoUseCase), WithContexts(new(application.MockContextUseCase)))
+	cmd := cli.moveCommand()
+	cmd.SetArgs([]string{"a1"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	// Act
+	err := cmd.Execute()
+
+	// Assert
+	assert.ErrorContains(t, err, `"to" not set`)
+}
+
+func TestShouldCompleteContextNames(t *testing.T) {
+	// Arrange
+	mockContexts := new(application.MockContextUseCase)
+	cli := NewTodoCLI(new(application.MockTodoUseCase), WithContexts(mockContexts))
+	mockContexts.On("ListContexts").Return([]string{"default", "work"}, nil)
+
+	// Act
+	use := complete(cli, "context", "use", "")
+	to := complete(cli, "move", "a1", "--to", "")
+
+	// Assert
+	assert.Equal(t, []string{"default", "work"}, use)
+	assert.Equal(t, []string{"default", "work"}, to)
+}
+
+func TestShouldRefuseContextCommandsWhenDisabled(t *testing.T) {
+	// Arrange
+	cli := NewTodoCLI(new(application.MockTodoUseCase))
+	cmd := cli.contextCommand()
+	cmd.SetArgs([]string{"list"})
+	cmd.SilenceUsage = true
+
+	// Act
+	err := cmd.Execute()
+
+	// Assert
+	assert.EqualError(t, err, "contexts are not enabled")
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// mutatingCommands alteram as tarefas; o shell e a TUI entram porque os
+// comandos digitados neles também alteram
+var mutatingCommands = map[string]bool{
+	"todo create":         true,
+	"todo update":         true,
+	"todo complete":       true,
+	"todo delete":         true,
+	"todo assign":         true,
+	"todo undo":           true,
+	"todo redo":           true,
+	"todo trash restore":  true,
+	"todo trash purge":    true,
+	"todo archive":        true,
+	"todo unarchive":      true,
+	"todo store compact":  true,
+	"todo context delete": true,
+	"todo move":           true,
+	"todo import":         true,
+	"todo tui":            true,
+	"todo shell":          true,
+	"todo serve":          true,
+}
+
+// isMaintenanceFree indica os comandos que não devem disparar manutenção:
+// a completação do shell (chamada a cada tab), a ajuda e a geração do script
+func isMaintenanceFree(cmd *cobra.Command) bool {
+	switch cmd.Name() {
+	case cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd, "completion", "help":
+		return true
+	}
+	return false
+}
+
+// WithAutoArchive arquiva, antes de cada comando que altera as tarefas, as
+// concluídas há mais tempo que after
+func WithAutoArchive(after time.Duration) Option {
+	return func(cli *TodoCLI) {
+		cli.autoArchiveAfter = after
+	}
+}
+
+// WithAutoBackup cria, antes de cada comando que altera as tarefas, um backup
+// automático quando o último tiver mais que interval, mantendo os keep mais
+// recentes; depende de WithBackups
+func WithAutoBackup(interval time.Duration, keep int) Option {
+	return func(cli *TodoCLI) {
+		cli.autoBackupInterval = interval
+		cli.autoBackupKeep = keep
+	}
+}
+
+// Mutated indica se o comando executado pode ter alterado as tarefas; o
+// reenvio dos webhooks pendentes só acontece depois desses comandos
+func (cli *TodoCLI) Mutated() bool {
+	return cli.mutated
+}
+
+// beforeCommand roda a manutenção automática depois que as flags já foram
+// lidas (inclusive --no-hooks) e só uma vez: as linhas do shell já estão
+// cobertas pelo próprio "todo shell"
+func (cli *TodoCLI) beforeCommand(cmd *cobra.Command) {
+	if isMaintenanceFree(cmd) {
+		return
+	}
+	if mutatingCommands[cmd.CommandPath()] {
+		cli.mutated = true
+	}
+	if cli.inShell || !mutatingCommands[cmd.CommandPath()] {
+		return
+	}
+
+	// O backup vem antes do arquivamento, que também altera os dados; os
+	// comandos de backup não estão entre os que alteram tarefas
+	if cli.backupUseCase != nil && cli.autoBackupInterval > 0 {
+		if _, err := cli.backupUseCase.AutoBackup(cli.autoBackupInterval, cli.autoBackupKeep); err != nil {
+			fmt.Printf("⚠️  Erro ao criar backup automático: %v\n", err)
+		}
+	}
+	if cli.autoArchiveAfter > 0 {
+		if _, err := cli.todoUseCase.ArchiveTodos(time.Now().Add(-cli.autoArchiveAfter)); err != nil {
+			fmt.Printf("⚠️  Erro ao arquivar tarefas automaticamente: %v\n", err)
+		}
+	}
+}
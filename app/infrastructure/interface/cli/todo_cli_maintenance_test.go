@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/application"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestShouldAutoArchiveOnlyBeforeMutatingCommands(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase, WithAutoArchive(30*24*time.Hour))
+	mockUseCase.On("ArchiveTodos", mock.AnythingOfType("time.Time")).Return([]*entity.Todo{}, nil).Once()
+	mockUseCase.On("CreateTodo", "Comprar pão", "").Return(&entity.Todo{ID: "1", Title: "Comprar pão"}, nil)
+	mockUseCase.On("GetAllTodos").Return([]*entity.Todo{}, nil)
+	mockUseCase.On("FilterTodos", mock.Anything).Return([]*entity.Todo{}, nil)
+
+	run := func(args ...string) {
+		rootCmd := cli.GetRootCommand()
+		rootCmd.SetArgs(args)
+		captureOutput(func() {
+			rootCmd.Execute()
+		})
+	}
+
+	// Act
+	run("list")
+	run("__complete", "complete", "")
+	run("help", "create")
+	run("create", "Comprar pão")
+
+	// Assert
+	mockUseCase.AssertNumberOfCalls(t, "ArchiveTodos", 1)
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldNotAutoArchiveByDefault(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("CreateTodo", "Comprar pão", "").Return(&entity.Todo{ID: "1", Title: "Comprar pão"}, nil)
+
+	rootCmd := cli.GetRootCommand()
+	rootCmd.SetArgs([]string{"create", "Comprar pão"})
+
+	// Act
+	captureOutput(func() {
+		rootCmd.Execute()
+	})
+
+	// Assert
+	mockUseCase.AssertNotCalled(t, "ArchiveTodos", mock.Anything)
+}
+
+func TestShouldReportMutatedOnlyAfterMutatingCommands(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	mockUseCase.On("CreateTodo", "Comprar pão", "").Return(&entity.Todo{ID: "1", Title: "Comprar pão"}, nil)
+	mockUseCase.On("GetAllTodos").Return([]*entity.Todo{}, nil)
+	mockUseCase.On("FilterTodos", mock.Anything).Return([]*entity.Todo{}, nil)
+
+	run := func(args ...string) bool {
+		cli := NewTodoCLI(mockUseCase)
+		rootCmd := cli.GetRootCommand()
+		rootCmd.SetArgs(args)
+		captureOutput(func() {
+			rootCmd.Execute()
+		})
+		return cli.Mutated()
+	}
+
+	// Act & Assert
+	assert.False(t, run("list"))
+	assert.False(t, run("__complete", "create", ""))
+	assert.False(t, run("completion", "bash"))
+	assert.False(t, run("create", "--help"))
+	assert.True(t, run("create", "Comprar pão"))
+}
+
+func TestShouldAutoBackupOnlyBeforeMutatingCommands(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	mockBackups := new(application.MockBackupUseCase)
+	cli := NewTodoCLI(mockUseCase, WithBackups(mockBackups), WithAutoBackup(24*time.Hour, 7))
+	mockBackups.On("AutoBackup", 24*time.Hour, 7).Return(nil, nil).Once()
+	mockBackups.On("ListBackups").Return([]*entity.Backup{}, nil)
+	mockUseCase.On("CreateTodo", "Comprar pão", "").Return(&entity.Todo{ID: "1", Title: "Comprar pão"}, nil)
+	mockUseCase.On("GetAllTodos").Return([]*entity.Todo{}, nil)
+
+	run := func(args ...string) {
+		rootCmd := cli.GetRootCommand()
+		rootCmd.SetArgs(args)
+		captureOutput(func() {
+			rootCmd.Execute()
+		})
+	}
+
+	// Act
+	run("list")
+	run("backup", "list")
+	run("__complete", "backup", "restore", "")
+	run("help", "backup")
+	run("create", "Comprar pão")
+
+	// Assert
+	mockBackups.AssertNumberOfCalls(t, "AutoBackup", 1)
+	mockBackups.AssertExpectations(t)
+}
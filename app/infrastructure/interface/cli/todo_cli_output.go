@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+
+	defaultDateFormat = "02/01/2006 15:04"
+)
+
+// outputFormat lê o --output do comando, validando o valor
+func outputFormat(cmd *cobra.Command) (string, error) {
+	format, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return outputText, nil
+	}
+	switch format {
+	case outputText, outputJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q (expected text, json)", format)
+	}
+}
+
+func wantsJSON(cmd *cobra.Command) bool {
+	format, _ := outputFormat(cmd)
+	return format == outputJSON
+}
+
+// printJSON escreve o valor indentado; listas vazias saem como []
+func printJSON(value any) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		fmt.Printf("❌ Erro ao gerar JSON: %v\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+func nonNil(todos []*entity.Todo) []*entity.Todo {
+	if todos == nil {
+		return []*entity.Todo{}
+	}
+	return todos
+}
+
+func (cli *TodoCLI) formatDate(t time.Time) string {
+	return t.Format(cli.dateFormat)
+}
+
+// formatDay mostra só a data, usando a parte do formato configurado antes
+// do primeiro espaço (onde costuma começar a hora)
+func (cli *TodoCLI) formatDay(t time.Time) string {
+	layout, _, _ := strings.Cut(cli.dateFormat, " ")
+	return t.Format(layout)
+}
+
+// todoLabels resume prioridade, projetos, tags e prazo na notação do todo.txt
+func (cli *TodoCLI) todoLabels(todo *entity.Todo) string {
+	var labels []string
+	if todo.Priority != "" {
+		labels = append(labels, "("+todo.Priority+")")
+	}
+	for _, project := range todo.Projects {
+		labels = append(labels, "+"+project)
+	}
+	for _, tag := range todo.Tags {
+		labels = append(labels, "@"+tag)
+	}
+	if todo.DueDate != nil {
+		labels = append(labels, "📆 "+cli.formatDay(*todo.DueDate))
+	}
+	return strings.Join(labels, " ")
+}
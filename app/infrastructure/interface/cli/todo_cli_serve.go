@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"codecademy-yellowbelt2/infrastructure/interface/api"
+)
+
+func (cli *TodoCLI) serveCommand() *cobra.Command {
+	var addr string
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Iniciar o servidor HTTP com as tarefas em /todos/{id} e o stream de alterações em /events",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			server := &http.Server{
+				Addr:        addr,
+				Handler:     api.NewServer(cli.todoUseCase).Handler(),
+				BaseContext: func(net.Listener) context.Context { return ctx },
+			}
+			go func() {
+				<-ctx.Done()
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				server.Shutdown(shutdownCtx)
+			}()
+
+			fmt.Printf("🌐 Servidor HTTP em http://%s\n", addr)
+			fmt.Printf("📝 Tarefas (ETag/If-Match): http://%s/todos/{id}\n", addr)
+			fmt.Printf("📡 Alterações ao vivo: http://%s/events\n", addr)
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				fmt.Printf("❌ Erro no servidor HTTP: %v\n", err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", "127.0.0.1:8080", "Endereço em que o servidor escuta")
+
+	return cmd
+}
@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+	app_interfaces "codecademy-yellowbelt2/infrastructure/interface/application"
+	"codecademy-yellowbelt2/infrastructure/interface/shell"
+)
+
+// storeFlags escolhem o armazenamento ao iniciar o programa e não mudam dentro do shell
+var storeFlags = []string{"--config", "--profile", "--data-dir", "--storage"}
+
+func (cli *TodoCLI) shellCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "shell",
+		Short: "Abrir um shell interativo com os comandos de tarefas",
+		Long:  "Mantém as tarefas carregadas entre os comandos. Após um list, os números da listagem (2 ou #2) podem ser usados no lugar dos IDs.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if cli.inShell {
+				fmt.Println("❌ O shell já está aberto")
+				return
+			}
+			cli.inShell = true
+			defer func() { cli.inShell = false }()
+
+			opts := []shell.Option{
+				shell.WithCompleter(&shell.Completer{
+					Commands: cli.shellSubcommands,
+					Todos:    cli.todoUseCase.GetAllTodos,
+				}),
+			}
+			if cli.shellHistory != "" {
+				history, err := shell.LoadHistory(cli.shellHistory, shell.DefaultHistorySize)
+				if err != nil {
+					fmt.Printf("⚠️  Histórico indisponível: %v\n", err)
+				} else {
+					opts = append(opts, shell.WithHistory(history))
+				}
+			}
+
+			// Ctrl+C interrompe o comando em execução (como o watch), não o shell
+			interrupts := make(chan os.Signal, 1)
+			signal.Notify(interrupts, os.Interrupt)
+			defer signal.Stop(interrupts)
+
+			fmt.Println("🐚 Shell de tarefas - digite help para ver os comandos e exit para sair")
+			if err := shell.NewShell(cli.runShellLine, opts...).Run(os.Stdin, os.Stdout); err != nil {
+				fmt.Printf("❌ Erro no shell: %v\n", err)
+			}
+		},
+	}
+}
+
+// runShellLine executa uma linha do shell em uma árvore de comandos nova, para
+// que nenhuma flag da linha anterior continue valendo
+func (cli *TodoCLI) runShellLine(args []string) {
+	for _, arg := range args {
+		for _, flag := range storeFlags {
+			if arg == flag || strings.HasPrefix(arg, flag+"=") {
+				fmt.Printf("❌ %s só pode ser usado ao abrir o shell (ex: todo %s ... shell)\n", flag, flag)
+				return
+			}
+		}
+	}
+
+	rootCmd := cli.GetRootCommand()
+	args, err := cli.expandListRefs(rootCmd, args)
+	if err != nil {
+		fmt.Printf("❌ Referência inválida: %v\n", err)
+		return
+	}
+
+	rootCmd.SetArgs(args)
+	rootCmd.Execute()
+
+	// --no-hooks vale apenas para a linha em que foi usado
+	if toggle, ok := cli.todoUseCase.(app_interfaces.IHookToggle); ok {
+		toggle.EnableHooks()
+	}
+}
+
+// shellSubcommands lista os subcomandos que podem vir depois de words
+func (cli *TodoCLI) shellSubcommands(words []string) []string {
+	rootCmd := cli.GetRootCommand()
+	cmd, rest, err := rootCmd.Find(words)
+	if err != nil || len(rest) > 0 {
+		return nil
+	}
+
+	var names []string
+	for _, sub := range cmd.Commands() {
+		if sub.IsAvailableCommand() && sub.Name() != "shell" {
+			names = append(names, sub.Name())
+		}
+	}
+	return names
+}
+
+func (cli *TodoCLI) remember(todos []*entity.Todo) {
+	cli.listed = make([]string, len(todos))
+	for i, todo := range todos {
+		cli.listed[i] = todo.ID
+	}
+}
+
+// expandListRefs troca "2" ou "#2" pelo ID da segunda tarefa da última
+// listagem, apenas nos argumentos que são IDs de tarefa
+func (cli *TodoCLI) expandListRefs(rootCmd *cobra.Command, args []string) ([]string, error) {
+	cmd, _, err := rootCmd.Find(args)
+	if err != nil {
+		return args, nil
+	}
+	slots, ok := todoIDArgs[cmd.CommandPath()]
+	if !ok {
+		return args, nil
+	}
+	if idsOnly(cmd.CommandPath(), args) {
+		slots = -1
+	}
+
+	depth := len(strings.Fields(cmd.CommandPath())) - 1
+	expanded := append([]string(nil), args...)
+	positional := 0
+	onlyArgs := false
+	for i := 0; i < len(expanded); i++ {
+		arg := expanded[i]
+		if !onlyArgs && arg == "--" {
+			onlyArgs = true
+			continue
+		}
+		if !onlyArgs && strings.HasPrefix(arg, "-") && len(arg) > 1 {
+			if flagTakesValue(cmd, arg) {
+				i++
+			}
+			continue
+		}
+
+		index := positional - depth
+		positional++
+		if index < 0 || (slots >= 0 && index >= slots) {
+			continue
+		}
+
+		id, err := cli.listRef(arg)
+		if err != nil {
+			return nil, err
+		}
+		expanded[i] = id
+	}
+	return expanded, nil
+}
+
+// listRef resolve uma referência numérica; sem listagem, números sem "#"
+// continuam sendo tratados como IDs
+func (cli *TodoCLI) listRef(arg string) (string, error) {
+	text, marked := strings.CutPrefix(arg, "#")
+	number, err := strconv.Atoi(text)
+	if err != nil || (!marked && cli.listed == nil) {
+		return arg, nil
+	}
+	if number < 1 || number > len(cli.listed) {
+		return "", fmt.Errorf("no todo #%d in the last list", number)
+	}
+	return cli.listed[number-1], nil
+}
+
+// flagTakesValue informa se a flag consome o próximo argumento como valor
+func flagTakesValue(cmd *cobra.Command, arg string) bool {
+	if strings.Contains(arg, "=") {
+		return false
+	}
+	name := strings.TrimLeft(arg, "-")
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil {
+		flag = cmd.InheritedFlags().Lookup(name)
+	}
+	if flag == nil && !strings.HasPrefix(arg, "--") && len(name) == 1 {
+		flag = cmd.Flags().ShorthandLookup(name)
+	}
+	return flag != nil && flag.NoOptDefVal == ""
+}
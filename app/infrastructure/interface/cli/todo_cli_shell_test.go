@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/application"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// runShell executa o comando shell lendo o script como se fosse digitado
+func runShell(t *testing.T, cli *TodoCLI, script string) string {
+	r, w, err := os.Pipe()
+	assert.NoError(t, err)
+	w.WriteString(script)
+	w.Close()
+
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() {
+		os.Stdin = stdin
+		r.Close()
+	}()
+
+	return captureOutput(func() {
+		cli.shellCommand().Execute()
+	})
+}
+
+func TestShouldRunCommandsInsideShell(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("CreateTodo", "Comprar pão", "").Return(&entity.Todo{ID: "1", Title: "Comprar pão"}, nil)
+	mockUseCase.On("GetTodoByID", "1").Return(&entity.Todo{ID: "1", Title: "Comprar pão"}, nil)
+
+	// Act
+	output := runShell(t, cli, "create \"Comprar pão\"\nshow 1\nexit\nlist\n")
+
+	// Assert
+	assert.Contains(t, output, "🐚 Shell de tarefas")
+	assert.Contains(t, output, "✅ Tarefa criada com sucesso!")
+	assert.Contains(t, output, "📝 Título: Comprar pão")
+	mockUseCase.AssertExpectations(t)
+	mockUseCase.AssertNotCalled(t, "GetAllTodos")
+}
+
+func TestShouldResolveNumbersFromLastList(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("GetAllTodos").Return([]*entity.Todo{
+		{ID: "aaa", Title: "Primeira"},
+		{ID: "bbb", Title: "Segunda"},
+	}, nil)
+	mockUseCase.On("CompleteTodo", "bbb").Return(&entity.Todo{ID: "bbb", Title: "Segunda", Completed: true}, nil)
+	mockUseCase.On("GetTodoByID", "aaa").Return(&entity.Todo{ID: "aaa", Title: "Primeira"}, nil)
+
+	// Act
+	output := runShell(t, cli, "list\ncomplete 2\nshow #1\nshow 3\n")
+
+	// Assert
+	assert.Contains(t, output, "✅ Tarefa 'Segunda' marcada como concluída!")
+	assert.Contains(t, output, "📝 Título: Primeira")
+	assert.Contains(t, output, "❌ Referência inválida: no todo #3 in the last list")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldExpandOnlyTodoIDArguments(t *testing.T) {
+	// Arrange
+	cli := NewTodoCLI(new(application.MockTodoUseCase))
+	cli.listed = []string{"aaa", "bbb"}
+	rootCmd := cli.GetRootCommand()
+
+	// Act
+	update, updateErr := cli.expandListRefs(rootCmd, []string{"update", "2", "1", "--if-version", "1"})
+	bulkUpdate, _ := cli.expandListRefs(rootCmd, []string{"update", "2", "1", "--title", "Revisar"})
+	create, _ := cli.expandListRefs(rootCmd, []string{"create", "2"})
+	restore, _ := cli.expandListRefs(rootCmd, []string{"trash", "restore", "#1"})
+
+	// Assert
+	assert.NoError(t, updateErr)
+	assert.Equal(t, []string{"update", "bbb", "1", "--if-version", "1"}, update)
+	assert.Equal(t, []string{"update", "bbb", "aaa", "--title", "Revisar"}, bulkUpdate, "Expected every argument to be an ID with --title")
+	assert.Equal(t, []string{"create", "2"}, create)
+	assert.Equal(t, []string{"trash", "restore", "aaa"}, restore)
+}
+
+func TestShouldKeepNoHooksToASingleShellLine(t *testing.T) {
+	// Arrange
+	useCase := &hookedUseCase{MockTodoUseCase: new(application.MockTodoUseCase)}
+	cli := NewTodoCLI(useCase)
+	useCase.On("CompleteTodo", "1").Return(&entity.Todo{ID: "1", Title: "Quiet", Completed: true}, nil)
+
+	// Act
+	runShell(t, cli, "complete 1 --no-hooks\n")
+
+	// Assert
+	assert.False(t, useCase.disabled)
+	useCase.AssertExpectations(t)
+}
+
+func TestShouldKeepPipedLinesOutOfShellHistory(t *testing.T) {
+	// Arrange
+	filename := filepath.Join(t.TempDir(), "shell_history")
+	cli := NewTodoCLI(new(application.MockTodoUseCase), WithShellHistory(filename))
+
+	// Act
+	output := runShell(t, cli, "exit\n")
+	_, err := os.Stat(filename)
+
+	// Assert
+	assert.NotContains(t, output, "Histórico indisponível")
+	assert.True(t, os.IsNotExist(err), "Expected piped input to stay out of the history")
+}
+
+func TestShouldRejectStoreFlagsInsideShell(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+
+	// Act
+	output := runShell(t, cli, "list --profile work\n")
+
+	// Assert
+	assert.Contains(t, output, "❌ --profile só pode ser usado ao abrir o shell")
+	mockUseCase.AssertNotCalled(t, "GetAllTodos")
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func (cli *TodoCLI) storeCommand() *cobra.Command {
+	storeCmd := &cobra.Command{
+		Use:   "store",
+		Short: "Manutenção do armazenamento das tarefas",
+	}
+
+	storeCmd.AddCommand(cli.storeCompactCommand())
+
+	return storeCmd
+}
+
+func (cli *TodoCLI) storeCompactCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "compact",
+		Short: "Gravar um snapshot do estado atual e descartar o log de eventos já incluído nele",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			result, err := cli.todoUseCase.CompactStore()
+			if err != nil {
+				fmt.Printf("❌ Erro ao compactar armazenamento: %v\n", err)
+				return
+			}
+
+			fmt.Println("🗜️  Armazenamento compactado com sucesso!")
+			fmt.Printf("📦 Tarefas no snapshot: %d\n", result.Todos)
+			fmt.Printf("🔢 Último evento: %d\n", result.Sequence)
+			fmt.Printf("🧹 Log liberado: %d bytes\n", result.ReclaimedBytes)
+		},
+	}
+}
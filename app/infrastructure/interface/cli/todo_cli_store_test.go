@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"codecademy-yellowbelt2/infrastructure/interface/application"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldCompactStore(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("CompactStore").Return(repository.CompactResult{Todos: 3, Sequence: 42, ReclaimedBytes: 2048}, nil)
+
+	cmd := cli.storeCommand()
+	cmd.SetArgs([]string{"compact"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "🗜️  Armazenamento compactado com sucesso!")
+	assert.Contains(t, output, "📦 Tarefas no snapshot: 3")
+	assert.Contains(t, output, "🔢 Último evento: 42")
+	assert.Contains(t, output, "🧹 Log liberado: 2048 bytes")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldReportStoreCompactionError(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("CompactStore").Return(repository.CompactResult{}, errors.New("todo store does not support compaction"))
+
+	cmd := cli.storeCommand()
+	cmd.SetArgs([]string{"compact"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "❌ Erro ao compactar armazenamento: todo store does not support compaction")
+	mockUseCase.AssertExpectations(t)
+}
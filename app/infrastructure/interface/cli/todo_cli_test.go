@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -13,6 +14,7 @@ import (
 	"codecademy-yellowbelt2/infrastructure/interface/application"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func captureOutput(f func()) string {
@@ -238,6 +240,27 @@ func TestShouldUpdateTodoWithError(t *testing.T) {
 	mockUseCase.AssertExpectations(t)
 }
 
+func TestShouldReportVersionConflictOnUpdate(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	conflict := &entity.VersionConflictError{ID: "1", Expected: 2, Current: 3}
+	mockUseCase.On("UpdateTodoIfVersion", "1", 2, "Updated", "").Return(nil, conflict)
+
+	cmd := cli.updateCommand()
+	cmd.SetArgs([]string{"1", "Updated", "--if-version", "2"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "⚠️  Conflito de versão: a tarefa 1 está na versão 3 (você informou 2)")
+	assert.Contains(t, output, "--if-version 3")
+	mockUseCase.AssertExpectations(t)
+}
+
 func TestShouldCompleteTodoSuccessfully(t *testing.T) {
 	// Arrange
 	mockUseCase := new(application.MockTodoUseCase)
@@ -296,6 +319,7 @@ func TestShouldDeleteTodoSuccessfully(t *testing.T) {
 
 	// Assert
 	assert.Contains(t, output, "🗑️  Tarefa deletada com sucesso!")
+	assert.Contains(t, output, "todo trash restore 1")
 	mockUseCase.AssertExpectations(t)
 }
 
@@ -328,7 +352,7 @@ func TestShouldGetRootCommandWithAllSubcommands(t *testing.T) {
 
 	// Assert
 	assert.Equal(t, "todo", rootCmd.Use)
-	subcommands := []string{"create", "list", "show", "update", "complete", "delete"}
+	subcommands := []string{"create", "list", "show", "update", "complete", "delete", "assign", "history", "undo", "redo", "trash", "archive", "unarchive", "store", "webhook", "context", "move", "import", "export", "backup", "watch", "serve", "tui", "shell", "completion"}
 	for _, sub := range subcommands {
 		found := false
 		for _, c := range rootCmd.Commands() {
@@ -340,3 +364,547 @@ func TestShouldGetRootCommandWithAllSubcommands(t *testing.T) {
 		assert.True(t, found, "Subcommand %s not found", sub)
 	}
 }
+
+func TestShouldListTodosByCurrentUser(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase, WithCurrentUser("alice"))
+	todos := []*entity.Todo{
+		{ID: "1", Title: "A", Assignees: []string{"alice", "bob"}},
+	}
+	mockUseCase.On("GetTodosByAssignee", "alice").Return(todos, nil)
+
+	cmd := cli.listCommand()
+	cmd.SetArgs([]string{"--assignee", "me"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "1. ⏳ A")
+	assert.Contains(t, output, "   👤 alice, bob")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldListUnassignedTodos(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("GetTodosByAssignee", "").Return([]*entity.Todo{}, nil)
+
+	cmd := cli.listCommand()
+	cmd.SetArgs([]string{"--assignee", "none"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "📝 Nenhuma tarefa encontrada!")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldFailToListByMeWithoutCurrentUser(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+
+	cmd := cli.listCommand()
+	cmd.SetArgs([]string{"--assignee", "me"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "usuário atual não configurado")
+	mockUseCase.AssertNotCalled(t, "GetTodosByAssignee", "")
+}
+
+func TestShouldAssignTodoSuccessfully(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase, WithCurrentUser("alice"))
+	todo := &entity.Todo{ID: "1", Title: "Test", Assignees: []string{"alice", "bob"}}
+	mockUseCase.On("AssignTodo", "1", []string{"alice", "bob"}).Return(todo, nil)
+
+	cmd := cli.assignCommand()
+	cmd.SetArgs([]string{"1", "me", "bob"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "👤 Responsáveis de 'Test': alice, bob")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldUnassignTodoSuccessfully(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	todo := &entity.Todo{ID: "1", Title: "Test"}
+	mockUseCase.On("UnassignTodo", "1", []string{"bob"}).Return(todo, nil)
+
+	cmd := cli.assignCommand()
+	cmd.SetArgs([]string{"1", "bob", "--remove"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "👤 Tarefa 'Test' está sem responsáveis")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldAssignTodoWithError(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("AssignTodo", "1", []string{"bob"}).Return(nil, errors.New("fail"))
+
+	cmd := cli.assignCommand()
+	cmd.SetArgs([]string{"1", "bob"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "❌ Erro ao atribuir tarefa: fail")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldShowTodoHistorySuccessfully(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	now := time.Now()
+	entries := []*entity.HistoryEntry{
+		{TodoID: "1", Action: entity.HistoryActionCreated, Actor: "alice", Timestamp: now,
+			Changes: []entity.FieldChange{{Field: "title", NewValue: "Old"}}},
+		{TodoID: "1", Action: entity.HistoryActionUpdated, Actor: "bob", Timestamp: now,
+			Changes: []entity.FieldChange{{Field: "title", OldValue: "Old", NewValue: "New"}}},
+		{TodoID: "1", Action: entity.HistoryActionDeleted, Timestamp: now},
+	}
+	mockUseCase.On("GetTodoHistory", "1").Return(entries, nil)
+
+	cmd := cli.historyCommand()
+	cmd.SetArgs([]string{"1"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "📜 Histórico da tarefa 1")
+	assert.Contains(t, output, "✨ Criada por alice")
+	assert.Contains(t, output, "✏️  Atualizada por bob")
+	assert.Contains(t, output, "   - title: Old\n   + title: New")
+	assert.Contains(t, output, "🗑️  Deletada por desconhecido")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldShowTodoHistoryWithConfiguredDateFormat(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase, WithDateFormat("2006-01-02 15:04"))
+	timestamp := time.Date(2024, time.March, 5, 9, 30, 0, 0, time.Local)
+	mockUseCase.On("GetTodoHistory", "1").Return([]*entity.HistoryEntry{
+		{TodoID: "1", Action: entity.HistoryActionCreated, Actor: "alice", Timestamp: timestamp},
+	}, nil)
+
+	cmd := cli.historyCommand()
+	cmd.SetArgs([]string{"1"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "2024-03-05 09:30  ✨ Criada por alice")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldShowTodoHistoryWithError(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("GetTodoHistory", "1").Return(nil, errors.New("fail"))
+
+	cmd := cli.historyCommand()
+	cmd.SetArgs([]string{"1"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "❌ Erro ao buscar histórico: fail")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldUndoLastOperation(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	operation := &entity.Operation{Type: entity.OperationDelete, Before: &entity.Todo{ID: "1", Title: "Test"}}
+	mockUseCase.On("Undo").Return(operation, nil)
+
+	cmd := cli.undoCommand()
+	cmd.SetArgs([]string{})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "↩️  Desfeito: remoção de 'Test'")
+	assert.Contains(t, output, "🆔 ID: 1")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldRedoLastUndoneOperation(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	operation := &entity.Operation{Type: entity.OperationCreate, After: &entity.Todo{ID: "1", Title: "Test"}}
+	mockUseCase.On("Redo").Return(operation, nil)
+
+	cmd := cli.redoCommand()
+	cmd.SetArgs([]string{})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "↪️  Refeito: criação de 'Test'")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldUndoWithError(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("Undo").Return(nil, errors.New("nothing to undo"))
+
+	cmd := cli.undoCommand()
+	cmd.SetArgs([]string{})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "❌ Erro ao desfazer: nothing to undo")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldListTrashSuccessfully(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	deletedAt := time.Now()
+	todos := []*entity.Todo{{ID: "1", Title: "Deleted", DeletedAt: &deletedAt}}
+	mockUseCase.On("GetDeletedTodos").Return(todos, nil)
+
+	cmd := cli.trashListCommand()
+	cmd.SetArgs([]string{})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "🗑️  Tarefas na lixeira: 1")
+	assert.Contains(t, output, "1. Deleted")
+	assert.Contains(t, output, fmt.Sprintf("   🕒 Deletada em: %s", deletedAt.Format("02/01/2006 15:04")))
+	assert.Contains(t, output, "   🆔 ID: 1")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldListEmptyTrash(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("GetDeletedTodos").Return([]*entity.Todo{}, nil)
+
+	cmd := cli.trashListCommand()
+	cmd.SetArgs([]string{})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "🗑️  A lixeira está vazia!")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldRestoreTodoFromTrash(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("RestoreTodo", "1").Return(&entity.Todo{ID: "1", Title: "Back"}, nil)
+
+	cmd := cli.trashRestoreCommand()
+	cmd.SetArgs([]string{"1"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "♻️  Tarefa 'Back' restaurada com sucesso!")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldPurgeTrashOlderThanDays(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("PurgeTodos", 30*24*time.Hour).Return([]*entity.Todo{{ID: "1"}, {ID: "2"}}, nil)
+
+	cmd := cli.trashPurgeCommand()
+	cmd.SetArgs([]string{"--older-than", "30d"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "🔥 2 tarefa(s) removida(s) definitivamente")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldRejectInvalidPurgeAge(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+
+	cmd := cli.trashPurgeCommand()
+	cmd.SetArgs([]string{"--older-than", "soon"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "❌ Valor inválido para --older-than")
+	mockUseCase.AssertNotCalled(t, "PurgeTodos", mock.Anything)
+}
+
+func TestShouldListArchivedTodos(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	todos := []*entity.Todo{{ID: "1", Title: "Old", Completed: true}}
+	mockUseCase.On("GetArchivedTodos").Return(todos, nil)
+
+	cmd := cli.listCommand()
+	cmd.SetArgs([]string{"--archived"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "1. ✅ Old")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldArchiveTodosCompletedBeforeDate(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	cutoff := time.Date(2025, 8, 1, 0, 0, 0, 0, time.Local)
+	mockUseCase.On("ArchiveTodos", cutoff).Return([]*entity.Todo{{ID: "1"}}, nil)
+
+	cmd := cli.archiveCommand()
+	cmd.SetArgs([]string{"--completed-before", "2025-08-01"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "📦 1 tarefa(s) arquivada(s)")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldRejectInvalidArchiveDate(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+
+	cmd := cli.archiveCommand()
+	cmd.SetArgs([]string{"--completed-before", "yesterday"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "❌ Valor inválido para --completed-before")
+	mockUseCase.AssertNotCalled(t, "ArchiveTodos", mock.Anything)
+}
+
+func TestShouldUnarchiveTodo(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("UnarchiveTodo", "1").Return(&entity.Todo{ID: "1", Title: "Back"}, nil)
+
+	cmd := cli.unarchiveCommand()
+	cmd.SetArgs([]string{"1"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "📤 Tarefa 'Back' desarquivada com sucesso!")
+	mockUseCase.AssertExpectations(t)
+}
+
+// hookedUseCase simula um use case com hooks para conferir o --no-hooks
+type hookedUseCase struct {
+	*application.MockTodoUseCase
+	disabled bool
+}
+
+func (h *hookedUseCase) DisableHooks() {
+	h.disabled = true
+}
+
+func (h *hookedUseCase) EnableHooks() {
+	h.disabled = false
+}
+
+func TestShouldDisableHooksWithNoHooksFlag(t *testing.T) {
+	// Arrange
+	useCase := &hookedUseCase{MockTodoUseCase: new(application.MockTodoUseCase)}
+	cli := NewTodoCLI(useCase)
+	useCase.On("CompleteTodo", "1").Return(&entity.Todo{ID: "1", Title: "Quiet", Completed: true}, nil)
+
+	rootCmd := cli.GetRootCommand()
+	rootCmd.SetArgs([]string{"complete", "1", "--no-hooks"})
+
+	// Act
+	captureOutput(func() {
+		rootCmd.Execute()
+	})
+
+	// Assert
+	assert.True(t, useCase.disabled)
+	useCase.AssertExpectations(t)
+}
+
+func TestShouldKeepHooksEnabledByDefault(t *testing.T) {
+	// Arrange
+	useCase := &hookedUseCase{MockTodoUseCase: new(application.MockTodoUseCase)}
+	cli := NewTodoCLI(useCase)
+	useCase.On("CompleteTodo", "1").Return(&entity.Todo{ID: "1", Title: "Loud", Completed: true}, nil)
+
+	rootCmd := cli.GetRootCommand()
+	rootCmd.SetArgs([]string{"complete", "1"})
+
+	// Act
+	captureOutput(func() {
+		rootCmd.Execute()
+	})
+
+	// Assert
+	assert.False(t, useCase.disabled)
+}
+
+func TestShouldListTodosAsJSON(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("GetAllTodos").Return([]*entity.Todo{{ID: "1", Title: "Comprar pão"}}, nil)
+
+	rootCmd := cli.GetRootCommand()
+	rootCmd.SetArgs([]string{"list", "-o", "json"})
+
+	// Act
+	output := captureOutput(func() {
+		rootCmd.Execute()
+	})
+
+	// Assert
+	var todos []entity.Todo
+	assert.NoError(t, json.Unmarshal([]byte(output), &todos))
+	assert.Equal(t, "Comprar pão", todos[0].Title)
+}
+
+func TestShouldUseConfiguredOutputFormatAndDateFormat(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase, WithOutputFormat("json"), WithDateFormat("2006-01-02"))
+	created := time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC)
+	mockUseCase.On("GetAllTodos").Return([]*entity.Todo{}, nil)
+	mockUseCase.On("GetTodoByID", "1").Return(&entity.Todo{ID: "1", Title: "Pão", CreatedAt: created, UpdatedAt: created}, nil)
+
+	listCmd := cli.GetRootCommand()
+	listCmd.SetArgs([]string{"list"})
+	showCmd := cli.GetRootCommand()
+	showCmd.SetArgs([]string{"show", "1", "--output", "text"})
+
+	// Act
+	listOutput := captureOutput(func() {
+		listCmd.Execute()
+	})
+	showOutput := captureOutput(func() {
+		showCmd.Execute()
+	})
+
+	// Assert
+	assert.Equal(t, "[]\n", listOutput)
+	assert.Contains(t, showOutput, "📅 Criada em: 2026-10-19")
+}
+
+func TestShouldRejectUnknownOutputFormat(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+
+	rootCmd := cli.GetRootCommand()
+	rootCmd.SetArgs([]string{"list", "-o", "xml"})
+	rootCmd.SetErr(&bytes.Buffer{})
+
+	// Act
+	var err error
+	captureOutput(func() {
+		err = rootCmd.Execute()
+	})
+
+	// Assert
+	assert.ErrorContains(t, err, `unknown output format "xml"`)
+	mockUseCase.AssertNotCalled(t, "GetAllTodos")
+}
@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	app_interfaces "codecademy-yellowbelt2/infrastructure/interface/application"
+	"codecademy-yellowbelt2/infrastructure/interface/transfer"
+)
+
+// formatNames lista os formatos registrados em ordem alfabética
+func (cli *TodoCLI) formatNames() []string {
+	names := make([]string, 0, len(cli.formats))
+	for name := range cli.formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (cli *TodoCLI) lookupFormat(name string) (transfer.ITodoFormat, error) {
+	format, ok := cli.formats[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q (expected %s)", name, strings.Join(cli.formatNames(), ", "))
+	}
+	return format, nil
+}
+
+// transferFlags reúne as flags de formato comuns a import e export
+type transferFlags struct {
+	format    string
+	columns   map[string]string
+	delimiter string
+	encoding  string
+}
+
+func (f *transferFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.format, "format", "", "Formato do arquivo")
+	cmd.Flags().StringToStringVar(&f.columns, "map", nil, "Nome da coluna de cada campo (ex: Title=Tarefa,Description=Notas)")
+	cmd.Flags().StringVar(&f.delimiter, "delimiter", "", "Separador de colunas (um caractere ou tab)")
+	cmd.Flags().StringVar(&f.encoding, "encoding", "", "Codificação do arquivo ("+strings.Join(transfer.Encodings, ", ")+")")
+	cmd.MarkFlagRequired("format")
+}
+
+// resolveFormat busca o formato e, se alguma opção foi passada, aplica as
+// opções nos formatos que as aceitam
+func (cli *TodoCLI) resolveFormat(flags transferFlags) (transfer.ITodoFormat, error) {
+	format, err := cli.lookupFormat(flags.format)
+	if err != nil {
+		return nil, err
+	}
+	if len(flags.columns) == 0 && flags.delimiter == "" && flags.encoding == "" {
+		return format, nil
+	}
+
+	configurable, ok := format.(transfer.IConfigurableFormat)
+	if !ok {
+		return nil, fmt.Errorf("format %q does not accept --map, --delimiter or --encoding", flags.format)
+	}
+	delimiter, err := parseDelimiter(flags.delimiter)
+	if err != nil {
+		return nil, err
+	}
+	return configurable.WithOptions(transfer.Options{Columns: flags.columns, Delimiter: delimiter, Encoding: flags.encoding})
+}
+
+func parseDelimiter(value string) (rune, error) {
+	switch value {
+	case "":
+		return 0, nil
+	case "tab", `\t`:
+		return '\t', nil
+	}
+	if utf8.RuneCountInString(value) != 1 {
+		return 0, fmt.Errorf("invalid delimiter %q (expected a single character or tab)", value)
+	}
+	delimiter, _ := utf8.DecodeRuneInString(value)
+	return delimiter, nil
+}
+
+func (cli *TodoCLI) importCommand() *cobra.Command {
+	var flags transferFlags
+	var dryRun bool
+	var dedup string
+
+	cmd := &cobra.Command{
+		Use:   "import [arquivo]",
+		Short: "Importar tarefas de um arquivo de outra ferramenta (- para a entrada padrão)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if dedup != dedupByID && dedup != dedupByTitle {
+				fmt.Printf("❌ Erro ao importar: invalid dedup mode %q (expected %s or %s)\n", dedup, dedupByID, dedupByTitle)
+				return
+			}
+			format, err := cli.resolveFormat(flags)
+			if err != nil {
+				fmt.Printf("❌ Erro ao importar: %v\n", err)
+				return
+			}
+
+			var in io.Reader = cmd.InOrStdin()
+			if args[0] != "-" {
+				file, err := os.Open(args[0])
+				if err != nil {
+					fmt.Printf("❌ Erro ao abrir arquivo: %v\n", err)
+					return
+				}
+				defer file.Close()
+				in = file
+			}
+
+			decoded, err := format.Decode(in)
+			if err != nil {
+				fmt.Printf("❌ Erro ao ler arquivo: %v\n", err)
+				return
+			}
+
+			results, err := cli.todoUseCase.ImportTodos(app_interfaces.Import{
+				Todos:      decoded.Todos,
+				MatchTitle: dedup == dedupByTitle,
+				DryRun:     dryRun,
+			})
+			if err != nil {
+				fmt.Printf("❌ Erro ao importar: %v\n", err)
+				return
+			}
+
+			printImportReport(results, decoded, dryRun)
+		},
+	}
+
+	flags.register(cmd)
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Apenas validar e mostrar o que seria importado, sem gravar")
+	cmd.Flags().StringVar(&dedup, "dedup", dedupByID, "Como reconhecer tarefas já existentes (id ou title)")
+
+	return cmd
+}
+
+const (
+	dedupByID    = "id"
+	dedupByTitle = "title"
+)
+
+func printImportReport(results []app_interfaces.ImportResult, decoded *transfer.Decoded, dryRun bool) {
+	created := app_interfaces.CountImportResults(results, app_interfaces.ImportCreated)
+	updated := app_interfaces.CountImportResults(results, app_interfaces.ImportUpdated)
+	unchanged := app_interfaces.CountImportResults(results, app_interfaces.ImportUnchanged)
+	if dryRun {
+		fmt.Printf("🔍 Simulação: %d seriam criadas, %d atualizadas, %d sem alteração (nada foi gravado)\n", created, updated, unchanged)
+	} else {
+		fmt.Printf("📥 Importação concluída: %d criadas, %d atualizadas, %d sem alteração\n", created, updated, unchanged)
+	}
+
+	for i, result := range results {
+		if result.Err == nil {
+			continue
+		}
+		if i < len(decoded.Lines) {
+			fmt.Printf("❌ linha %d: %s: %v\n", decoded.Lines[i], decoded.Todos[i].Title, result.Err)
+		} else {
+			fmt.Printf("❌ %s: %v\n", decoded.Todos[i].Title, result.Err)
+		}
+	}
+	if len(decoded.Skipped) > 0 {
+		fmt.Printf("\n⚠️  Linhas ignoradas: %d\n", len(decoded.Skipped))
+		for _, issue := range decoded.Skipped {
+			fmt.Printf("   %s\n", issue)
+		}
+	}
+	if len(decoded.Unmapped) > 0 {
+		fmt.Printf("\n💡 Trechos sem campo correspondente: %d\n", len(decoded.Unmapped))
+		for _, issue := range decoded.Unmapped {
+			fmt.Printf("   %s\n", issue)
+		}
+	}
+}
+
+func (cli *TodoCLI) exportCommand() *cobra.Command {
+	var flags transferFlags
+
+	cmd := &cobra.Command{
+		Use:   "export [arquivo]",
+		Short: "Exportar as tarefas para o formato de outra ferramenta (sem arquivo, na saída padrão)",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			format, err := cli.resolveFormat(flags)
+			if err != nil {
+				fmt.Printf("❌ Erro ao exportar: %v\n", err)
+				return
+			}
+
+			todos, err := cli.todoUseCase.GetAllTodos()
+			if err != nil {
+				fmt.Printf("❌ Erro ao listar tarefas: %v\n", err)
+				return
+			}
+
+			if len(args) == 0 || args[0] == "-" {
+				if err := format.Encode(cmd.OutOrStdout(), todos); err != nil {
+					fmt.Printf("❌ Erro ao exportar: %v\n", err)
+				}
+				return
+			}
+
+			var out bytes.Buffer
+			if err := format.Encode(&out, todos); err != nil {
+				fmt.Printf("❌ Erro ao exportar: %v\n", err)
+				return
+			}
+			if err := os.WriteFile(args[0], out.Bytes(), 0644); err != nil {
+				fmt.Printf("❌ Erro ao gravar arquivo: %v\n", err)
+				return
+			}
+
+			fmt.Printf("📤 %d tarefas exportadas para %s\n", len(todos), args[0])
+		},
+	}
+
+	flags.register(cmd)
+
+	return cmd
+}
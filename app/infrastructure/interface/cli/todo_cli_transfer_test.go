@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/application"
+	"codecademy-yellowbelt2/infrastructure/interface/transfer"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestShouldImportTodosAndReportIssues(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	mockFormat := new(transfer.MockTodoFormat)
+	cli := NewTodoCLI(mockUseCase, WithFormat("todotxt", mockFormat))
+	todos := []*entity.Todo{{ID: "a1", Title: "Nova"}, {ID: "b2", Title: "Existente"}, {ID: "c3", Title: "Quebrada"}}
+	mockFormat.On("Decode", mock.Anything).Return(&transfer.Decoded{
+		Todos:    todos,
+		Skipped:  []transfer.Issue{{Line: 4, Text: "(B) +projeto", Reason: "missing task description"}},
+		Unmapped: []transfer.Issue{{Line: 2, Text: "rec:1w", Reason: "unknown key"}},
+	}, nil)
+	mockUseCase.On("ImportTodos", application.Import{Todos: todos}).Return([]application.ImportResult{
+		{Todo: todos[0], Action: application.ImportCreated},
+		{Todo: todos[1], Action: application.ImportUpdated},
+		{Err: errors.New("todo is archived")},
+	}, nil)
+
+	cmd := cli.importCommand()
+	cmd.SetIn(strings.NewReader("conteúdo"))
+	cmd.SetArgs([]string{"-", "--format", "todotxt"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "📥 Importação concluída: 1 criadas, 1 atualizadas, 0 sem alteração")
+	assert.Contains(t, output, "❌ Quebrada: todo is archived")
+	assert.Contains(t, output, "linha 4: (B) +projeto (missing task description)")
+	assert.Contains(t, output, "linha 2: rec:1w (unknown key)")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldExportTodosToStdoutOrFile(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	mockFormat := new(transfer.MockTodoFormat)
+	cli := NewTodoCLI(mockUseCase, WithFormat("todotxt", mockFormat))
+	todos := []*entity.Todo{{ID: "a1", Title: "Comprar pão"}}
+	mockUseCase.On("GetAllTodos").Return(todos, nil)
+	mockFormat.On("Encode", mock.Anything, todos).Run(func(args mock.Arguments) {
+		io.WriteString(args.Get(0).(io.Writer), "Comprar pão id:a1\n")
+	}).Return(nil)
+	file := filepath.Join(t.TempDir(), "todo.txt")
+
+	// Act
+	var stdout bytes.Buffer
+	toStdout := cli.exportCommand()
+	toStdout.SetOut(&stdout)
+	toStdout.SetArgs([]string{"--format", "todotxt"})
+	toStdout.Execute()
+
+	output := captureOutput(func() {
+		toFile := cli.exportCommand()
+		toFile.SetArgs([]string{file, "--format", "todotxt"})
+		toFile.Execute()
+	})
+	written, err := os.ReadFile(file)
+
+	// Assert
+	assert.Equal(t, "Comprar pão id:a1\n", stdout.String())
+	assert.NoError(t, err)
+	assert.Equal(t, "Comprar pão id:a1\n", string(written))
+	assert.Contains(t, output, "📤 1 tarefas exportadas para "+file)
+}
+
+func TestShouldRejectUnknownTransferFormat(t *testing.T) {
+	// Arrange
+	cli := NewTodoCLI(new(application.MockTodoUseCase), WithFormat("todotxt", new(transfer.MockTodoFormat)))
+	cmd := cli.exportCommand()
+	cmd.SetArgs([]string{"--format", "csv"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, `unknown format "csv" (expected todotxt)`)
+}
+
+// configurableFormat registra as opções recebidas para os testes de --map e afins
+type configurableFormat struct {
+	*transfer.MockTodoFormat
+	options transfer.Options
+}
+
+func (f *configurableFormat) WithOptions(options transfer.Options) (transfer.ITodoFormat, error) {
+	f.options = options
+	return f.MockTodoFormat, nil
+}
+
+func TestShouldSimulateImportWithOptionsAndDedupByTitle(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	format := &configurableFormat{MockTodoFormat: new(transfer.MockTodoFormat)}
+	cli := NewTodoCLI(mockUseCase, WithFormat("csv", format))
+	todos := []*entity.Todo{{Title: "Nova"}, {Title: "Quebrada"}}
+	format.On("Decode", mock.Anything).Return(&transfer.Decoded{Todos: todos, Lines: []int{2, 5}}, nil)
+	mockUseCase.On("ImportTodos", application.Import{Todos: todos, MatchTitle: true, DryRun: true}).Return([]application.ImportResult{
+		{Todo: todos[0], Action: application.ImportCreated},
+		{Err: errors.New("invalid priority")},
+	}, nil)
+
+	cmd := cli.importCommand()
+	cmd.SetIn(strings.NewReader("conteúdo"))
+	cmd.SetArgs([]string{"-", "--format", "csv", "--map", "Title=Tarefa,Description=Notas", "--delimiter", "tab",
+		"--encoding", "windows-1252", "--dedup", "title", "--dry-run"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Equal(t, transfer.Options{
+		Columns:   map[string]string{"Title": "Tarefa", "Description": "Notas"},
+		Delimiter: '\t',
+		Encoding:  "windows-1252",
+	}, format.options)
+	assert.Contains(t, output, "🔍 Simulação: 1 seriam criadas, 0 atualizadas, 0 sem alteração (nada foi gravado)")
+	assert.Contains(t, output, "❌ linha 5: Quebrada: invalid priority")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldRejectInvalidTransferOptions(t *testing.T) {
+	// Arrange
+	cli := NewTodoCLI(new(application.MockTodoUseCase),
+		WithFormat("todotxt", new(transfer.MockTodoFormat)),
+		WithFormat("csv", &configurableFormat{MockTodoFormat: new(transfer.MockTodoFormat)}))
+
+	run := func(cmd *cobra.Command, args ...string) string {
+		return captureOutput(func() {
+			cmd.SetArgs(args)
+			cmd.Execute()
+		})
+	}
+
+	// Act
+	notConfigurable := run(cli.exportCommand(), "--format", "todotxt", "--delimiter", ";")
+	badDelimiter := run(cli.exportCommand(), "--format", "csv", "--delimiter", ";;")
+	badDedup := run(cli.importCommand(), "-", "--format", "csv", "--dedup", "hash")
+
+	// Assert
+	assert.Contains(t, notConfigurable, `format "todotxt" does not accept --map, --delimiter or --encoding`)
+	assert.Contains(t, badDelimiter, `invalid delimiter ";;" (expected a single character or tab)`)
+	assert.Contains(t, badDedup, `invalid dedup mode "hash" (expected id or title)`)
+}
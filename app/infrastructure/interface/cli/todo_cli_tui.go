@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"codecademy-yellowbelt2/infrastructure/interface/tui"
+)
+
+func (cli *TodoCLI) tuiCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tui",
+		Short: "Abrir a interface interativa em tela cheia",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+			defer stop()
+
+			if err := tui.Run(ctx, cli.todoUseCase, os.Stdin, os.Stdout); err != nil {
+				fmt.Printf("❌ Erro na interface interativa: %v\n", err)
+			}
+		},
+	}
+}
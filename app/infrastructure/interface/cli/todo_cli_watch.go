@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+)
+
+var eventIcons = map[string]string{
+	entity.EventTodoCreated:    "➕",
+	entity.EventTodoUpdated:    "✏️ ",
+	entity.EventTodoCompleted:  "✅",
+	entity.EventTodoDeleted:    "🗑️ ",
+	entity.EventTodoRestored:   "♻️ ",
+	entity.EventTodoPurged:     "🔥",
+	entity.EventTodoArchived:   "📦",
+	entity.EventTodoUnarchived: "📤",
+}
+
+func (cli *TodoCLI) watchCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "watch",
+		Short: "Acompanhar ao vivo as alterações nas tarefas (Ctrl+C para sair)",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			changes, err := cli.todoUseCase.WatchTodos(ctx)
+			if err != nil {
+				fmt.Printf("❌ Erro ao acompanhar tarefas: %v\n", err)
+				return
+			}
+
+			fmt.Println("👀 Acompanhando alterações... (Ctrl+C para sair)")
+			for event := range changes {
+				printChange(event)
+			}
+		},
+	}
+}
+
+func printChange(event *entity.Event) {
+	icon, ok := eventIcons[event.Type]
+	if !ok {
+		icon = "🔔"
+	}
+
+	title := event.TodoID
+	if event.Todo != nil {
+		title = event.Todo.Title
+	} else if event.Previous != nil {
+		title = event.Previous.Title
+	}
+
+	fmt.Printf("[%s] %s %-16s %s (🆔 %s)\n", event.Timestamp.Local().Format("15:04:05"), icon, event.Type, title, event.TodoID)
+}
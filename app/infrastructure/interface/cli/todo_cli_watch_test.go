@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/application"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestShouldPrintChangesWhileWatching(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	at := time.Date(2026, 10, 19, 9, 30, 0, 0, time.Local)
+	changes := make(chan *entity.Event, 2)
+	changes <- &entity.Event{Type: entity.EventTodoCompleted, TodoID: "1", Timestamp: at, Todo: &entity.Todo{ID: "1", Title: "Ship it"}}
+	changes <- &entity.Event{Type: entity.EventTodoPurged, TodoID: "2", Timestamp: at, Previous: &entity.Todo{ID: "2", Title: "Old"}}
+	close(changes)
+	mockUseCase.On("WatchTodos", mock.Anything).Return((<-chan *entity.Event)(changes), nil)
+
+	cmd := cli.watchCommand()
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "👀 Acompanhando alterações...")
+	assert.Contains(t, output, "[09:30:00] ✅ todo.completed   Ship it (🆔 1)")
+	assert.Contains(t, output, "[09:30:00] 🔥 todo.purged      Old (🆔 2)")
+	mockUseCase.AssertExpectations(t)
+}
+
+func TestShouldReportWatchError(t *testing.T) {
+	// Arrange
+	mockUseCase := new(application.MockTodoUseCase)
+	cli := NewTodoCLI(mockUseCase)
+	mockUseCase.On("WatchTodos", mock.Anything).Return(nil, errors.New("todo store does not support watching"))
+
+	cmd := cli.watchCommand()
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "❌ Erro ao acompanhar tarefas: todo store does not support watching")
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+)
+
+func (cli *TodoCLI) webhookCommand() *cobra.Command {
+	webhookCmd := &cobra.Command{
+		Use:   "webhook",
+		Short: "Gerenciar webhooks notificados a cada alteração das tarefas",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if cli.webhookUseCase == nil {
+				return fmt.Errorf("webhooks are not enabled")
+			}
+			return nil
+		},
+	}
+
+	webhookCmd.AddCommand(cli.webhookListCommand())
+	webhookCmd.AddCommand(cli.webhookAddCommand())
+	webhookCmd.AddCommand(cli.webhookRemoveCommand())
+	webhookCmd.AddCommand(cli.webhookTestCommand())
+
+	return webhookCmd
+}
+
+func (cli *TodoCLI) webhookListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Listar os webhooks cadastrados",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			webhooks, err := cli.webhookUseCase.ListWebhooks()
+			if err != nil {
+				fmt.Printf("❌ Erro ao listar webhooks: %v\n", err)
+				return
+			}
+
+			if len(webhooks) == 0 {
+				fmt.Println("🔕 Nenhum webhook cadastrado!")
+				return
+			}
+
+			fmt.Printf("🔔 Total de webhooks: %d\n\n", len(webhooks))
+			for i, webhook := range webhooks {
+				fmt.Printf("%d. %s\n", i+1, webhook.URL)
+				fmt.Printf("   📨 Eventos: %s\n", webhookEvents(webhook))
+				fmt.Printf("   🆔 ID: %s\n", webhook.ID)
+				fmt.Println()
+			}
+		},
+	}
+}
+
+func (cli *TodoCLI) webhookAddCommand() *cobra.Command {
+	var events []string
+	var secret string
+
+	cmd := &cobra.Command{
+		Use:   "add [url]",
+		Short: "Cadastrar um webhook",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			webhook, err := cli.webhookUseCase.AddWebhook(args[0], events, secret)
+			if err != nil {
+				fmt.Printf("❌ Erro ao cadastrar webhook: %v\n", err)
+				return
+			}
+
+			fmt.Println("✅ Webhook cadastrado com sucesso!")
+			fmt.Printf("ID: %s\n", webhook.ID)
+			fmt.Printf("URL: %s\n", webhook.URL)
+			fmt.Printf("Eventos: %s\n", webhookEvents(webhook))
+			if secret == "" {
+				// O segredo gerado só é mostrado aqui para configurar o destino
+				fmt.Printf("🔑 Segredo: %s\n", webhook.Secret)
+			}
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&events, "events", nil, fmt.Sprintf("Eventos enviados, separados por vírgula (%s)", strings.Join(entity.EventTypes, ", ")))
+	cmd.Flags().StringVar(&secret, "secret", "", "Segredo da assinatura HMAC-SHA256 (gerado se omitido)")
+
+	return cmd
+}
+
+func (cli *TodoCLI) webhookRemoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove [id]",
+		Short: "Remover um webhook",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cli.webhookUseCase.RemoveWebhook(args[0]); err != nil {
+				fmt.Printf("❌ Erro ao remover webhook: %v\n", err)
+				return
+			}
+
+			fmt.Println("🗑️  Webhook removido com sucesso!")
+		},
+	}
+}
+
+func (cli *TodoCLI) webhookTestCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "test [id]",
+		Short: "Enviar um evento de teste para o webhook",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cli.webhookUseCase.TestWebhook(args[0]); err != nil {
+				fmt.Printf("❌ Falha ao enviar evento de teste: %v\n", err)
+				return
+			}
+
+			fmt.Printf("📡 Evento %s entregue com sucesso!\n", entity.EventWebhookPing)
+		},
+	}
+}
+
+func webhookEvents(webhook *entity.Webhook) string {
+	if len(webhook.Events) == 0 {
+		return "todos"
+	}
+	return strings.Join(webhook.Events, ", ")
+}
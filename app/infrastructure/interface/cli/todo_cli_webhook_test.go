@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/application"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldAddWebhookWithEventFilter(t *testing.T) {
+	// Arrange
+	mockWebhooks := new(application.MockWebhookUseCase)
+	cli := NewTodoCLI(new(application.MockTodoUseCase), WithWebhooks(mockWebhooks))
+	webhook := &entity.Webhook{ID: "w1", URL: "https://chat.example.com/hook", Events: []string{entity.EventTodoCompleted}, Secret: "generated"}
+	mockWebhooks.On("AddWebhook", "https://chat.example.com/hook", []string{entity.EventTodoCompleted}, "").Return(webhook, nil)
+
+	cmd := cli.webhookCommand()
+	cmd.SetArgs([]string{"add", "https://chat.example.com/hook", "--events", entity.EventTodoCompleted})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "✅ Webhook cadastrado com sucesso!")
+	assert.Contains(t, output, "Eventos: todo.completed")
+	assert.Contains(t, output, "🔑 Segredo: generated")
+	mockWebhooks.AssertExpectations(t)
+}
+
+func TestShouldListWebhooks(t *testing.T) {
+	// Arrange
+	mockWebhooks := new(application.MockWebhookUseCase)
+	cli := NewTodoCLI(new(application.MockTodoUseCase), WithWebhooks(mockWebhooks))
+	mockWebhooks.On("ListWebhooks").Return([]*entity.Webhook{{ID: "w1", URL: "https://example.com/hook"}}, nil)
+
+	cmd := cli.webhookCommand()
+	cmd.SetArgs([]string{"list"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "🔔 Total de webhooks: 1")
+	assert.Contains(t, output, "1. https://example.com/hook")
+	assert.Contains(t, output, "📨 Eventos: todos")
+	assert.NotContains(t, output, "Segredo")
+	mockWebhooks.AssertExpectations(t)
+}
+
+func TestShouldRemoveWebhook(t *testing.T) {
+	// Arrange
+	mockWebhooks := new(application.MockWebhookUseCase)
+	cli := NewTodoCLI(new(application.MockTodoUseCase), WithWebhooks(mockWebhooks))
+	mockWebhooks.On("RemoveWebhook", "w1").Return(nil)
+
+	cmd := cli.webhookCommand()
+	cmd.SetArgs([]string{"remove", "w1"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "🗑️  Webhook removido com sucesso!")
+	mockWebhooks.AssertExpectations(t)
+}
+
+func TestShouldReportWebhookTestFailure(t *testing.T) {
+	// Arrange
+	mockWebhooks := new(application.MockWebhookUseCase)
+	cli := NewTodoCLI(new(application.MockTodoUseCase), WithWebhooks(mockWebhooks))
+	mockWebhooks.On("TestWebhook", "w1").Return(errors.New("webhook responded with status 404"))
+
+	cmd := cli.webhookCommand()
+	cmd.SetArgs([]string{"test", "w1"})
+
+	// Act
+	output := captureOutput(func() {
+		cmd.Execute()
+	})
+
+	// Assert
+	assert.Contains(t, output, "❌ Falha ao enviar evento de teste: webhook responded with status 404")
+	mockWebhooks.AssertExpectations(t)
+}
+
+func TestShouldRefuseWebhookCommandsWhenDisabled(t *testing.T) {
+	// Arrange
+	cli := NewTodoCLI(new(application.MockTodoUseCase))
+	cmd := cli.webhookCommand()
+	cmd.SetArgs([]string{"list"})
+	cmd.SilenceUsage = true
+
+	// Act
+	err := cmd.Execute()
+
+	// Assert
+	assert.EqualError(t, err, "webhooks are not enabled")
+}
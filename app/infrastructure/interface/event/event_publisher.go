@@ -0,0 +1,34 @@
+package event
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// IEventPublisher recebe os eventos de domínio emitidos pelo TodoUseCase
+// depois que as alterações foram gravadas
+type IEventPublisher interface {
+	Publish(event *entity.Event)
+}
+
+// ISubscriber reage aos eventos publicados; um erro afeta apenas o próprio
+// assinante
+type ISubscriber interface {
+	Handle(event *entity.Event) error
+}
+
+// SubscriberFunc permite usar uma função simples como assinante
+type SubscriberFunc func(event *entity.Event) error
+
+func (f SubscriberFunc) Handle(event *entity.Event) error {
+	return f(event)
+}
+
+type MockEventPublisher struct {
+	mock.Mock
+}
+
+func (m *MockEventPublisher) Publish(event *entity.Event) {
+	m.Called(event)
+}
@@ -0,0 +1,29 @@
+package event
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// IWebhookSender entrega um evento a um webhook e retorna erro quando o
+// destino não confirma o recebimento; Post reenvia uma entrega já montada,
+// com o mesmo payload, nas novas tentativas
+type IWebhookSender interface {
+	Send(webhook *entity.Webhook, event *entity.Event) error
+	Post(webhook *entity.Webhook, delivery *entity.WebhookDelivery) error
+}
+
+type MockWebhookSender struct {
+	mock.Mock
+}
+
+func (m *MockWebhookSender) Send(webhook *entity.Webhook, event *entity.Event) error {
+	args := m.Called(webhook, event)
+	return args.Error(0)
+}
+
+func (m *MockWebhookSender) Post(webhook *entity.Webhook, delivery *entity.WebhookDelivery) error {
+	args := m.Called(webhook, delivery)
+	return args.Error(0)
+}
@@ -0,0 +1,45 @@
+package hook
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"fmt"
+
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	PreCreate    = "pre-create"
+	PostCreate   = "post-create"
+	PreUpdate    = "pre-update"
+	PostUpdate   = "post-update"
+	PreComplete  = "pre-complete"
+	PostComplete = "post-complete"
+	PreDelete    = "pre-delete"
+	PostDelete   = "post-delete"
+)
+
+// IHookRunner executa o hook name recebendo a tarefa. Retorna a tarefa
+// devolvida pelo hook, ou nil se ele não existir ou não devolver nada
+type IHookRunner interface {
+	Run(name string, todo *entity.Todo) (*entity.Todo, error)
+}
+
+// HookRejectedError indica que um hook terminou com falha e vetou a operação
+type HookRejectedError struct {
+	Hook    string
+	Message string
+}
+
+func (e *HookRejectedError) Error() string {
+	return fmt.Sprintf("hook %s rejected the operation: %s", e.Hook, e.Message)
+}
+
+type MockHookRunner struct {
+	mock.Mock
+}
+
+func (m *MockHookRunner) Run(name string, todo *entity.Todo) (*entity.Todo, error) {
+	args := m.Called(name, todo)
+	modified, _ := args.Get(0).(*entity.Todo)
+	return modified, args.Error(1)
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// IBackupRepository cria e restaura cópias completas dos dados. Os backups
+// são referenciados pelo nome (na pasta de backups) ou pelo caminho do arquivo
+type IBackupRepository interface {
+	// Create grava um backup em to (arquivo ou diretório); vazio usa a pasta de backups
+	Create(trigger, to string) (*entity.Backup, error)
+	// List devolve os backups da pasta de backups, do mais novo ao mais antigo
+	List() ([]*entity.Backup, error)
+	// Verify lê o backup inteiro conferindo manifesto, checksums e conteúdo
+	Verify(ref string) (*entity.Backup, error)
+	// Restore substitui os dados atuais pelos do backup, só depois de verificá-lo
+	Restore(ref string) (*entity.Backup, error)
+	Delete(ref string) error
+}
+
+type MockBackupRepository struct {
+	mock.Mock
+}
+
+func (m *MockBackupRepository) Create(trigger, to string) (*entity.Backup, error) {
+	args := m.Called(trigger, to)
+	backup, _ := args.Get(0).(*entity.Backup)
+	return backup, args.Error(1)
+}
+
+func (m *MockBackupRepository) List() ([]*entity.Backup, error) {
+	args := m.Called()
+	backups, _ := args.Get(0).([]*entity.Backup)
+	return backups, args.Error(1)
+}
+
+func (m *MockBackupRepository) Verify(ref string) (*entity.Backup, error) {
+	args := m.Called(ref)
+	backup, _ := args.Get(0).(*entity.Backup)
+	return backup, args.Error(1)
+}
+
+func (m *MockBackupRepository) Restore(ref string) (*entity.Backup, error) {
+	args := m.Called(ref)
+	backup, _ := args.Get(0).(*entity.Backup)
+	return backup, args.Error(1)
+}
+
+func (m *MockBackupRepository) Delete(ref string) error {
+	args := m.Called(ref)
+	return args.Error(0)
+}
@@ -0,0 +1,15 @@
+package repository
+
+// CompactResult resume uma compactação: quantas tarefas foram gravadas no
+// snapshot, até qual evento ele vai e quantos bytes de log foram liberados
+type CompactResult struct {
+	Todos          int
+	Sequence       int64
+	ReclaimedBytes int64
+}
+
+// ICompactable é implementado pelos repositórios que acumulam histórico no
+// armazenamento e podem reduzi-lo sem perder o estado atual
+type ICompactable interface {
+	Compact() (CompactResult, error)
+}
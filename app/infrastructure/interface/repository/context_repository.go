@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// IContextRepository guarda os contextos (áreas de trabalho com tarefas
+// separadas) e qual deles está em uso; o contexto padrão sempre existe
+type IContextRepository interface {
+	List() ([]string, error)
+	Create(name string) error
+	Delete(name string) error
+	Rename(oldName, newName string) error
+	Current() (string, error)
+	SetCurrent(name string) error
+}
+
+type MockContextRepository struct {
+	mock.Mock
+}
+
+func (m *MockContextRepository) List() ([]string, error) {
+	args := m.Called()
+	names, _ := args.Get(0).([]string)
+	return names, args.Error(1)
+}
+
+func (m *MockContextRepository) Create(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func (m *MockContextRepository) Delete(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+func (m *MockContextRepository) Rename(oldName, newName string) error {
+	args := m.Called(oldName, newName)
+	return args.Error(0)
+}
+
+func (m *MockContextRepository) Current() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockContextRepository) SetCurrent(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// IHistoryRepository armazena o histórico de alterações das tarefas em modo
+// somente-anexação, independente do ciclo de vida da própria tarefa
+type IHistoryRepository interface {
+	Append(entry *entity.HistoryEntry) error
+	GetByTodoID(todoID string) ([]*entity.HistoryEntry, error)
+}
+
+type MockHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockHistoryRepository) Append(entry *entity.HistoryEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockHistoryRepository) GetByTodoID(todoID string) ([]*entity.HistoryEntry, error) {
+	args := m.Called(todoID)
+	entries, _ := args.Get(0).([]*entity.HistoryEntry)
+	return entries, args.Error(1)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// IJournalRepository persiste as pilhas de desfazer/refazer entre execuções da CLI
+type IJournalRepository interface {
+	Load() (*entity.Journal, error)
+	Save(journal *entity.Journal) error
+}
+
+type MockJournalRepository struct {
+	mock.Mock
+}
+
+func (m *MockJournalRepository) Load() (*entity.Journal, error) {
+	args := m.Called()
+	journal, _ := args.Get(0).(*entity.Journal)
+	return journal, args.Error(1)
+}
+
+func (m *MockJournalRepository) Save(journal *entity.Journal) error {
+	args := m.Called(journal)
+	return args.Error(0)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import "codecademy-yellowbelt2/core/domain/entity"
+
+// TodoQuery seleciona tarefas considerando a lixeira; por padrão apenas as
+// tarefas não deletadas são retornadas
+type TodoQuery struct {
+	ID             string
+	IncludeDeleted bool
+	OnlyDeleted    bool
+}
+
+func (q TodoQuery) Matches(todo *entity.Todo) bool {
+	if q.ID != "" && todo.ID != q.ID {
+		return false
+	}
+	if q.OnlyDeleted {
+		return todo.IsDeleted()
+	}
+	return q.IncludeDeleted || !todo.IsDeleted()
+}
@@ -10,6 +10,7 @@ type ITodoRepository interface {
 	Create(todo *entity.Todo) error
 	GetByID(id string) (*entity.Todo, error)
 	GetAll() ([]*entity.Todo, error)
+	Find(query TodoQuery) ([]*entity.Todo, error)
 	Update(todo *entity.Todo) error
 	Delete(id string) error
 }
@@ -33,6 +34,11 @@ func (m *MockTodoRepository) GetAll() ([]*entity.Todo, error) {
 	return args.Get(0).([]*entity.Todo), args.Error(1)
 }
 
+func (m *MockTodoRepository) Find(query TodoQuery) ([]*entity.Todo, error) {
+	args := m.Called(query)
+	return args.Get(0).([]*entity.Todo), args.Error(1)
+}
+
 func (m *MockTodoRepository) Update(todo *entity.Todo) error {
 	args := m.Called(todo)
 	return args.Error(0)
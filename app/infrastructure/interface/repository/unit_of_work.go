@@ -0,0 +1,15 @@
+package repository
+
+// ITodoTransaction é uma visão isolada do repositório: as alterações feitas
+// por ela só ficam visíveis para os demais após Commit, e Rollback as descarta
+type ITodoTransaction interface {
+	ITodoRepository
+	Commit() error
+	Rollback() error
+}
+
+// IUnitOfWork é implementado pelos repositórios capazes de agrupar várias
+// operações em uma única gravação atômica
+type IUnitOfWork interface {
+	Begin() (ITodoTransaction, error)
+}
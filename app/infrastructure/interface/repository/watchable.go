@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"context"
+)
+
+// IWatchable é implementado pelos repositórios que avisam sobre alterações
+// nas tarefas, inclusive as gravadas por outros processos. O canal é fechado
+// quando ctx termina
+type IWatchable interface {
+	Watch(ctx context.Context) (<-chan *entity.Event, error)
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// IWebhookRepository guarda os webhooks cadastrados
+type IWebhookRepository interface {
+	Create(webhook *entity.Webhook) error
+	GetByID(id string) (*entity.Webhook, error)
+	GetAll() ([]*entity.Webhook, error)
+	Delete(id string) error
+}
+
+// IWebhookQueueRepository persiste as entregas pendentes entre execuções da
+// CLI para que possam ser reenviadas
+type IWebhookQueueRepository interface {
+	Load() ([]*entity.WebhookDelivery, error)
+	Save(deliveries []*entity.WebhookDelivery) error
+}
+
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) Create(webhook *entity.Webhook) error {
+	args := m.Called(webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) GetByID(id string) (*entity.Webhook, error) {
+	args := m.Called(id)
+	webhook, _ := args.Get(0).(*entity.Webhook)
+	return webhook, args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetAll() ([]*entity.Webhook, error) {
+	args := m.Called()
+	webhooks, _ := args.Get(0).([]*entity.Webhook)
+	return webhooks, args.Error(1)
+}
+
+func (m *MockWebhookRepository) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
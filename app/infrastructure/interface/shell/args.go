@@ -0,0 +1,64 @@
+package shell
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrUnterminatedQuote = errors.New("unterminated quote")
+
+// splitArgs separa uma linha em argumentos como um shell POSIX simplificado:
+// aspas simples são literais, aspas duplas aceitam \" e \\, e a barra
+// invertida fora de aspas escapa o próximo caractere
+func splitArgs(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else if r == '\\' {
+				escaped = true
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '\\':
+			escaped = true
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				args = append(args, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, ErrUnterminatedQuote
+	}
+	if inWord {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,110 @@
+package shell
+
+import (
+	"fmt"
+	"strings"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+)
+
+// Completer completa nomes de comandos e, nos argumentos, tarefas pelo ID ou
+// pelo começo do título (a palavra é trocada pelo ID da tarefa)
+type Completer struct {
+	// Commands devolve os subcomandos aceitos depois das palavras já digitadas,
+	// ou nada quando o próximo argumento não é um comando
+	Commands func(words []string) []string
+	Todos    func() ([]*entity.Todo, error)
+}
+
+type candidate struct {
+	value   string
+	display string
+}
+
+// Complete devolve a linha completada, a nova posição do cursor e, quando há
+// mais de uma opção, as opções para mostrar ao usuário
+func (c *Completer) Complete(line string, pos int) (string, int, []string) {
+	head := line[:pos]
+	start := strings.LastIndexAny(head, " \t") + 1
+	word := head[start:]
+
+	prior, err := splitArgs(head[:start])
+	if err != nil || strings.HasPrefix(word, "-") {
+		return line, pos, nil
+	}
+
+	candidates := c.candidates(prior, word)
+	if len(candidates) == 0 {
+		return line, pos, nil
+	}
+
+	var replacement string
+	var options []string
+	if len(candidates) == 1 {
+		// Reaproveita o espaço seguinte quando a palavra está no meio da linha
+		replacement = candidates[0].value
+		if pos < len(line) && line[pos] == ' ' {
+			return line[:start] + replacement + line[pos:], start + len(replacement) + 1, nil
+		}
+		replacement += " "
+	} else {
+		values := make([]string, len(candidates))
+		for i, candidate := range candidates {
+			values[i] = candidate.value
+			options = append(options, candidate.display)
+		}
+		replacement = commonPrefix(values)
+		if !strings.HasPrefix(replacement, word) {
+			replacement = word
+		}
+	}
+
+	return line[:start] + replacement + line[pos:], start + len(replacement), options
+}
+
+func (c *Completer) candidates(prior []string, word string) []candidate {
+	var candidates []candidate
+
+	if c.Commands != nil {
+		if names := c.Commands(prior); len(names) > 0 {
+			if len(prior) == 0 {
+				names = append(names, builtins...)
+			}
+			for _, name := range names {
+				if strings.HasPrefix(name, word) {
+					candidates = append(candidates, candidate{value: name, display: name})
+				}
+			}
+			return candidates
+		}
+	}
+
+	if len(prior) == 0 || c.Todos == nil {
+		return nil
+	}
+	todos, err := c.Todos()
+	if err != nil {
+		return nil
+	}
+
+	lowerWord := strings.ToLower(word)
+	for _, todo := range todos {
+		if strings.HasPrefix(todo.ID, word) || strings.HasPrefix(strings.ToLower(todo.Title), lowerWord) {
+			candidates = append(candidates, candidate{
+				value:   todo.ID,
+				display: fmt.Sprintf("%s  %s", todo.ID, todo.Title),
+			})
+		}
+	}
+	return candidates
+}
+
+func commonPrefix(values []string) string {
+	prefix := values[0]
+	for _, value := range values[1:] {
+		for !strings.HasPrefix(value, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
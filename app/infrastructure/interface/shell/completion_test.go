@@ -0,0 +1,78 @@
+package shell
+
+import (
+	"testing"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCompleter() *Completer {
+	return &Completer{
+		Commands: func(words []string) []string {
+			switch {
+			case len(words) == 0:
+				return []string{"complete", "create", "list", "trash"}
+			case len(words) == 1 && words[0] == "trash":
+				return []string{"list", "restore", "purge"}
+			}
+			return nil
+		},
+		Todos: func() ([]*entity.Todo, error) {
+			return []*entity.Todo{
+				{ID: "a1b2", Title: "Comprar pão"},
+				{ID: "a1c3", Title: "Estudar Go"},
+				{ID: "f9e8", Title: "Comprar leite"},
+			}, nil
+		},
+	}
+}
+
+func TestShouldCompleteCommandNames(t *testing.T) {
+	// Arrange
+	completer := newTestCompleter()
+
+	// Act
+	line, pos, options := completer.Complete("tr", 2)
+	subLine, _, _ := completer.Complete("trash re", 8)
+	ambiguous, _, ambiguousOptions := completer.Complete("c", 1)
+
+	// Assert
+	assert.Equal(t, "trash ", line)
+	assert.Equal(t, 6, pos)
+	assert.Empty(t, options)
+	assert.Equal(t, "trash restore ", subLine)
+	assert.Equal(t, "c", ambiguous)
+	assert.Equal(t, []string{"complete", "create"}, ambiguousOptions)
+}
+
+func TestShouldCompleteTodoIDs(t *testing.T) {
+	// Arrange
+	completer := newTestCompleter()
+
+	// Act
+	line, pos, options := completer.Complete("show f", 6)
+	prefix, _, prefixOptions := completer.Complete("show a", 6)
+
+	// Assert
+	assert.Equal(t, "show f9e8 ", line)
+	assert.Equal(t, 10, pos)
+	assert.Empty(t, options)
+	assert.Equal(t, "show a1", prefix)
+	assert.Equal(t, []string{"a1b2  Comprar pão", "a1c3  Estudar Go"}, prefixOptions)
+}
+
+func TestShouldCompleteTitlesToTheirIDs(t *testing.T) {
+	// Arrange
+	completer := newTestCompleter()
+
+	// Act
+	line, _, _ := completer.Complete("complete est --force", 12)
+	ambiguous, _, options := completer.Complete("complete comp", 13)
+
+	// Assert
+	assert.Equal(t, "complete a1c3 --force", line)
+	assert.Equal(t, "complete comp", ambiguous)
+	assert.Equal(t, []string{"a1b2  Comprar pão", "f9e8  Comprar leite"}, options)
+}
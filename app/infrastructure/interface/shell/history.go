@@ -0,0 +1,98 @@
+package shell
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"strings"
+)
+
+// DefaultHistorySize é quantas linhas ficam guardadas entre as sessões
+const DefaultHistorySize = 500
+
+// History guarda as linhas digitadas no shell e as anexa a um arquivo para
+// que fiquem disponíveis nas próximas sessões
+type History struct {
+	filename string
+	size     int
+	entries  []string // Da mais antiga para a mais recente
+}
+
+// LoadHistory lê o histórico salvo, mantendo apenas as últimas size linhas
+func LoadHistory(filename string, size int) (*History, error) {
+	h := &History{filename: filename, size: size}
+
+	file, err := os.Open(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines++
+		h.append(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// O arquivo só cresce durante as sessões; ao abrir, descarta o excesso
+	if lines > size {
+		if err := h.rewrite(); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// Add registra uma linha, ignorando linhas vazias e repetições seguidas
+func (h *History) Add(entry string) {
+	entry = strings.TrimRight(entry, " \t")
+	if strings.TrimSpace(entry) == "" {
+		return
+	}
+	if len(h.entries) > 0 && h.entries[len(h.entries)-1] == entry {
+		return
+	}
+	h.append(entry)
+
+	file, err := os.OpenFile(h.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	file.WriteString(entry + "\n")
+}
+
+func (h *History) Len() int {
+	return len(h.entries)
+}
+
+// At devolve a entrada idx, onde 0 é a mais recente
+func (h *History) At(idx int) string {
+	return h.entries[len(h.entries)-1-idx]
+}
+
+func (h *History) append(entry string) {
+	h.entries = append(h.entries, entry)
+	if len(h.entries) > h.size {
+		h.entries = h.entries[len(h.entries)-h.size:]
+	}
+}
+
+func (h *History) rewrite() error {
+	tempFile := h.filename + ".tmp"
+	data := strings.Join(h.entries, "\n")
+	if len(h.entries) > 0 {
+		data += "\n"
+	}
+	if err := os.WriteFile(tempFile, []byte(data), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tempFile, h.filename)
+}
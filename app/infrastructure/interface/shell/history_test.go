@@ -0,0 +1,60 @@
+package shell
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldPersistHistoryAcrossSessions(t *testing.T) {
+	// Arrange
+	filename := filepath.Join(t.TempDir(), "shell_history")
+	first, err := LoadHistory(filename, DefaultHistorySize)
+	assert.NoError(t, err)
+
+	// Act
+	first.Add("list")
+	first.Add("complete 1")
+	second, err := LoadHistory(filename, DefaultHistorySize)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, second.Len())
+	assert.Equal(t, "complete 1", second.At(0))
+	assert.Equal(t, "list", second.At(1))
+}
+
+func TestShouldSkipBlankAndRepeatedEntries(t *testing.T) {
+	// Arrange
+	history, err := LoadHistory(filepath.Join(t.TempDir(), "shell_history"), DefaultHistorySize)
+	assert.NoError(t, err)
+
+	// Act
+	history.Add("list")
+	history.Add("list")
+	history.Add("   ")
+	history.Add("show 1")
+
+	// Assert
+	assert.Equal(t, 2, history.Len())
+	assert.Equal(t, "show 1", history.At(0))
+}
+
+func TestShouldTrimHistoryToSize(t *testing.T) {
+	// Arrange
+	filename := filepath.Join(t.TempDir(), "shell_history")
+	assert.NoError(t, os.WriteFile(filename, []byte("one\ntwo\nthree\nfour\n"), 0600))
+
+	// Act
+	history, err := LoadHistory(filename, 2)
+	data, _ := os.ReadFile(filename)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, history.Len())
+	assert.Equal(t, "four", history.At(0))
+	assert.Equal(t, "three", history.At(1))
+	assert.Equal(t, "three\nfour\n", string(data))
+}
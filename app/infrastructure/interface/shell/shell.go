@@ -0,0 +1,148 @@
+package shell
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// DefaultPrompt é mostrado antes de cada linha no terminal
+const DefaultPrompt = "todo> "
+
+// builtins são tratados pelo próprio shell, antes de chegar aos comandos
+var builtins = []string{"exit", "quit"}
+
+// Shell lê linhas, separa os argumentos e entrega cada linha ao executor
+type Shell struct {
+	execute   func(args []string)
+	prompt    string
+	history   *History
+	completer *Completer
+}
+
+type Option func(*Shell)
+
+// WithPrompt troca o prompt padrão
+func WithPrompt(prompt string) Option {
+	return func(s *Shell) {
+		s.prompt = prompt
+	}
+}
+
+// WithHistory usa (e persiste) o histórico informado nas setas ↑ e ↓
+func WithHistory(history *History) Option {
+	return func(s *Shell) {
+		s.history = history
+	}
+}
+
+// WithCompleter habilita a completação com Tab
+func WithCompleter(completer *Completer) Option {
+	return func(s *Shell) {
+		s.completer = completer
+	}
+}
+
+func NewShell(execute func(args []string), opts ...Option) *Shell {
+	s := &Shell{
+		execute: execute,
+		prompt:  DefaultPrompt,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Run lê comandos até exit, quit, Ctrl+D ou o fim da entrada. Fora de um
+// terminal as linhas são lidas sem prompt, edição ou histórico
+func (s *Shell) Run(in *os.File, out io.Writer) error {
+	fd := int(in.Fd())
+	if !term.IsTerminal(fd) {
+		return s.runScript(in, out)
+	}
+
+	terminal := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{in, out}, s.prompt)
+	if s.history != nil {
+		terminal.History = s.history
+	}
+	if s.completer != nil {
+		terminal.AutoCompleteCallback = s.autoComplete(terminal)
+	}
+
+	for {
+		if width, height, err := term.GetSize(fd); err == nil && width > 0 {
+			terminal.SetSize(width, height)
+		}
+
+		// O modo raw vale só durante a edição; os comandos escrevem no modo normal
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return err
+		}
+		line, err := terminal.ReadLine()
+		term.Restore(fd, state)
+
+		if errors.Is(err, io.EOF) {
+			fmt.Fprintln(out)
+			return nil
+		}
+		if err != nil && !errors.Is(err, term.ErrPasteIndicator) {
+			return err
+		}
+		if s.runLine(line, out) {
+			return nil
+		}
+	}
+}
+
+func (s *Shell) runScript(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		if s.runLine(scanner.Text(), out) {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+// runLine executa uma linha e informa se o shell deve encerrar
+func (s *Shell) runLine(line string, out io.Writer) bool {
+	args, err := splitArgs(line)
+	if err != nil {
+		fmt.Fprintf(out, "❌ Linha inválida: %v\n", err)
+		return false
+	}
+	if len(args) == 0 {
+		return false
+	}
+	for _, builtin := range builtins {
+		if args[0] == builtin {
+			return true
+		}
+	}
+
+	s.execute(args)
+	return false
+}
+
+func (s *Shell) autoComplete(terminal *term.Terminal) func(string, int, rune) (string, int, bool) {
+	return func(line string, pos int, key rune) (string, int, bool) {
+		if key != '\t' {
+			return "", 0, false
+		}
+		newLine, newPos, options := s.completer.Complete(line, pos)
+		if len(options) > 0 {
+			terminal.Write([]byte(strings.Join(options, "\n") + "\n"))
+		}
+		return newLine, newPos, true
+	}
+}
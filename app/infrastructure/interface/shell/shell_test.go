@@ -0,0 +1,86 @@
+package shell
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func scriptInput(t *testing.T, script string) *os.File {
+	r, w, err := os.Pipe()
+	assert.NoError(t, err)
+	w.WriteString(script)
+	w.Close()
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+func TestShouldSplitArgsLikeAShell(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected []string
+	}{
+		{"list", []string{"list"}},
+		{"  create   Pão  ", []string{"create", "Pão"}},
+		{`create "Comprar pão" 'na padaria'`, []string{"create", "Comprar pão", "na padaria"}},
+		{`create "Diga \"oi\"" it\'s`, []string{"create", `Diga "oi"`, "it's"}},
+		{`update 1 ""`, []string{"update", "1", ""}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			// Act
+			args, err := splitArgs(tt.line)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, args)
+		})
+	}
+}
+
+func TestShouldRejectUnterminatedQuote(t *testing.T) {
+	// Act
+	_, err := splitArgs(`create "Comprar pão`)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrUnterminatedQuote)
+}
+
+func TestShouldExecuteEachLineUntilExit(t *testing.T) {
+	// Arrange
+	var executed [][]string
+	shell := NewShell(func(args []string) {
+		executed = append(executed, args)
+	})
+	in := scriptInput(t, "create \"Comprar pão\"\n\nlist\nexit\ncomplete 1\n")
+	var out bytes.Buffer
+
+	// Act
+	err := shell.Run(in, &out)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"create", "Comprar pão"}, {"list"}}, executed)
+}
+
+func TestShouldReportInvalidLineAndContinue(t *testing.T) {
+	// Arrange
+	var executed [][]string
+	shell := NewShell(func(args []string) {
+		executed = append(executed, args)
+	})
+	in := scriptInput(t, "create \"sem fim\nlist\n")
+	var out bytes.Buffer
+
+	// Act
+	err := shell.Run(in, &out)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "❌ Linha inválida: unterminated quote")
+	assert.Equal(t, [][]string{{"list"}}, executed)
+}
@@ -0,0 +1,76 @@
+package transfer
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"fmt"
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// Issue aponta uma linha do arquivo importado que foi ignorada ou que teve
+// partes sem campo correspondente na tarefa
+type Issue struct {
+	Line   int
+	Text   string
+	Reason string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("linha %d: %s (%s)", i.Line, i.Text, i.Reason)
+}
+
+// Decoded é o resultado da leitura de um arquivo: as tarefas reconhecidas
+// (com a linha de cada uma em Lines), as linhas ignoradas e as partes que não
+// puderam ser mapeadas
+type Decoded struct {
+	Todos    []*entity.Todo
+	Lines    []int
+	Skipped  []Issue
+	Unmapped []Issue
+}
+
+// Codificações aceitas em Options.Encoding; planilhas exportadas pelo Excel
+// costumam vir em windows-1252 ou, como "Texto Unicode", em utf-16
+const (
+	EncodingUTF8        = "utf-8"
+	EncodingUTF16       = "utf-16"
+	EncodingLatin1      = "latin1"
+	EncodingWindows1252 = "windows-1252"
+)
+
+var Encodings = []string{EncodingUTF8, EncodingUTF16, EncodingLatin1, EncodingWindows1252}
+
+// Options ajusta a leitura e a escrita dos formatos que aceitam configuração
+type Options struct {
+	Columns   map[string]string // Campo da tarefa -> nome da coluna no arquivo
+	Delimiter rune
+	Encoding  string
+}
+
+// ITodoFormat converte tarefas de e para um formato de arquivo de outra ferramenta
+type ITodoFormat interface {
+	Decode(r io.Reader) (*Decoded, error)
+	Encode(w io.Writer, todos []*entity.Todo) error
+}
+
+// IConfigurableFormat é implementado pelos formatos que aceitam Options,
+// devolvendo uma cópia configurada do formato
+type IConfigurableFormat interface {
+	WithOptions(options Options) (ITodoFormat, error)
+}
+
+type MockTodoFormat struct {
+	mock.Mock
+}
+
+func (m *MockTodoFormat) Decode(r io.Reader) (*Decoded, error) {
+	args := m.Called(r)
+	decoded, _ := args.Get(0).(*Decoded)
+	return decoded, args.Error(1)
+}
+
+func (m *MockTodoFormat) Encode(w io.Writer, todos []*entity.Todo) error {
+	args := m.Called(w, todos)
+	return args.Error(0)
+}
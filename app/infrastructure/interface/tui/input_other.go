@@ -0,0 +1,14 @@
+//go:build !unix
+
+package tui
+
+import (
+	"io"
+	"os"
+)
+
+// openInput lê direto do terminal: fora do Unix a leitura pendente não pode
+// ser interrompida e termina na próxima tecla
+func openInput(in *os.File) (io.ReadCloser, error) {
+	return io.NopCloser(in), nil
+}
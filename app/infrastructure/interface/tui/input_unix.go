@@ -0,0 +1,39 @@
+//go:build unix
+
+package tui
+
+import (
+	"io"
+	"os"
+	"syscall"
+)
+
+// openInput lê as teclas por uma cópia não bloqueante do terminal: fechá-la
+// interrompe a leitura pendente, para que nenhuma tecla digitada depois que
+// a interface fecha (como as do "todo shell") seja consumida por ela
+func openInput(in *os.File) (io.ReadCloser, error) {
+	original := int(in.Fd())
+	fd, err := syscall.Dup(original)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.SetNonblock(fd, true); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+	return &input{File: os.NewFile(uintptr(fd), in.Name()), original: original}, nil
+}
+
+type input struct {
+	*os.File
+	original int
+}
+
+// Close devolve o modo bloqueante, que a cópia compartilha com o original
+func (i *input) Close() error {
+	err := i.File.Close()
+	if nonblockErr := syscall.SetNonblock(i.original, false); err == nil {
+		err = nonblockErr
+	}
+	return err
+}
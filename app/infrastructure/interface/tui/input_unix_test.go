@@ -0,0 +1,43 @@
+//go:build unix
+
+package tui
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldLeaveInputToCallerAfterKeysStop(t *testing.T) {
+	// Arrange
+	reader, writer, err := os.Pipe()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer reader.Close()
+	defer writer.Close()
+	input, err := openInput(reader)
+	if !assert.NoError(t, err) {
+		return
+	}
+	keys := make(chan []Key)
+	go readKeys(context.Background(), input, keys)
+	writer.WriteString("q")
+	assert.Equal(t, []Key{{Code: KeyRune, Rune: 'q'}}, <-keys)
+
+	// Act
+	input.Close()
+	_, open := <-keys
+	writer.WriteString("list\n")
+	reader.SetReadDeadline(time.Now().Add(time.Second))
+	buf := make([]byte, 16)
+	n, err := reader.Read(buf)
+
+	// Assert
+	assert.False(t, open)
+	assert.NoError(t, err)
+	assert.Equal(t, "list\n", string(buf[:n]))
+}
@@ -0,0 +1,91 @@
+package tui
+
+import "unicode/utf8"
+
+type KeyCode int
+
+const (
+	KeyRune KeyCode = iota
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyHome
+	KeyEnd
+	KeyPageUp
+	KeyPageDown
+	KeyEnter
+	KeyEscape
+	KeyBackspace
+	KeyTab
+	KeyCtrlC
+)
+
+// Key é uma tecla já decodificada; Rune só é usado quando Code é KeyRune
+type Key struct {
+	Code KeyCode
+	Rune rune
+}
+
+var escapeSequences = map[string]KeyCode{
+	"[A": KeyUp, "OA": KeyUp,
+	"[B": KeyDown, "OB": KeyDown,
+	"[C": KeyRight, "OC": KeyRight,
+	"[D": KeyLeft, "OD": KeyLeft,
+	"[H": KeyHome, "OH": KeyHome, "[1~": KeyHome, "[7~": KeyHome,
+	"[F": KeyEnd, "OF": KeyEnd, "[4~": KeyEnd, "[8~": KeyEnd,
+	"[5~": KeyPageUp,
+	"[6~": KeyPageDown,
+}
+
+// decodeKeys traduz os bytes lidos do terminal em modo raw. Sequências de
+// escape desconhecidas são descartadas; um ESC sozinho é a tecla Esc
+func decodeKeys(data []byte) []Key {
+	var keys []Key
+	for len(data) > 0 {
+		switch b := data[0]; {
+		case b == 0x1b:
+			code, size, ok := decodeEscape(data[1:])
+			data = data[1+size:]
+			if ok {
+				keys = append(keys, Key{Code: code})
+			} else if size == 0 {
+				keys = append(keys, Key{Code: KeyEscape})
+			}
+			continue
+		case b == 0x03:
+			keys = append(keys, Key{Code: KeyCtrlC})
+		case b == '\r' || b == '\n':
+			keys = append(keys, Key{Code: KeyEnter})
+		case b == 0x7f || b == 0x08:
+			keys = append(keys, Key{Code: KeyBackspace})
+		case b == '\t':
+			keys = append(keys, Key{Code: KeyTab})
+		case b < 0x20:
+			// Demais teclas de controle não têm função
+		default:
+			r, size := utf8.DecodeRune(data)
+			keys = append(keys, Key{Code: KeyRune, Rune: r})
+			data = data[size:]
+			continue
+		}
+		data = data[1:]
+	}
+	return keys
+}
+
+// decodeEscape reconhece o que vem depois de um ESC e retorna quantos bytes
+// a sequência ocupa; size zero indica um ESC isolado
+func decodeEscape(data []byte) (KeyCode, int, bool) {
+	if len(data) == 0 || (data[0] != '[' && data[0] != 'O') {
+		return 0, 0, false
+	}
+	// A sequência termina no primeiro byte final (letra ou ~)
+	for i := 1; i < len(data); i++ {
+		if c := data[i]; c == '~' || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') {
+			code, ok := escapeSequences[string(data[:i+1])]
+			return code, i + 1, ok
+		}
+	}
+	return 0, len(data), false
+}
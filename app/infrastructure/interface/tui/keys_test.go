@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldDecodeKeysFromRawInput(t *testing.T) {
+	// Arrange
+	input := []byte("j\x1b[Aé\r\x7f\x1b[6~\x03")
+
+	// Act
+	keys := decodeKeys(input)
+
+	// Assert
+	assert.Equal(t, []Key{
+		{Code: KeyRune, Rune: 'j'},
+		{Code: KeyUp},
+		{Code: KeyRune, Rune: 'é'},
+		{Code: KeyEnter},
+		{Code: KeyBackspace},
+		{Code: KeyPageDown},
+		{Code: KeyCtrlC},
+	}, keys)
+}
+
+func TestShouldTreatLoneEscapeAsEscapeKey(t *testing.T) {
+	// Act
+	keys := decodeKeys([]byte("\x1b"))
+
+	// Assert
+	assert.Equal(t, []Key{{Code: KeyEscape}}, keys)
+}
+
+func TestShouldDropUnknownEscapeSequences(t *testing.T) {
+	// Act
+	keys := decodeKeys([]byte("\x1b[200~x"))
+
+	// Assert
+	assert.Equal(t, []Key{{Code: KeyRune, Rune: 'x'}}, keys)
+}
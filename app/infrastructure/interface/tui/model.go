@@ -0,0 +1,385 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+	app_interfaces "codecademy-yellowbelt2/infrastructure/interface/application"
+)
+
+type mode int
+
+const (
+	modeBrowse mode = iota
+	modeFilter
+	modeCreate
+	modeEdit
+	modeConfirmDelete
+)
+
+const helpLine = "↑↓ mover  ␣ concluir  e editar  n nova  d remover  / filtrar  u desfazer  q sair"
+
+// Model guarda o estado da interface e traduz as teclas em chamadas ao use
+// case; não conhece o terminal, o que permite testá-lo sem um
+type Model struct {
+	useCase app_interfaces.ITodoUseCase
+
+	todos   []*entity.Todo
+	visible []*entity.Todo
+	cursor  int
+	scroll  int
+
+	mode   mode
+	input  []rune
+	filter string
+	status string
+	quit   bool
+}
+
+func NewModel(useCase app_interfaces.ITodoUseCase) *Model {
+	return &Model{useCase: useCase}
+}
+
+// Reload busca as tarefas de novo mantendo o cursor na mesma tarefa
+func (m *Model) Reload() error {
+	todos, err := m.useCase.GetAllTodos()
+	if err != nil {
+		return err
+	}
+
+	// Pendentes primeiro; dentro de cada grupo, as mais antigas em cima
+	sort.SliceStable(todos, func(i, j int) bool {
+		if todos[i].Completed != todos[j].Completed {
+			return !todos[i].Completed
+		}
+		return todos[i].CreatedAt.Before(todos[j].CreatedAt)
+	})
+	m.todos = todos
+	m.applyFilter()
+	return nil
+}
+
+func (m *Model) Quit() bool {
+	return m.quit
+}
+
+func (m *Model) HandleKey(key Key) {
+	if key.Code == KeyCtrlC {
+		m.quit = true
+		return
+	}
+
+	switch m.mode {
+	case modeBrowse:
+		m.handleBrowse(key)
+	case modeConfirmDelete:
+		m.handleConfirmDelete(key)
+	default:
+		m.handleInput(key)
+	}
+}
+
+func (m *Model) handleBrowse(key Key) {
+	m.status = ""
+
+	switch {
+	case key.Code == KeyUp || key.Rune == 'k':
+		m.moveCursor(-1)
+	case key.Code == KeyDown || key.Rune == 'j':
+		m.moveCursor(1)
+	case key.Code == KeyPageUp:
+		m.moveCursor(-10)
+	case key.Code == KeyPageDown:
+		m.moveCursor(10)
+	case key.Code == KeyHome || key.Rune == 'g':
+		m.moveCursor(-len(m.visible))
+	case key.Code == KeyEnd || key.Rune == 'G':
+		m.moveCursor(len(m.visible))
+	case key.Rune == ' ' || key.Rune == 'x':
+		m.toggleSelected()
+	case key.Code == KeyEnter || key.Rune == 'e':
+		if selected := m.selected(); selected != nil {
+			m.startInput(modeEdit, selected.Title)
+		}
+	case key.Rune == 'n' || key.Rune == 'a':
+		m.startInput(modeCreate, "")
+	case key.Rune == 'd':
+		if m.selected() != nil {
+			m.mode = modeConfirmDelete
+		}
+	case key.Rune == '/':
+		m.startInput(modeFilter, m.filter)
+	case key.Code == KeyEscape:
+		m.filter = ""
+		m.applyFilter()
+	case key.Rune == 'u':
+		m.undo()
+	case key.Rune == 'r':
+		m.reloadWithStatus()
+	case key.Rune == 'q':
+		m.quit = true
+	}
+}
+
+func (m *Model) handleConfirmDelete(key Key) {
+	m.mode = modeBrowse
+	selected := m.selected()
+	if selected == nil || (key.Rune != 's' && key.Rune != 'S' && key.Rune != 'y' && key.Rune != 'Y') {
+		m.status = "Remoção cancelada"
+		return
+	}
+
+	if err := m.useCase.DeleteTodo(selected.ID); err != nil {
+		m.status = fmt.Sprintf("❌ Erro ao remover tarefa: %v", err)
+		return
+	}
+	m.status = fmt.Sprintf("🗑️  '%s' movida para a lixeira", selected.Title)
+	m.reloadWithStatus()
+}
+
+func (m *Model) handleInput(key Key) {
+	switch key.Code {
+	case KeyEscape:
+		if m.mode == modeFilter {
+			m.filter = ""
+			m.applyFilter()
+		}
+		m.mode = modeBrowse
+	case KeyEnter:
+		m.submitInput()
+	case KeyBackspace:
+		if len(m.input) > 0 {
+			m.input = m.input[:len(m.input)-1]
+		}
+	case KeyRune:
+		m.input = append(m.input, key.Rune)
+	}
+
+	// O filtro é aplicado enquanto se digita
+	if m.mode == modeFilter {
+		m.filter = string(m.input)
+		m.applyFilter()
+	}
+}
+
+func (m *Model) startInput(next mode, initial string) {
+	m.mode = next
+	m.input = []rune(initial)
+	m.status = ""
+}
+
+func (m *Model) submitInput() {
+	current := m.mode
+	text := strings.TrimSpace(string(m.input))
+	m.mode = modeBrowse
+
+	switch current {
+	case modeFilter:
+		m.filter = text
+		m.applyFilter()
+	case modeCreate:
+		if text == "" {
+			return
+		}
+		todo, err := m.useCase.CreateTodo(text, "")
+		if err != nil {
+			m.status = fmt.Sprintf("❌ Erro ao criar tarefa: %v", err)
+			return
+		}
+		m.status = fmt.Sprintf("✅ '%s' criada", todo.Title)
+		m.reloadWithStatus()
+		m.selectID(todo.ID)
+	case modeEdit:
+		selected := m.selected()
+		if selected == nil || text == "" || text == selected.Title {
+			return
+		}
+		// A versão exibida protege contra alterações feitas por outro terminal
+		todo, err := m.useCase.UpdateTodoIfVersion(selected.ID, selected.Version, text, "")
+		if err != nil {
+			m.status = fmt.Sprintf("❌ Erro ao atualizar tarefa: %v", err)
+			m.reloadWithStatus()
+			return
+		}
+		m.status = fmt.Sprintf("✏️  Título alterado para '%s'", todo.Title)
+		m.reloadWithStatus()
+	}
+}
+
+func (m *Model) toggleSelected() {
+	selected := m.selected()
+	if selected == nil {
+		return
+	}
+
+	if selected.Completed {
+		if _, err := m.useCase.ReopenTodo(selected.ID); err != nil {
+			m.status = fmt.Sprintf("❌ Erro ao reabrir tarefa: %v", err)
+			return
+		}
+		m.status = fmt.Sprintf("⏳ '%s' voltou a ficar pendente", selected.Title)
+	} else {
+		if _, err := m.useCase.CompleteTodo(selected.ID); err != nil {
+			m.status = fmt.Sprintf("❌ Erro ao completar tarefa: %v", err)
+			return
+		}
+		m.status = fmt.Sprintf("✅ '%s' concluída", selected.Title)
+	}
+	m.reloadWithStatus()
+	m.selectID(selected.ID)
+}
+
+func (m *Model) undo() {
+	operation, err := m.useCase.Undo()
+	if err != nil {
+		m.status = fmt.Sprintf("❌ Erro ao desfazer: %v", err)
+		return
+	}
+	m.status = fmt.Sprintf("↩️  Desfeito: '%s'", operation.Title())
+	m.reloadWithStatus()
+}
+
+// reloadWithStatus recarrega a lista sem apagar a mensagem da ação anterior,
+// a não ser que a própria recarga falhe
+func (m *Model) reloadWithStatus() {
+	if err := m.Reload(); err != nil {
+		m.status = fmt.Sprintf("❌ Erro ao carregar tarefas: %v", err)
+	}
+}
+
+func (m *Model) applyFilter() {
+	var selectedID string
+	if selected := m.selected(); selected != nil {
+		selectedID = selected.ID
+	}
+
+	m.visible = m.visible[:0]
+	query := strings.ToLower(m.filter)
+	for _, todo := range m.todos {
+		if query == "" || matches(todo, query) {
+			m.visible = append(m.visible, todo)
+		}
+	}
+
+	m.selectID(selectedID)
+}
+
+func matches(todo *entity.Todo, query string) bool {
+	fields := append([]string{todo.Title, todo.Description}, todo.Assignees...)
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Model) selectID(id string) {
+	for i, todo := range m.visible {
+		if todo.ID == id {
+			m.cursor = i
+			return
+		}
+	}
+	m.moveCursor(0)
+}
+
+func (m *Model) moveCursor(delta int) {
+	m.cursor = max(0, min(m.cursor+delta, len(m.visible)-1))
+}
+
+func (m *Model) selected() *entity.Todo {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return nil
+	}
+	return m.visible[m.cursor]
+}
+
+// View desenha a tela inteira em linhas de no máximo width caracteres
+func (m *Model) View(width, height int) []string {
+	pending := 0
+	for _, todo := range m.todos {
+		if !todo.Completed {
+			pending++
+		}
+	}
+
+	header := fmt.Sprintf("📋 Tarefas: %d pendentes, %d concluídas", pending, len(m.todos)-pending)
+	if m.filter != "" {
+		header += fmt.Sprintf(" · filtro: %q (%d)", m.filter, len(m.visible))
+	}
+	lines := []string{truncate(header, width), strings.Repeat("─", width)}
+
+	// Cabeçalho, duas separações, rodapé e ajuda ocupam 5 linhas
+	rows := max(1, height-5)
+	if m.cursor < m.scroll {
+		m.scroll = m.cursor
+	}
+	if m.cursor >= m.scroll+rows {
+		m.scroll = m.cursor - rows + 1
+	}
+
+	if len(m.visible) == 0 {
+		lines = append(lines, "📝 Nenhuma tarefa encontrada! Pressione n para criar uma.")
+	}
+	for i := m.scroll; i < len(m.visible) && i < m.scroll+rows; i++ {
+		lines = append(lines, m.row(m.visible[i], i == m.cursor, width))
+	}
+	for len(lines) < rows+2 {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, strings.Repeat("─", width), truncate(m.footer(), width), truncate(helpLine, width))
+	return lines
+}
+
+func (m *Model) row(todo *entity.Todo, selected bool, width int) string {
+	status := "⏳"
+	if todo.Completed {
+		status = "✅"
+	}
+	line := fmt.Sprintf("%s %s", status, todo.Title)
+	if len(todo.Assignees) > 0 {
+		line += "  👤 " + strings.Join(todo.Assignees, ", ")
+	}
+
+	if selected {
+		// Vídeo reverso destaca a linha do cursor
+		return "\x1b[7m> " + padRight(truncate(line, width-2), width-2) + "\x1b[0m"
+	}
+	return "  " + truncate(line, width-2)
+}
+
+func (m *Model) footer() string {
+	switch m.mode {
+	case modeFilter:
+		return "🔍 Filtrar: " + string(m.input) + "█"
+	case modeCreate:
+		return "➕ Nova tarefa: " + string(m.input) + "█"
+	case modeEdit:
+		return "✏️  Título: " + string(m.input) + "█"
+	case modeConfirmDelete:
+		return fmt.Sprintf("🗑️  Remover '%s'? (s/n)", m.selected().Title)
+	}
+	return m.status
+}
+
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if width <= 0 {
+		return ""
+	}
+	if len(runes) <= width {
+		return text
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+func padRight(text string, width int) string {
+	if padding := width - len([]rune(text)); padding > 0 {
+		return text + strings.Repeat(" ", padding)
+	}
+	return text
+}
@@ -0,0 +1,212 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"codecademy-yellowbelt2/core/application"
+	"codecademy-yellowbelt2/core/domain/entity"
+	app_interfaces "codecademy-yellowbelt2/infrastructure/interface/application"
+	"codecademy-yellowbelt2/infrastructure/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestModel(t *testing.T, titles ...string) (*Model, app_interfaces.ITodoUseCase) {
+	useCase := application.NewTodoUseCase(
+		repository.NewInMemoryTodoRepository(),
+		application.WithJournal(repository.NewInMemoryJournalRepository(), application.DefaultJournalSize),
+	)
+	for _, title := range titles {
+		useCase.CreateTodo(title, "")
+	}
+	model := NewModel(useCase)
+	assert.NoError(t, model.Reload())
+	return model, useCase
+}
+
+func typeText(model *Model, text string) {
+	for _, r := range text {
+		model.HandleKey(Key{Code: KeyRune, Rune: r})
+	}
+}
+
+func screen(model *Model) string {
+	return strings.Join(model.View(100, 12), "\n")
+}
+
+func TestShouldToggleCompletionOfSelectedTodo(t *testing.T) {
+	// Arrange
+	model, useCase := newTestModel(t, "First", "Second")
+
+	// Act
+	model.HandleKey(Key{Code: KeyDown})
+	typeText(model, " ")
+	completed := model.selected()
+	typeText(model, " ")
+	reopened := model.selected()
+	todos, _ := useCase.GetAllTodos()
+
+	// Assert
+	assert.Equal(t, "Second", completed.Title)
+	assert.True(t, completed.Completed)
+	assert.Equal(t, "Second", reopened.Title, "Expected cursor to follow the toggled todo")
+	assert.False(t, reopened.Completed)
+	for _, todo := range todos {
+		assert.False(t, todo.Completed)
+	}
+}
+
+func TestShouldCreateTodoFromInputLine(t *testing.T) {
+	// Arrange
+	model, useCase := newTestModel(t, "Existing")
+
+	// Act
+	typeText(model, "n")
+	typeText(model, "Brand nex")
+	model.HandleKey(Key{Code: KeyBackspace})
+	typeText(model, "w")
+	inputScreen := screen(model)
+	model.HandleKey(Key{Code: KeyEnter})
+	todos, _ := useCase.GetAllTodos()
+
+	// Assert
+	assert.Contains(t, inputScreen, "➕ Nova tarefa: Brand new█")
+	assert.Len(t, todos, 2)
+	assert.Equal(t, "Brand new", model.selected().Title)
+	assert.Contains(t, screen(model), "✅ 'Brand new' criada")
+}
+
+func TestShouldEditTitleInline(t *testing.T) {
+	// Arrange
+	model, useCase := newTestModel(t, "Typo")
+	id := model.selected().ID
+
+	// Act
+	model.HandleKey(Key{Code: KeyEnter})
+	model.HandleKey(Key{Code: KeyBackspace})
+	model.HandleKey(Key{Code: KeyBackspace})
+	typeText(model, "ught")
+	model.HandleKey(Key{Code: KeyEnter})
+	stored, _ := useCase.GetTodoByID(id)
+
+	// Assert
+	assert.Equal(t, "Tyught", stored.Title)
+}
+
+func TestShouldReportConflictWhenTodoChangedElsewhere(t *testing.T) {
+	// Arrange
+	model, useCase := newTestModel(t, "Shared")
+	id := model.selected().ID
+	useCase.UpdateTodo(id, "Changed in another terminal", "")
+
+	// Act
+	model.HandleKey(Key{Code: KeyRune, Rune: 'e'})
+	typeText(model, "!")
+	model.HandleKey(Key{Code: KeyEnter})
+	stored, _ := useCase.GetTodoByID(id)
+
+	// Assert
+	assert.Contains(t, screen(model), "❌ Erro ao atualizar tarefa")
+	assert.Equal(t, "Changed in another terminal", stored.Title)
+	assert.Equal(t, "Changed in another terminal", model.selected().Title, "Expected the list to be reloaded")
+}
+
+func TestShouldAskForConfirmationBeforeDeleting(t *testing.T) {
+	// Arrange
+	model, useCase := newTestModel(t, "Keep", "Remove")
+	model.HandleKey(Key{Code: KeyEnd})
+
+	// Act
+	typeText(model, "d")
+	prompt := screen(model)
+	typeText(model, "n")
+	afterCancel, _ := useCase.GetAllTodos()
+	typeText(model, "ds")
+	afterConfirm, _ := useCase.GetAllTodos()
+
+	// Assert
+	assert.Contains(t, prompt, "🗑️  Remover 'Remove'? (s/n)")
+	assert.Len(t, afterCancel, 2)
+	assert.Len(t, afterConfirm, 1)
+	assert.Equal(t, "Keep", afterConfirm[0].Title)
+}
+
+func TestShouldFilterWhileTyping(t *testing.T) {
+	// Arrange
+	model, _ := newTestModel(t, "Write report", "Buy milk", "Review report")
+
+	// Act
+	typeText(model, "/REPORT")
+	whileTyping := len(model.visible)
+	model.HandleKey(Key{Code: KeyEnter})
+	filtered := screen(model)
+	model.HandleKey(Key{Code: KeyEscape})
+
+	// Assert
+	assert.Equal(t, 2, whileTyping)
+	assert.Contains(t, filtered, `filtro: "REPORT" (2)`)
+	assert.NotContains(t, filtered, "Buy milk")
+	assert.Len(t, model.visible, 3, "Expected Esc to clear the filter")
+}
+
+func TestShouldUndoLastChange(t *testing.T) {
+	// Arrange
+	model, _ := newTestModel(t, "Oops")
+	typeText(model, " ")
+
+	// Act
+	typeText(model, "u")
+
+	// Assert
+	assert.False(t, model.selected().Completed)
+	assert.Contains(t, screen(model), "↩️  Desfeito: 'Oops'")
+}
+
+func TestShouldScrollToKeepCursorVisible(t *testing.T) {
+	// Arrange
+	titles := make([]string, 20)
+	for i := range titles {
+		titles[i] = string(rune('A' + i))
+	}
+	model, _ := newTestModel(t, titles...)
+
+	// Act
+	model.HandleKey(Key{Code: KeyEnd})
+	lines := model.View(40, 10)
+
+	// Assert
+	assert.Len(t, lines, 10)
+	assert.Contains(t, lines[len(lines)-4], "> ⏳ T")
+	assert.NotContains(t, strings.Join(lines, "\n"), "⏳ A")
+}
+
+func TestShouldShowUseCaseErrorsInStatusLine(t *testing.T) {
+	// Arrange
+	mockUseCase := new(app_interfaces.MockTodoUseCase)
+	mockUseCase.On("GetAllTodos").Return([]*entity.Todo{{ID: "1", Title: "Guarded"}}, nil)
+	mockUseCase.On("CompleteTodo", "1").Return(nil, errors.New("hook pre-complete rejected the operation: tests are red"))
+	model := NewModel(mockUseCase)
+	model.Reload()
+
+	// Act
+	typeText(model, "x")
+
+	// Assert
+	assert.Contains(t, screen(model), "❌ Erro ao completar tarefa: hook pre-complete rejected the operation: tests are red")
+}
+
+func TestShouldQuitOnQOrCtrlC(t *testing.T) {
+	// Arrange
+	first, _ := newTestModel(t)
+	second, _ := newTestModel(t)
+
+	// Act
+	typeText(first, "q")
+	second.HandleKey(Key{Code: KeyCtrlC})
+
+	// Assert
+	assert.True(t, first.Quit())
+	assert.True(t, second.Quit())
+}
@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"codecademy-yellowbelt2/core/domain/entity"
+	app_interfaces "codecademy-yellowbelt2/infrastructure/interface/application"
+)
+
+// RefreshInterval é usado para recarregar a lista quando o armazenamento não
+// avisa sobre alterações, e para perceber que o terminal mudou de tamanho
+const RefreshInterval = 2 * time.Second
+
+var ErrNotTerminal = errors.New("tui requires an interactive terminal")
+
+const (
+	enterScreen = "\x1b[?1049h\x1b[?25l" // Tela alternativa, cursor oculto
+	leaveScreen = "\x1b[?25h\x1b[?1049l"
+)
+
+// Run abre a interface em tela cheia até o usuário sair ou ctx terminar
+func Run(ctx context.Context, useCase app_interfaces.ITodoUseCase, in *os.File, out io.Writer) error {
+	fd := int(in.Fd())
+	if !term.IsTerminal(fd) {
+		return ErrNotTerminal
+	}
+
+	model := NewModel(useCase)
+	if err := model.Reload(); err != nil {
+		return err
+	}
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+	io.WriteString(out, enterScreen)
+	defer io.WriteString(out, leaveScreen)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Sem suporte a observação, a lista é recarregada periodicamente
+	changes, err := useCase.WatchTodos(ctx)
+	if err != nil {
+		changes = nil
+	}
+
+	input, err := openInput(in)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+	keys := make(chan []Key)
+	go readKeys(ctx, input, keys)
+
+	ticker := time.NewTicker(RefreshInterval)
+	defer ticker.Stop()
+
+	width, height := terminalSize(fd)
+	render(out, model, width, height)
+	for !model.Quit() {
+		select {
+		case <-ctx.Done():
+			return nil
+		case batch, open := <-keys:
+			if !open {
+				return nil
+			}
+			for _, key := range batch {
+				model.HandleKey(key)
+			}
+		case _, open := <-changes:
+			if !open {
+				changes = nil
+				continue
+			}
+			drain(changes)
+			model.reloadWithStatus()
+		case <-ticker.C:
+			if changes == nil {
+				model.reloadWithStatus()
+			} else if w, h := terminalSize(fd); w == width && h == height {
+				continue
+			}
+		}
+		width, height = terminalSize(fd)
+		render(out, model, width, height)
+	}
+	return nil
+}
+
+func readKeys(ctx context.Context, in io.Reader, keys chan<- []Key) {
+	defer close(keys)
+	buf := make([]byte, 64)
+	for {
+		n, err := in.Read(buf)
+		if err != nil {
+			return
+		}
+		select {
+		case keys <- decodeKeys(buf[:n]):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// drain descarta as alterações já enfileiradas: uma recarga cobre todas
+func drain(changes <-chan *entity.Event) {
+	for {
+		select {
+		case _, open := <-changes:
+			if !open {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+func terminalSize(fd int) (int, int) {
+	width, height, err := term.GetSize(fd)
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// render reescreve a tela a partir do topo, limpando o resto de cada linha
+// em vez da tela toda para evitar que ela pisque
+func render(out io.Writer, model *Model, width, height int) {
+	var screen strings.Builder
+	screen.WriteString("\x1b[H")
+	for i, line := range model.View(width, height) {
+		if i > 0 {
+			screen.WriteString("\r\n")
+		}
+		screen.WriteString(line)
+		screen.WriteString("\x1b[K")
+	}
+	screen.WriteString("\x1b[J")
+	io.WriteString(out, screen.String())
+}
@@ -0,0 +1,597 @@
+package repository
+
+import (
+	"bufio"
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultSnapshotInterval é quantos eventos são gravados entre dois snapshots
+const DefaultSnapshotInterval = 100
+
+// EventSourcedTodoRepository guarda cada alteração como um evento em um log
+// append-only (JSON lines) e reconstrói o estado reproduzindo o log a partir
+// do último snapshot
+type EventSourcedTodoRepository struct {
+	logFile          string
+	snapshotFile     string
+	snapshotInterval int
+	tailInterval     time.Duration
+	mutex            sync.Mutex
+
+	loaded        bool
+	todos         map[string]*entity.Todo
+	sequence      int64 // último evento aplicado
+	offset        int64 // bytes do log já lidos
+	first         int64 // sequência do primeiro evento do log lido; muda quando o log é compactado
+	sinceSnapshot int
+}
+
+// eventSnapshot é o estado reconstruído até Sequence; LogOffset indica onde
+// o log deve continuar sendo lido
+type eventSnapshot struct {
+	Sequence  int64                   `json:"seq"`
+	LogOffset int64                   `json:"log_offset"`
+	Todos     map[string]*entity.Todo `json:"todos"`
+}
+
+var _ repository.ITodoRepository = (*EventSourcedTodoRepository)(nil)
+var _ repository.IUnitOfWork = (*EventSourcedTodoRepository)(nil)
+var _ repository.ICompactable = (*EventSourcedTodoRepository)(nil)
+var _ repository.IWatchable = (*EventSourcedTodoRepository)(nil)
+
+type EventSourcedOption func(*EventSourcedTodoRepository)
+
+// WithSnapshotInterval define a cada quantos eventos um snapshot é gravado;
+// zero desativa os snapshots automáticos
+func WithSnapshotInterval(events int) EventSourcedOption {
+	return func(r *EventSourcedTodoRepository) {
+		r.snapshotInterval = events
+	}
+}
+
+// WithTailInterval define de quanto em quanto tempo Watch procura novos
+// eventos no log
+func WithTailInterval(interval time.Duration) EventSourcedOption {
+	return func(r *EventSourcedTodoRepository) {
+		r.tailInterval = interval
+	}
+}
+
+// NewEventSourcedTodoRepository usa filename como log de eventos e
+// filename + ".snapshot" para os snapshots
+func NewEventSourcedTodoRepository(filename string, opts ...EventSourcedOption) repository.ITodoRepository {
+	r := &EventSourcedTodoRepository{
+		logFile:          filename,
+		snapshotFile:     filename + ".snapshot",
+		snapshotInterval: DefaultSnapshotInterval,
+		tailInterval:     DefaultPollInterval,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (r *EventSourcedTodoRepository) Create(todo *entity.Todo) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	unlock, err := r.lockLog()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := r.refresh(); err != nil {
+		return err
+	}
+
+	todo.Version = 1
+	return r.append([]*entity.Event{entity.NewEvent(entity.EventTodoCreated, todo.ID, todo)})
+}
+
+func (r *EventSourcedTodoRepository) GetByID(id string) (*entity.Todo, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := r.refresh(); err != nil {
+		return nil, err
+	}
+
+	todo, exists := r.todos[id]
+	if !exists || todo.IsDeleted() {
+		return nil, errors.New("todo not found")
+	}
+	return todo.Clone(), nil
+}
+
+func (r *EventSourcedTodoRepository) GetAll() ([]*entity.Todo, error) {
+	return r.Find(repository.TodoQuery{})
+}
+
+func (r *EventSourcedTodoRepository) Find(query repository.TodoQuery) ([]*entity.Todo, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := r.refresh(); err != nil {
+		return nil, err
+	}
+
+	todos := make([]*entity.Todo, 0, len(r.todos))
+	for _, todo := range r.todos {
+		if query.Matches(todo) {
+			todos = append(todos, todo.Clone())
+		}
+	}
+	return todos, nil
+}
+
+func (r *EventSourcedTodoRepository) Update(todo *entity.Todo) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	unlock, err := r.lockLog()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := r.refresh(); err != nil {
+		return err
+	}
+
+	stored, exists := r.todos[todo.ID]
+	if !exists {
+		return errors.New("todo not found")
+	}
+	if err := nextVersion(stored, todo); err != nil {
+		return err
+	}
+
+	return r.append([]*entity.Event{entity.NewEvent(entity.EventTypeFor(stored, todo), todo.ID, todo)})
+}
+
+func (r *EventSourcedTodoRepository) Delete(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	unlock, err := r.lockLog()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := r.refresh(); err != nil {
+		return err
+	}
+
+	if _, exists := r.todos[id]; !exists {
+		return errors.New("todo not found")
+	}
+
+	return r.append([]*entity.Event{entity.NewEvent(entity.EventTodoDeleted, id, nil)})
+}
+
+// Begin bloqueia o repositório, inclusive para outros processos, até
+// Commit/Rollback; no Commit as diferenças entre o estado atual e o da
+// transação viram eventos gravados de uma só vez
+func (r *EventSourcedTodoRepository) Begin() (repository.ITodoTransaction, error) {
+	r.mutex.Lock()
+
+	unlockLog, err := r.lockLog()
+	if err != nil {
+		r.mutex.Unlock()
+		return nil, err
+	}
+	unlock := func() {
+		unlockLog()
+		r.mutex.Unlock()
+	}
+
+	if err := r.refresh(); err != nil {
+		unlock()
+		return nil, err
+	}
+
+	commit := func(todos map[string]*entity.Todo) error {
+		return r.append(diffEvents(r.todos, todos))
+	}
+	return newTodoTransaction(r.todos, commit, unlock), nil
+}
+
+// lockLog impede que outro processo grave no log entre a leitura da última
+// sequência e a gravação dos próximos eventos, o que repetiria sequências
+func (r *EventSourcedTodoRepository) lockLog() (func(), error) {
+	file, err := os.OpenFile(r.logFile, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return func() {
+		unlockFile(file)
+		file.Close()
+	}, nil
+}
+
+// Compact grava um snapshot com o estado atual e esvazia o log; os eventos
+// anteriores ao snapshot deixam de existir
+func (r *EventSourcedTodoRepository) Compact() (repository.CompactResult, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	unlock, err := r.lockLog()
+	if err != nil {
+		return repository.CompactResult{}, err
+	}
+	defer unlock()
+
+	if err := r.refresh(); err != nil {
+		return repository.CompactResult{}, err
+	}
+
+	// O snapshot é gravado antes de truncar o log: se o processo parar no
+	// meio, os eventos que sobrarem são ignorados pela sequência
+	if err := r.writeSnapshot(0); err != nil {
+		return repository.CompactResult{}, err
+	}
+	if err := os.Truncate(r.logFile, 0); err != nil && !os.IsNotExist(err) {
+		return repository.CompactResult{}, err
+	}
+
+	result := repository.CompactResult{
+		Todos:          len(r.todos),
+		Sequence:       r.sequence,
+		ReclaimedBytes: r.offset,
+	}
+	r.offset = 0
+	r.first = 0
+	r.sinceSnapshot = 0
+	return result, nil
+}
+
+// Watch acompanha o final do log e entrega os próprios eventos gravados,
+// inclusive por outros processos, completando-os com o estado anterior
+func (r *EventSourcedTodoRepository) Watch(ctx context.Context) (<-chan *entity.Event, error) {
+	r.mutex.Lock()
+	err := r.refresh()
+	known := make(map[string]*entity.Todo, len(r.todos))
+	for id, todo := range r.todos {
+		known[id] = todo.Clone()
+	}
+	sequence, offset := r.sequence, r.offset
+	r.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	first, err := firstSequence(r.logFile)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(chan *entity.Event, DefaultWatchBuffer)
+	go func() {
+		defer close(changes)
+		poll(ctx, r.tailInterval, func() bool {
+			size, err := fileSize(r.logFile)
+			if err != nil || size == offset {
+				return true
+			}
+			// Um log que começa em outro evento foi compactado e regravado,
+			// mesmo que já tenha voltado a crescer além do que foi lido
+			current, err := firstSequence(r.logFile)
+			if err != nil {
+				return true
+			}
+			if size < offset || current != first {
+				offset, first = 0, current
+			}
+
+			var events []*entity.Event
+			offset, err = readLog(r.logFile, offset, func(event *entity.Event) error {
+				if event.Sequence <= sequence {
+					return nil
+				}
+				previous := known[event.TodoID]
+				event.Type = watchEventType(event.Type, previous, event.Todo)
+				event.Previous = previous
+				if event.Todo == nil {
+					delete(known, event.TodoID)
+				} else {
+					known[event.TodoID] = event.Todo.Clone()
+				}
+				sequence = event.Sequence
+				events = append(events, event)
+				return nil
+			})
+			for _, event := range events {
+				if !send(ctx, changes, event) {
+					return false
+				}
+			}
+			return err == nil
+		})
+	}()
+	return changes, nil
+}
+
+// refresh aplica os eventos gravados desde a última leitura, inclusive por
+// outros processos. Como no Watch, um log menor que o já lido ou que começa
+// em outro evento foi compactado e regravado, mesmo que já tenha voltado a
+// crescer até o tamanho lido; o estado é então recarregado do snapshot
+func (r *EventSourcedTodoRepository) refresh() error {
+	size, err := fileSize(r.logFile)
+	if err != nil {
+		return err
+	}
+
+	compacted := size < r.offset
+	if r.loaded && !compacted && r.offset > 0 {
+		first, err := firstSequence(r.logFile)
+		if err != nil {
+			return err
+		}
+		compacted = first != r.first
+	}
+	if !r.loaded || compacted {
+		if err := r.loadSnapshot(size); err != nil {
+			return err
+		}
+		r.loaded = true
+	}
+	if size != r.offset {
+		if err := r.replay(); err != nil {
+			return err
+		}
+	}
+
+	if r.first == 0 && r.offset > 0 {
+		r.first, err = firstSequence(r.logFile)
+	}
+	return err
+}
+
+func (r *EventSourcedTodoRepository) loadSnapshot(logSize int64) error {
+	r.todos = make(map[string]*entity.Todo)
+	r.sequence = 0
+	r.offset = 0
+	r.first = 0
+	r.sinceSnapshot = 0
+
+	data, err := os.ReadFile(r.snapshotFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil // Sem snapshot, o log é reproduzido desde o início
+		}
+		return err
+	}
+
+	var snapshot eventSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return err
+	}
+	if snapshot.Todos != nil {
+		r.todos = snapshot.Todos
+	}
+	r.sequence = snapshot.Sequence
+	// Se o log mudou desde o snapshot, ele é relido por inteiro e os eventos
+	// já incluídos no snapshot são descartados pela sequência
+	if snapshot.LogOffset <= logSize {
+		r.offset = snapshot.LogOffset
+	}
+	return nil
+}
+
+// replay aplica os eventos do log posteriores ao estado atual; as sequências
+// do log só crescem, então uma sequência repetida indica que dois processos
+// gravaram ao mesmo tempo e o estado não pode ser reconstruído com segurança
+func (r *EventSourcedTodoRepository) replay() error {
+	var previous int64
+	if r.offset > 0 {
+		previous = r.sequence
+	}
+	offset, err := readLog(r.logFile, r.offset, func(event *entity.Event) error {
+		if event.Sequence <= previous {
+			return fmt.Errorf("event log %s has duplicate sequence %d", r.logFile, event.Sequence)
+		}
+		previous = event.Sequence
+		if event.Sequence > r.sequence {
+			r.apply(event)
+		}
+		return nil
+	})
+	if err != nil {
+		// O estado pode ter ficado pela metade; a próxima leitura recomeça do snapshot
+		r.loaded = false
+		return err
+	}
+	r.offset = offset
+	return nil
+}
+
+// readLog entrega os eventos gravados a partir de offset e retorna até onde
+// leu; uma linha incompleta é de uma gravação em andamento e fica para depois.
+// Um erro de fn interrompe a leitura antes do evento que o causou
+func readLog(filename string, offset int64, fn func(event *entity.Event) error) (int64, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return offset, err
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return offset, err
+	}
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			return offset, nil
+		}
+		if err != nil {
+			return offset, err
+		}
+		var event entity.Event
+		if err := json.Unmarshal(line, &event); err != nil {
+			return offset, err
+		}
+		if err := fn(&event); err != nil {
+			return offset, err
+		}
+		offset += int64(len(line))
+	}
+}
+
+func (r *EventSourcedTodoRepository) apply(event *entity.Event) {
+	if event.Type == entity.EventTodoDeleted {
+		delete(r.todos, event.TodoID)
+	} else {
+		r.todos[event.TodoID] = event.Todo
+	}
+	r.sequence = event.Sequence
+	r.sinceSnapshot++
+}
+
+// append grava os eventos com uma única escrita e só então os aplica ao estado
+func (r *EventSourcedTodoRepository) append(events []*entity.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var data []byte
+	for i, event := range events {
+		event.Sequence = r.sequence + int64(i) + 1
+		line, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		data = append(data, line...)
+		data = append(data, '\n')
+	}
+
+	file, err := os.OpenFile(r.logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if r.offset == 0 {
+		r.first = events[0].Sequence
+	}
+	r.offset += int64(len(data))
+	for _, event := range events {
+		r.apply(event)
+	}
+
+	// O snapshot só acelera a próxima carga; se falhar, o log continua
+	// completo e a gravação é tentada de novo no próximo evento
+	if r.snapshotInterval > 0 && r.sinceSnapshot >= r.snapshotInterval {
+		_ = r.writeSnapshot(r.offset)
+	}
+	return nil
+}
+
+// writeSnapshot grava o estado em um arquivo temporário e o renomeia, para
+// que um snapshot pela metade nunca substitua o anterior
+func (r *EventSourcedTodoRepository) writeSnapshot(logOffset int64) error {
+	data, err := json.MarshalIndent(eventSnapshot{
+		Sequence:  r.sequence,
+		LogOffset: logOffset,
+		Todos:     r.todos,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpFile := r.snapshotFile + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpFile, r.snapshotFile); err != nil {
+		return err
+	}
+
+	r.sinceSnapshot = 0
+	return nil
+}
+
+// diffEvents descreve como eventos a passagem do estado before para after,
+// em ordem de ID para que o log seja determinístico
+func diffEvents(before, after map[string]*entity.Todo) []*entity.Event {
+	ids := make([]string, 0, len(before)+len(after))
+	for id := range before {
+		ids = append(ids, id)
+	}
+	for id := range after {
+		if _, exists := before[id]; !exists {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	var events []*entity.Event
+	for _, id := range ids {
+		old, next := before[id], after[id]
+		if old != nil && next != nil && old.Version == next.Version {
+			continue
+		}
+		events = append(events, entity.NewEvent(entity.EventTypeFor(old, next), id, next))
+	}
+	return events
+}
+
+// firstSequence retorna a sequência do primeiro evento do log, ou zero se
+// ele ainda não tem um evento completo
+func firstSequence(filename string) (int64, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer file.Close()
+
+	line, err := bufio.NewReader(file).ReadBytes('\n')
+	if err == io.EOF {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	var event entity.Event
+	if err := json.Unmarshal(line, &event); err != nil {
+		return 0, err
+	}
+	return event.Sequence, nil
+}
+
+func fileSize(filename string) (int64, error) {
+	info, err := os.Stat(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return info.Size(), nil
+}
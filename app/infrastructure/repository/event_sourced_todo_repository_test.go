@@ -0,0 +1,326 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func readEvents(t *testing.T, filename string) []entity.Event {
+	file, err := os.Open(filename)
+	assert.NoError(t, err)
+	defer file.Close()
+
+	var events []entity.Event
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event entity.Event
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestShouldAppendDomainEventsToLog(t *testing.T) {
+	// Arrange
+	logFile := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	repo := NewEventSourcedTodoRepository(logFile)
+	todo := entity.NewTodo("Evented", "")
+
+	// Act
+	repo.Create(todo)
+	todo.Update("Renamed", "")
+	repo.Update(todo)
+	todo.MarkAsCompleted()
+	repo.Update(todo)
+	repo.Delete(todo.ID)
+	events := readEvents(t, logFile)
+
+	// Assert
+	assert.Len(t, events, 4)
+	assert.Equal(t, entity.EventTodoCreated, events[0].Type)
+	assert.Equal(t, entity.EventTodoUpdated, events[1].Type)
+	assert.Equal(t, entity.EventTodoCompleted, events[2].Type)
+	assert.Equal(t, entity.EventTodoDeleted, events[3].Type)
+	for i, event := range events {
+		assert.Equal(t, int64(i+1), event.Sequence)
+		assert.Equal(t, todo.ID, event.TodoID)
+	}
+}
+
+func TestShouldRebuildStateByReplayingLog(t *testing.T) {
+	// Arrange
+	logFile := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	writer := NewEventSourcedTodoRepository(logFile, WithSnapshotInterval(0))
+	kept := entity.NewTodo("Kept", "")
+	removed := entity.NewTodo("Removed", "")
+	writer.Create(kept)
+	writer.Create(removed)
+	kept.MarkAsCompleted()
+	writer.Update(kept)
+	writer.Delete(removed.ID)
+
+	// Act
+	reader := NewEventSourcedTodoRepository(logFile)
+	todos, err := reader.GetAll()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, todos, 1)
+	assert.Equal(t, kept.ID, todos[0].ID)
+	assert.True(t, todos[0].Completed)
+	assert.Equal(t, 2, todos[0].Version)
+}
+
+func TestShouldSeeEventsAppendedByAnotherInstance(t *testing.T) {
+	// Arrange
+	logFile := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	first := NewEventSourcedTodoRepository(logFile)
+	second := NewEventSourcedTodoRepository(logFile)
+	todo := entity.NewTodo("Shared", "")
+	first.Create(todo)
+	second.GetAll()
+
+	// Act
+	todo.Update("Changed by first", "")
+	first.Update(todo)
+	got, err := second.GetByID(todo.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Changed by first", got.Title)
+}
+
+func TestShouldReloadSnapshotWhenAnotherInstanceCompactsAndLogGrowsBack(t *testing.T) {
+	// Arrange
+	logFile := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	writer := NewEventSourcedTodoRepository(logFile)
+	reader := NewEventSourcedTodoRepository(logFile)
+	first := entity.NewTodo("First", "")
+	writer.Create(first)
+	reader.GetAll()
+
+	// Act
+	second := entity.NewTodo("Second", "")
+	writer.Create(second)
+	writer.(repository.ICompactable).Compact()
+	// O log volta a crescer além do ponto já lido pelo reader
+	first.Update("Renamed after compaction", strings.Repeat("x", 1024))
+	writer.Update(first)
+	todos, err := reader.GetAll()
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, todos, 2)
+	got, err := reader.GetByID(first.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Renamed after compaction", got.Title)
+}
+
+func TestShouldWriteSnapshotPeriodically(t *testing.T) {
+	// Arrange
+	logFile := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	repo := NewEventSourcedTodoRepository(logFile, WithSnapshotInterval(2))
+
+	// Act
+	repo.Create(entity.NewTodo("First", ""))
+	repo.Create(entity.NewTodo("Second", ""))
+	repo.Create(entity.NewTodo("Third", ""))
+	data, readErr := os.ReadFile(logFile + ".snapshot")
+	var snapshot eventSnapshot
+	json.Unmarshal(data, &snapshot)
+	todos, err := NewEventSourcedTodoRepository(logFile).GetAll()
+
+	// Assert
+	assert.NoError(t, readErr)
+	assert.Equal(t, int64(2), snapshot.Sequence)
+	assert.Len(t, snapshot.Todos, 2)
+	assert.Positive(t, snapshot.LogOffset)
+	assert.NoError(t, err)
+	assert.Len(t, todos, 3)
+}
+
+func TestShouldStartFromSnapshotWithoutReplayingOlderEvents(t *testing.T) {
+	// Arrange
+	logFile := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	repo := NewEventSourcedTodoRepository(logFile, WithSnapshotInterval(1))
+	todo := entity.NewTodo("Snapshotted", "")
+	repo.Create(todo)
+	// Eventos anteriores ao snapshot não precisam mais ser lidos
+	data, _ := os.ReadFile(logFile)
+	os.WriteFile(logFile, bytes.Repeat([]byte("x"), len(data)), 0644)
+
+	// Act
+	got, err := NewEventSourcedTodoRepository(logFile).GetByID(todo.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Snapshotted", got.Title)
+}
+
+func TestShouldCompactLogIntoSnapshot(t *testing.T) {
+	// Arrange
+	logFile := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	repo := NewEventSourcedTodoRepository(logFile, WithSnapshotInterval(0))
+	todo := entity.NewTodo("Compacted", "")
+	repo.Create(todo)
+	todo.MarkAsCompleted()
+	repo.Update(todo)
+	removed := entity.NewTodo("Removed", "")
+	repo.Create(removed)
+	repo.Delete(removed.ID)
+
+	// Act
+	result, err := repo.(repository.ICompactable).Compact()
+	info, _ := os.Stat(logFile)
+	repo.Create(entity.NewTodo("After compaction", ""))
+	events := readEvents(t, logFile)
+	todos, getErr := NewEventSourcedTodoRepository(logFile).GetAll()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Todos)
+	assert.Equal(t, int64(4), result.Sequence)
+	assert.Positive(t, result.ReclaimedBytes)
+	assert.Zero(t, info.Size())
+	assert.Len(t, events, 1)
+	assert.Equal(t, int64(5), events[0].Sequence)
+	assert.NoError(t, getErr)
+	assert.Len(t, todos, 2)
+}
+
+func TestShouldIgnoreIncompleteTrailingEvent(t *testing.T) {
+	// Arrange
+	logFile := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	repo := NewEventSourcedTodoRepository(logFile)
+	repo.Create(entity.NewTodo("Complete", ""))
+	file, _ := os.OpenFile(logFile, os.O_APPEND|os.O_WRONLY, 0644)
+	file.WriteString(`{"seq":2,"type":"todo.created"`)
+	file.Close()
+
+	// Act
+	todos, err := NewEventSourcedTodoRepository(logFile).GetAll()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, todos, 1)
+}
+
+func TestShouldWaitForAnotherInstanceBeforeAppending(t *testing.T) {
+	// Arrange
+	logFile := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	first := NewEventSourcedTodoRepository(logFile)
+	second := NewEventSourcedTodoRepository(logFile)
+	tx, err := first.(repository.IUnitOfWork).Begin()
+	assert.NoError(t, err)
+	created := make(chan error)
+
+	// Act
+	go func() {
+		created <- second.Create(entity.NewTodo("Waiting", ""))
+	}()
+	var blocked bool
+	select {
+	case <-created:
+	case <-time.After(50 * time.Millisecond):
+		blocked = true
+	}
+	tx.Create(&entity.Todo{ID: "tx", Title: "In transaction"})
+	commitErr := tx.Commit()
+	if blocked {
+		assert.NoError(t, <-created)
+	}
+	events := readEvents(t, logFile)
+	todos, getErr := NewEventSourcedTodoRepository(logFile).GetAll()
+
+	// Assert
+	assert.True(t, blocked)
+	assert.NoError(t, commitErr)
+	assert.NoError(t, getErr)
+	assert.Len(t, todos, 2)
+	if !assert.Len(t, events, 2) {
+		return
+	}
+	assert.Equal(t, int64(1), events[0].Sequence)
+	assert.Equal(t, int64(2), events[1].Sequence)
+}
+
+func TestShouldReportDuplicateSequencesOnReplay(t *testing.T) {
+	// Arrange
+	logFile := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	repo := NewEventSourcedTodoRepository(logFile)
+	repo.Create(entity.NewTodo("First", ""))
+	event, _ := json.Marshal(entity.Event{Sequence: 1, Type: entity.EventTodoCreated, TodoID: "other", Todo: entity.NewTodo("Second", "")})
+	file, _ := os.OpenFile(logFile, os.O_APPEND|os.O_WRONLY, 0644)
+	file.Write(append(event, '\n'))
+	file.Close()
+
+	// Act
+	_, err := NewEventSourcedTodoRepository(logFile).GetAll()
+
+	// Assert
+	assert.ErrorContains(t, err, "duplicate sequence 1")
+}
+
+func TestShouldWriteTransactionAsSingleBatchOfEvents(t *testing.T) {
+	// Arrange
+	logFile := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	repo := NewEventSourcedTodoRepository(logFile)
+	existing := entity.NewTodo("Existing", "")
+	repo.Create(existing)
+	tx, _ := repo.(repository.IUnitOfWork).Begin()
+
+	// Act
+	tx.Create(&entity.Todo{ID: "new", Title: "New"})
+	tx.Delete(existing.ID)
+	err := tx.Commit()
+	events := readEvents(t, logFile)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+	assert.Equal(t, events[1].Sequence+1, events[2].Sequence)
+	assert.ElementsMatch(t, []string{entity.EventTodoCreated, entity.EventTodoDeleted}, []string{events[1].Type, events[2].Type})
+}
+
+func TestShouldWatchEventsFromAnotherInstanceAcrossCompaction(t *testing.T) {
+	// Arrange
+	logFile := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	writer := NewEventSourcedTodoRepository(logFile)
+	writer.Create(entity.NewTodo("Before watching", ""))
+	watcher := NewEventSourcedTodoRepository(logFile, WithTailInterval(5*time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes, err := watcher.(repository.IWatchable).Watch(ctx)
+	assert.NoError(t, err)
+
+	// Act
+	first := entity.NewTodo("First", "")
+	writer.Create(first)
+	created := nextChange(t, changes)
+	writer.(repository.ICompactable).Compact()
+	// O log volta a crescer além do ponto já lido antes da próxima verificação
+	first.Update("Renamed after compaction", strings.Repeat("x", 1024))
+	writer.Update(first)
+	updated := nextChange(t, changes)
+
+	// Assert
+	assert.Equal(t, entity.EventTodoCreated, created.Type)
+	assert.Equal(t, int64(2), created.Sequence)
+	assert.Equal(t, entity.EventTodoUpdated, updated.Type)
+	assert.Equal(t, int64(3), updated.Sequence)
+	assert.Equal(t, "First", updated.Previous.Title)
+	assert.Equal(t, "Renamed after compaction", updated.Todo.Title)
+}
@@ -0,0 +1,556 @@
+package repository
+
+import (
+	"archive/tar"
+	"bytes"
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+)
+
+const (
+	backupExt        = ".tar.gz"
+	backupPrefix     = "todo-backup-"
+	backupTimeLayout = "20060102-150405"
+
+	// Caminhos dentro do arquivo: o manifesto vem sempre primeiro
+	backupManifest = "manifest.json"
+	backupData     = "data/"
+	backupConfig   = "config/config.toml"
+)
+
+// backupSkipped são diretórios de dataDir que não entram no backup: os
+// próprios backups e os dados dos outros perfis, que têm backups próprios
+var backupSkipped = map[string]bool{"backups": true, "profiles": true}
+
+// FileBackupRepository guarda backups .tar.gz de todo o dataDir (contextos,
+// histórico, arquivo morto, webhooks, hooks...) e do arquivo de configuração
+// em dataDir/backups. O manifesto lista cada arquivo com tamanho e SHA-256, e
+// nada é restaurado sem que o arquivo inteiro confira
+type FileBackupRepository struct {
+	dataDir    string
+	configFile string
+	mutex      sync.Mutex
+}
+
+var _ repository.IBackupRepository = (*FileBackupRepository)(nil)
+
+func NewFileBackupRepository(dataDir, configFile string) repository.IBackupRepository {
+	return &FileBackupRepository{
+		dataDir:    dataDir,
+		configFile: configFile,
+	}
+}
+
+func (r *FileBackupRepository) dir() string {
+	return filepath.Join(r.dataDir, "backups")
+}
+
+type backupSource struct {
+	name string // caminho dentro do arquivo
+	path string
+}
+
+// sources lista os arquivos de dados e, se existir, o de configuração, além
+// dos diretórios de dados
+func (r *FileBackupRepository) sources() (sources []backupSource, dirs []string, err error) {
+	err = filepath.WalkDir(r.dataDir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if file == r.dataDir && errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll // Nenhum dado gravado ainda
+			}
+			return err
+		}
+		rel, err := filepath.Rel(r.dataDir, file)
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if backupSkipped[rel] {
+				return fs.SkipDir
+			}
+			if rel != "." {
+				dirs = append(dirs, backupData+filepath.ToSlash(rel))
+			}
+			return nil
+		}
+		// Arquivos .tmp são gravações pela metade, trocadas pelo arquivo final
+		if !entry.Type().IsRegular() || strings.HasSuffix(rel, ".tmp") {
+			return nil
+		}
+		sources = append(sources, backupSource{name: backupData + filepath.ToSlash(rel), path: file})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if r.configFile != "" {
+		if info, err := os.Stat(r.configFile); err == nil && info.Mode().IsRegular() {
+			sources = append(sources, backupSource{name: backupConfig, path: r.configFile})
+		}
+	}
+	return sources, dirs, nil
+}
+
+func (r *FileBackupRepository) Create(trigger, to string) (*entity.Backup, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	sources, dirs, err := r.sources()
+	if err != nil {
+		return nil, err
+	}
+
+	// Os arquivos são lidos antes de gravar para que o manifesto e o
+	// conteúdo venham da mesma leitura
+	backup := entity.NewBackup(trigger)
+	backup.Dirs = dirs
+	contents := make([][]byte, len(sources))
+	modes := make([]fs.FileMode, len(sources))
+	for i, source := range sources {
+		info, err := os.Stat(source.path)
+		if err != nil {
+			return nil, err
+		}
+		if contents[i], err = os.ReadFile(source.path); err != nil {
+			return nil, err
+		}
+		modes[i] = info.Mode().Perm()
+		sum := sha256.Sum256(contents[i])
+		backup.Files = append(backup.Files, entity.BackupFile{
+			Path:   source.name,
+			Size:   int64(len(contents[i])),
+			SHA256: hex.EncodeToString(sum[:]),
+		})
+	}
+
+	target, err := r.destination(to, backup)
+	if err != nil {
+		return nil, err
+	}
+
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	manifest, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	entries := append([][]byte{manifest}, contents...)
+	for i, data := range entries {
+		header := &tar.Header{Name: backupManifest, Mode: 0600, Size: int64(len(data)), ModTime: backup.CreatedAt}
+		if i > 0 {
+			header.Name, header.Mode = sources[i-1].name, int64(modes[i-1])
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	// O backup guarda os segredos dos webhooks
+	tmpFile := target + ".tmp"
+	if err := os.WriteFile(tmpFile, archive.Bytes(), 0600); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmpFile, target); err != nil {
+		os.Remove(tmpFile)
+		return nil, err
+	}
+
+	backup.Name = filepath.Base(target)
+	backup.Path = target
+	backup.Size = int64(archive.Len())
+	return backup, nil
+}
+
+// destination escolhe onde gravar: to pode ser um arquivo novo ou um
+// diretório (existente ou terminado em /); sem to, a pasta de backups
+func (r *FileBackupRepository) destination(to string, backup *entity.Backup) (string, error) {
+	dir := r.dir()
+	if to != "" {
+		info, err := os.Stat(to)
+		isDir := err == nil && info.IsDir()
+		if !isDir && !strings.HasSuffix(to, string(os.PathSeparator)) && !strings.HasSuffix(to, "/") {
+			if err == nil {
+				return "", fmt.Errorf("%s already exists", to)
+			}
+			return to, os.MkdirAll(filepath.Dir(to), 0700)
+		}
+		dir = to
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+
+	base := backupPrefix + backup.CreatedAt.Format(backupTimeLayout) + "-" + backup.Trigger
+	target := filepath.Join(dir, base+backupExt)
+	for i := 2; fileExists(target); i++ {
+		target = filepath.Join(dir, fmt.Sprintf("%s-%d%s", base, i, backupExt))
+	}
+	return target, nil
+}
+
+func fileExists(file string) bool {
+	_, err := os.Stat(file)
+	return err == nil
+}
+
+// resolve aceita o nome de um backup da pasta de backups (com ou sem
+// .tar.gz) ou o caminho de um arquivo
+func (r *FileBackupRepository) resolve(ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	candidates := []string{ref}
+	if ref != "" && !strings.ContainsAny(ref, `/\`) {
+		candidates = []string{filepath.Join(r.dir(), ref), filepath.Join(r.dir(), ref+backupExt), ref}
+	}
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && info.Mode().IsRegular() {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", entity.ErrBackupNotFound, ref)
+}
+
+func (r *FileBackupRepository) List() ([]*entity.Backup, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entries, err := os.ReadDir(r.dir())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []*entity.Backup{}, nil
+		}
+		return nil, err
+	}
+
+	backups := []*entity.Backup{}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !strings.HasSuffix(entry.Name(), backupExt) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		file := filepath.Join(r.dir(), entry.Name())
+		backup, err := readBackupManifest(file)
+		if err != nil {
+			// Sem manifesto legível o backup aparece sem formato, para ser verificado
+			backup = &entity.Backup{CreatedAt: info.ModTime()}
+		}
+		backup.Name, backup.Path, backup.Size = entry.Name(), file, info.Size()
+		backups = append(backups, backup)
+	}
+
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+	return backups, nil
+}
+
+func (r *FileBackupRepository) Verify(ref string) (*entity.Backup, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	file, err := r.resolve(ref)
+	if err != nil {
+		return nil, err
+	}
+	return readBackup(file, "")
+}
+
+// Restore extrai e confere o backup numa pasta temporária e só então troca
+// os dados atuais pelos extraídos; se a troca falhar, os dados anteriores voltam
+func (r *FileBackupRepository) Restore(ref string) (*entity.Backup, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	file, err := r.resolve(ref)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(r.dir(), 0700); err != nil {
+		return nil, err
+	}
+	// A pasta temporária fica dentro de dataDir para que os renames não
+	// atravessem sistemas de arquivos
+	staging, err := os.MkdirTemp(r.dir(), ".restore-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(staging)
+
+	backup, err := readBackup(file, staging)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.swap(staging); err != nil {
+		return nil, fmt.Errorf("restore failed, current data kept: %w", err)
+	}
+
+	config := filepath.Join(staging, filepath.FromSlash(backupConfig))
+	if r.configFile != "" && fileExists(config) {
+		if err := replaceFile(config, r.configFile); err != nil {
+			return nil, fmt.Errorf("data restored, but not the config file: %w", err)
+		}
+	}
+
+	backup.Name, backup.Path = filepath.Base(file), file
+	return backup, nil
+}
+
+// swap move os dados atuais para staging/previous e os extraídos em
+// staging/data para dataDir
+func (r *FileBackupRepository) swap(staging string) (err error) {
+	previous := filepath.Join(staging, "previous")
+	if err := os.Mkdir(previous, 0700); err != nil {
+		return err
+	}
+	current, err := os.ReadDir(r.dataDir)
+	if err != nil {
+		return err
+	}
+	restored, err := os.ReadDir(filepath.Join(staging, "data"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	var moved, placed []string
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, name := range placed {
+			os.RemoveAll(filepath.Join(r.dataDir, name))
+		}
+		for _, name := range moved {
+			os.Rename(filepath.Join(previous, name), filepath.Join(r.dataDir, name))
+		}
+	}()
+
+	for _, entry := range current {
+		if backupSkipped[entry.Name()] {
+			continue
+		}
+		if err = os.Rename(filepath.Join(r.dataDir, entry.Name()), filepath.Join(previous, entry.Name())); err != nil {
+			return err
+		}
+		moved = append(moved, entry.Name())
+	}
+	for _, entry := range restored {
+		if err = os.Rename(filepath.Join(staging, "data", entry.Name()), filepath.Join(r.dataDir, entry.Name())); err != nil {
+			return err
+		}
+		placed = append(placed, entry.Name())
+	}
+	return nil
+}
+
+// replaceFile copia src sobre dst passando por um .tmp, já que os dois podem
+// estar em sistemas de arquivos diferentes
+func replaceFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	tmpFile := dst + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, dst)
+}
+
+func (r *FileBackupRepository) Delete(ref string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	file, err := r.resolve(ref)
+	if err != nil {
+		return err
+	}
+	return os.Remove(file)
+}
+
+// openBackup abre o arquivo e lê o manifesto, que precisa ser a primeira entrada
+func openBackup(file string) (*entity.Backup, *tar.Reader, io.Closer, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, nil, fmt.Errorf("%w: %s", entity.ErrBackupNotFound, file)
+		}
+		return nil, nil, nil, err
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, nil, nil, fmt.Errorf("%w: %v", entity.ErrBackupCorrupted, err)
+	}
+	tr := tar.NewReader(gz)
+
+	header, err := tr.Next()
+	if err != nil || header.Name != backupManifest {
+		f.Close()
+		return nil, nil, nil, fmt.Errorf("%w: missing %s", entity.ErrBackupCorrupted, backupManifest)
+	}
+	var backup entity.Backup
+	if err := json.NewDecoder(tr).Decode(&backup); err != nil {
+		f.Close()
+		return nil, nil, nil, fmt.Errorf("%w: invalid %s: %v", entity.ErrBackupCorrupted, backupManifest, err)
+	}
+	if err := backup.CheckVersion(); err != nil {
+		f.Close()
+		return nil, nil, nil, err
+	}
+	return &backup, tr, f, nil
+}
+
+func readBackupManifest(file string) (*entity.Backup, error) {
+	backup, _, closer, err := openBackup(file)
+	if err != nil {
+		return nil, err
+	}
+	closer.Close()
+	return backup, nil
+}
+
+// readBackup confere cada arquivo do backup contra o manifesto (caminho,
+// tamanho, SHA-256 e conteúdo legível) e, com extractTo, grava-os lá
+func readBackup(file, extractTo string) (*entity.Backup, error) {
+	backup, tr, closer, err := openBackup(file)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	expected := make(map[string]entity.BackupFile, len(backup.Files))
+	for _, item := range backup.Files {
+		if !validBackupPath(item.Path) {
+			return nil, fmt.Errorf("%w: unsafe path %q in manifest", entity.ErrBackupCorrupted, item.Path)
+		}
+		if _, ok := expected[item.Path]; ok {
+			return nil, fmt.Errorf("%w: %s listed twice in manifest", entity.ErrBackupCorrupted, item.Path)
+		}
+		expected[item.Path] = item
+	}
+	for _, dir := range backup.Dirs {
+		if !validBackupPath(dir) || dir == backupConfig {
+			return nil, fmt.Errorf("%w: unsafe path %q in manifest", entity.ErrBackupCorrupted, dir)
+		}
+		if extractTo != "" {
+			if err := os.MkdirAll(filepath.Join(extractTo, filepath.FromSlash(dir)), 0755); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", entity.ErrBackupCorrupted, err)
+		}
+		item, ok := expected[header.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is not in the manifest", entity.ErrBackupCorrupted, header.Name)
+		}
+		delete(expected, header.Name)
+
+		data, err := io.ReadAll(io.LimitReader(tr, item.Size+1))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", entity.ErrBackupCorrupted, item.Path, err)
+		}
+		sum := sha256.Sum256(data)
+		if int64(len(data)) != item.Size || hex.EncodeToString(sum[:]) != item.SHA256 {
+			return nil, fmt.Errorf("%w: %s: checksum mismatch", entity.ErrBackupCorrupted, item.Path)
+		}
+		if err := validateBackupContent(item.Path, data); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", entity.ErrBackupCorrupted, item.Path, err)
+		}
+
+		if extractTo != "" {
+			target := filepath.Join(extractTo, filepath.FromSlash(item.Path))
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return nil, err
+			}
+			mode := fs.FileMode(header.Mode).Perm()
+			if mode == 0 {
+				mode = 0644
+			}
+			if err := os.WriteFile(target, data, mode); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for name := range expected {
+		return nil, fmt.Errorf("%w: %s is missing", entity.ErrBackupCorrupted, name)
+	}
+	return backup, nil
+}
+
+// validBackupPath aceita só o arquivo de configuração e caminhos relativos
+// dentro de data/, fora das pastas que o backup não inclui
+func validBackupPath(name string) bool {
+	if name == backupConfig {
+		return true
+	}
+	rel, ok := strings.CutPrefix(name, backupData)
+	if !ok || rel == "" || path.Clean(rel) != rel || path.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, "../") {
+		return false
+	}
+	first, _, _ := strings.Cut(rel, "/")
+	return !backupSkipped[first]
+}
+
+// validateBackupContent confere que os arquivos JSON, JSON Lines e a
+// configuração continuam legíveis
+func validateBackupContent(name string, data []byte) error {
+	switch {
+	case name == backupConfig:
+		var config map[string]any
+		if err := toml.Unmarshal(data, &config); err != nil {
+			return fmt.Errorf("invalid TOML: %v", err)
+		}
+	case strings.HasSuffix(name, ".json"):
+		if len(bytes.TrimSpace(data)) > 0 && !json.Valid(data) {
+			return errors.New("invalid JSON")
+		}
+	case strings.HasSuffix(name, ".jsonl"):
+		for i, line := range bytes.Split(data, []byte("\n")) {
+			if len(bytes.TrimSpace(line)) > 0 && !json.Valid(line) {
+				return fmt.Errorf("invalid JSON on line %d", i+1)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"archive/tar"
+	"codecademy-yellowbelt2/core/domain/entity"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeBackupArchive grava um backup à mão, para simular arquivos adulterados
+func writeBackupArchive(t *testing.T, file string, manifest *entity.Backup, entries [][2]string) {
+	t.Helper()
+	out, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	data, _ := json.Marshal(manifest)
+	entries = append([][2]string{{backupManifest, string(data)}}, entries...)
+	for _, entry := range entries {
+		tw.WriteHeader(&tar.Header{Name: entry[0], Mode: 0644, Size: int64(len(entry[1]))})
+		tw.Write([]byte(entry[1]))
+	}
+	tw.Close()
+	gz.Close()
+}
+
+func writeDataFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestFileBackupRepository_CreateAndRestoreAllData(t *testing.T) {
+	// Arrange
+	dataDir := t.TempDir()
+	configFile := filepath.Join(t.TempDir(), "config.toml")
+	writeDataFiles(t, dataDir, map[string]string{
+		"todos.json":                  `{"a1":{"id":"a1","title":"Comprar pão"}}`,
+		"history.jsonl":               `{"todo_id":"a1"}` + "\n",
+		"contexts/work/todos.json":    `{}`,
+		"hooks/post-create":           "#!/bin/sh\n",
+		"todos.events.jsonl.snap.tmp": "pela metade",
+		"profiles/casa/todos.json":    `{}`,
+	})
+	writeDataFiles(t, filepath.Dir(configFile), map[string]string{"config.toml": "storage = \"file\"\n"})
+	os.MkdirAll(filepath.Join(dataDir, "contexts", "casa"), 0755)
+	repo := NewFileBackupRepository(dataDir, configFile)
+
+	// Act
+	backup, createErr := repo.Create(entity.BackupManual, "")
+	writeDataFiles(t, dataDir, map[string]string{"todos.json": `{}`, "journal.json": `[]`})
+	os.RemoveAll(filepath.Join(dataDir, "contexts"))
+	writeDataFiles(t, filepath.Dir(configFile), map[string]string{"config.toml": "storage = \"events\"\n"})
+	restored, restoreErr := repo.Restore(backup.Name)
+
+	// Assert
+	if !assert.NoError(t, createErr) || !assert.NoError(t, restoreErr) {
+		return
+	}
+	var paths []string
+	for _, file := range backup.Files {
+		paths = append(paths, file.Path)
+	}
+	assert.ElementsMatch(t, []string{"data/todos.json", "data/history.jsonl", "data/contexts/work/todos.json", "data/hooks/post-create", "config/config.toml"}, paths)
+	assert.Equal(t, entity.BackupManual, restored.Trigger)
+
+	todos, _ := os.ReadFile(filepath.Join(dataDir, "todos.json"))
+	config, _ := os.ReadFile(configFile)
+	assert.JSONEq(t, `{"a1":{"id":"a1","title":"Comprar pão"}}`, string(todos))
+	assert.Equal(t, "storage = \"file\"\n", string(config))
+	assert.FileExists(t, filepath.Join(dataDir, "contexts", "work", "todos.json"))
+	assert.DirExists(t, filepath.Join(dataDir, "contexts", "casa"), "Expected empty contexts to be restored")
+	assert.NoFileExists(t, filepath.Join(dataDir, "journal.json"), "Expected files created after the backup to be removed")
+	assert.FileExists(t, filepath.Join(dataDir, "profiles", "casa", "todos.json"), "Expected other profiles to be left alone")
+	assert.FileExists(t, backup.Path, "Expected the backups folder to be kept")
+}
+
+func TestFileBackupRepository_CreateWritesToGivenPath(t *testing.T) {
+	// Arrange
+	dataDir := t.TempDir()
+	writeDataFiles(t, dataDir, map[string]string{"todos.json": `{}`})
+	target := filepath.Join(t.TempDir(), "pendrive", "todos.tar.gz")
+	repo := NewFileBackupRepository(dataDir, "")
+
+	// Act
+	backup, err := repo.Create(entity.BackupManual, target)
+	_, existsErr := repo.Create(entity.BackupManual, target)
+	verified, verifyErr := repo.Verify(target)
+	backups, _ := repo.List()
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, target, backup.Path)
+	assert.EqualError(t, existsErr, target+" already exists")
+	assert.NoError(t, verifyErr)
+	assert.Equal(t, backup.Files, verified.Files)
+	assert.Empty(t, backups, "Expected backups outside the backups folder not to be listed")
+}
+
+func TestFileBackupRepository_ListNewestFirst(t *testing.T) {
+	// Arrange
+	dataDir := t.TempDir()
+	repo := NewFileBackupRepository(dataDir, "")
+	first, _ := repo.Create(entity.BackupAuto, "")
+	second, _ := repo.Create(entity.BackupManual, "")
+	junk := filepath.Join(dataDir, "backups", "todo-backup-lixo.tar.gz")
+	os.WriteFile(junk, []byte("não é gzip"), 0600)
+	os.Chtimes(junk, time.Now().Add(-time.Hour), time.Now().Add(-time.Hour))
+
+	// Act
+	backups, err := repo.List()
+
+	// Assert
+	if !assert.NoError(t, err) || !assert.Len(t, backups, 3) {
+		return
+	}
+	assert.Equal(t, second.Name, backups[0].Name)
+	assert.Equal(t, first.Name, backups[1].Name)
+	assert.Equal(t, entity.BackupAuto, backups[1].Trigger)
+	assert.Equal(t, "todo-backup-lixo.tar.gz", backups[2].Name)
+	assert.Empty(t, backups[2].Format, "Expected unreadable archives to be listed without a manifest")
+}
+
+func TestFileBackupRepository_VerifyRejectsDamagedArchives(t *testing.T) {
+	dataDir := t.TempDir()
+	writeDataFiles(t, dataDir, map[string]string{"todos.json": `{"a1":{}}`})
+	repo := NewFileBackupRepository(dataDir, "")
+	backup, err := repo.Create(entity.BackupManual, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	manifest := func(files ...entity.BackupFile) *entity.Backup {
+		return &entity.Backup{Format: entity.BackupFormat, Version: entity.BackupVersion, Files: files}
+	}
+	valid := backup.Files[0]
+
+	tests := []struct {
+		name     string
+		manifest *entity.Backup
+		entries  [][2]string
+		want     error
+		message  string
+	}{
+		{"tampered content", manifest(valid), [][2]string{{valid.Path, `{"a2":{}}`}},
+			entity.ErrBackupCorrupted, "backup is corrupted: data/todos.json: checksum mismatch"},
+		{"missing file", manifest(valid), nil,
+			entity.ErrBackupCorrupted, "backup is corrupted: data/todos.json is missing"},
+		{"file outside the manifest", manifest(), [][2]string{{valid.Path, `{"a1":{}}`}},
+			entity.ErrBackupCorrupted, "backup is corrupted: data/todos.json is not in the manifest"},
+		{"unsafe path", manifest(entity.BackupFile{Path: "data/../../etc/passwd"}), nil,
+			entity.ErrBackupCorrupted, `backup is corrupted: unsafe path "data/../../etc/passwd" in manifest`},
+		{"newer version", &entity.Backup{Format: entity.BackupFormat, Version: entity.BackupVersion + 1}, nil,
+			entity.ErrBackupUnsupported, "unsupported backup: version 2 (this program reads up to 1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			file := filepath.Join(t.TempDir(), "damaged.tar.gz")
+			writeBackupArchive(t, file, tt.manifest, tt.entries)
+
+			// Act
+			_, err := repo.Verify(file)
+			_, restoreErr := repo.Restore(file)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.want)
+			assert.EqualError(t, err, tt.message)
+			assert.ErrorIs(t, restoreErr, tt.want)
+			todos, _ := os.ReadFile(filepath.Join(dataDir, "todos.json"))
+			assert.Equal(t, `{"a1":{}}`, string(todos), "Expected current data to be kept")
+		})
+	}
+}
+
+func TestFileBackupRepository_RejectsInvalidJSONWithMatchingChecksum(t *testing.T) {
+	// Arrange
+	dataDir := t.TempDir()
+	writeDataFiles(t, dataDir, map[string]string{"todos.json": `{"a1":`})
+	repo := NewFileBackupRepository(dataDir, "")
+	backup, _ := repo.Create(entity.BackupManual, "")
+
+	// Act
+	_, err := repo.Verify(backup.Name)
+
+	// Assert
+	assert.EqualError(t, err, "backup is corrupted: data/todos.json: invalid JSON")
+}
+
+func TestFileBackupRepository_ResolvesNamesAndDeletes(t *testing.T) {
+	// Arrange
+	repo := NewFileBackupRepository(t.TempDir(), "")
+	backup, _ := repo.Create(entity.BackupManual, "")
+	name := backup.Name[:len(backup.Name)-len(backupExt)]
+
+	// Act
+	_, verifyErr := repo.Verify(name)
+	deleteErr := repo.Delete(backup.Name)
+	_, missingErr := repo.Verify(backup.Name)
+
+	// Assert
+	assert.NoError(t, verifyErr, "Expected the name without extension to be accepted")
+	assert.NoError(t, deleteErr)
+	assert.ErrorIs(t, missingErr, entity.ErrBackupNotFound)
+}
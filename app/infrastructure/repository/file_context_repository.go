@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// FileContextRepository guarda cada contexto em dataDir/contexts/<nome>; o
+// contexto padrão é o próprio dataDir, onde as tarefas sempre ficaram. O
+// contexto em uso fica em dataDir/current_context
+type FileContextRepository struct {
+	dataDir string
+	mutex   sync.Mutex
+}
+
+var _ repository.IContextRepository = (*FileContextRepository)(nil)
+
+func NewFileContextRepository(dataDir string) repository.IContextRepository {
+	return &FileContextRepository{dataDir: dataDir}
+}
+
+// ContextDir é o diretório com os arquivos de tarefas de um contexto
+func ContextDir(dataDir, name string) string {
+	if name == entity.DefaultContext {
+		return dataDir
+	}
+	return filepath.Join(dataDir, "contexts", name)
+}
+
+func (r *FileContextRepository) List() ([]string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entries, err := os.ReadDir(filepath.Join(r.dataDir, "contexts"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	names := []string{entity.DefaultContext}
+	for _, entry := range entries {
+		if entry.IsDir() && entity.ValidateContextName(entry.Name()) == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	slices.Sort(names[1:])
+	return names, nil
+}
+
+func (r *FileContextRepository) Create(name string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := entity.ValidateContextName(name); err != nil {
+		return err
+	}
+	if r.exists(name) {
+		return entity.ErrContextExists
+	}
+	return os.MkdirAll(ContextDir(r.dataDir, name), 0755)
+}
+
+// Delete apaga o diretório do contexto com todos os seus arquivos
+func (r *FileContextRepository) Delete(name string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if name == entity.DefaultContext || !r.exists(name) {
+		return entity.ErrContextNotFound
+	}
+	return os.RemoveAll(ContextDir(r.dataDir, name))
+}
+
+func (r *FileContextRepository) Rename(oldName, newName string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := entity.ValidateContextName(newName); err != nil {
+		return err
+	}
+	if oldName == entity.DefaultContext || !r.exists(oldName) {
+		return entity.ErrContextNotFound
+	}
+	if r.exists(newName) {
+		return entity.ErrContextExists
+	}
+	current, err := r.current()
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(ContextDir(r.dataDir, oldName), ContextDir(r.dataDir, newName)); err != nil {
+		return err
+	}
+
+	if current == oldName {
+		return r.setCurrent(newName)
+	}
+	return nil
+}
+
+// Current devolve o contexto em uso; se ele foi apagado por fora, volta ao padrão
+func (r *FileContextRepository) Current() (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.current()
+}
+
+func (r *FileContextRepository) SetCurrent(name string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.exists(name) {
+		return entity.ErrContextNotFound
+	}
+	return r.setCurrent(name)
+}
+
+func (r *FileContextRepository) current() (string, error) {
+	data, err := os.ReadFile(r.pointerFile())
+	if errors.Is(err, os.ErrNotExist) {
+		return entity.DefaultContext, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	name := strings.TrimSpace(string(data))
+	if name == "" || entity.ValidateContextName(name) != nil || !r.exists(name) {
+		return entity.DefaultContext, nil
+	}
+	return name, nil
+}
+
+func (r *FileContextRepository) setCurrent(name string) error {
+	if name == entity.DefaultContext {
+		err := os.Remove(r.pointerFile())
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	if err := os.MkdirAll(r.dataDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.pointerFile(), []byte(name+"\n"), 0644)
+}
+
+func (r *FileContextRepository) exists(name string) bool {
+	if name == entity.DefaultContext {
+		return true
+	}
+	if entity.ValidateContextName(name) != nil {
+		return false
+	}
+	info, err := os.Stat(ContextDir(r.dataDir, name))
+	return err == nil && info.IsDir()
+}
+
+func (r *FileContextRepository) pointerFile() string {
+	return filepath.Join(r.dataDir, "current_context")
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileContextRepository_CreateListAndUse(t *testing.T) {
+	// Arrange
+	dataDir := t.TempDir()
+	repo := NewFileContextRepository(dataDir)
+
+	// Act
+	assert.NoError(t, repo.Create("work"))
+	assert.NoError(t, repo.Create("casa"))
+	assert.NoError(t, repo.SetCurrent("work"))
+	names, listErr := repo.List()
+	current, currentErr := NewFileContextRepository(dataDir).Current()
+
+	// Assert
+	assert.NoError(t, listErr)
+	assert.Equal(t, []string{entity.DefaultContext, "casa", "work"}, names)
+	assert.NoError(t, currentErr)
+	assert.Equal(t, "work", current)
+	assert.DirExists(t, filepath.Join(dataDir, "contexts", "work"))
+	assert.Equal(t, dataDir, ContextDir(dataDir, entity.DefaultContext))
+}
+
+func TestFileContextRepository_RejectsInvalidAndDuplicateNames(t *testing.T) {
+	// Arrange
+	repo := NewFileContextRepository(t.TempDir())
+	assert.NoError(t, repo.Create("work"))
+
+	// Act
+	duplicate := repo.Create("work")
+	invalid := repo.Create("../escape")
+	reserved := repo.Create(entity.DefaultContext)
+
+	// Assert
+	assert.ErrorIs(t, duplicate, entity.ErrContextExists)
+	assert.ErrorIs(t, invalid, entity.ErrInvalidContextName)
+	assert.ErrorIs(t, reserved, entity.ErrContextExists)
+}
+
+func TestFileContextRepository_RenameMovesFilesAndCurrentPointer(t *testing.T) {
+	// Arrange
+	dataDir := t.TempDir()
+	repo := NewFileContextRepository(dataDir)
+	assert.NoError(t, repo.Create("work"))
+	assert.NoError(t, repo.SetCurrent("work"))
+	todos := filepath.Join(ContextDir(dataDir, "work"), "todos.json")
+	assert.NoError(t, os.WriteFile(todos, []byte("{}"), 0644))
+
+	// Act
+	err := repo.Rename("work", "job")
+	current, _ := repo.Current()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "job", current)
+	assert.FileExists(t, filepath.Join(ContextDir(dataDir, "job"), "todos.json"))
+	assert.ErrorIs(t, repo.Rename(entity.DefaultContext, "main"), entity.ErrContextNotFound)
+}
+
+func TestFileContextRepository_DeleteFallsBackToDefault(t *testing.T) {
+	// Arrange
+	dataDir := t.TempDir()
+	repo := NewFileContextRepository(dataDir)
+	assert.NoError(t, repo.Create("work"))
+	assert.NoError(t, repo.SetCurrent("work"))
+
+	// Act
+	err := repo.Delete("work")
+	current, _ := repo.Current()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoDirExists(t, filepath.Join(dataDir, "contexts", "work"))
+	assert.Equal(t, entity.DefaultContext, current)
+	assert.ErrorIs(t, repo.Delete(entity.DefaultContext), entity.ErrContextNotFound)
+	assert.ErrorIs(t, repo.SetCurrent("work"), entity.ErrContextNotFound)
+}
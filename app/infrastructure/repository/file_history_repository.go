@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"bytes"
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// FileHistoryRepository grava uma entrada JSON por linha, apenas anexando ao arquivo
+type FileHistoryRepository struct {
+	filename string
+	mutex    sync.RWMutex
+}
+
+var _ repository.IHistoryRepository = (*FileHistoryRepository)(nil)
+
+func NewFileHistoryRepository(filename string) repository.IHistoryRepository {
+	return &FileHistoryRepository{
+		filename: filename,
+	}
+}
+
+func (r *FileHistoryRepository) Append(entry *entity.HistoryEntry) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(r.filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (r *FileHistoryRepository) GetByTodoID(todoID string) ([]*entity.HistoryEntry, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	data, err := os.ReadFile(r.filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*entity.HistoryEntry{}, nil // Nenhum histórico gravado ainda
+		}
+		return nil, err
+	}
+
+	entries := make([]*entity.HistoryEntry, 0)
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry entity.HistoryEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, err
+		}
+		if entry.TodoID == todoID {
+			entries = append(entries, &entry)
+		}
+	}
+
+	return entries, nil
+}
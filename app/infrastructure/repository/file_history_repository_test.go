@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTempHistoryRepo(t *testing.T) *FileHistoryRepository {
+	filename := filepath.Join(t.TempDir(), "history.jsonl")
+	return NewFileHistoryRepository(filename).(*FileHistoryRepository)
+}
+
+func TestShouldAppendAndReadHistoryInOrder(t *testing.T) {
+	// Arrange
+	repo := createTempHistoryRepo(t)
+	created := entity.NewHistoryEntry("1", entity.HistoryActionCreated, "alice", nil)
+	other := entity.NewHistoryEntry("2", entity.HistoryActionCreated, "alice", nil)
+	deleted := entity.NewHistoryEntry("1", entity.HistoryActionDeleted, "bob", nil)
+
+	// Act
+	errs := []error{repo.Append(created), repo.Append(other), repo.Append(deleted)}
+	entries, err := repo.GetByTodoID("1")
+
+	// Assert
+	for _, appendErr := range errs {
+		assert.NoError(t, appendErr)
+	}
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, entity.HistoryActionCreated, entries[0].Action)
+	assert.Equal(t, entity.HistoryActionDeleted, entries[1].Action)
+	assert.Equal(t, "bob", entries[1].Actor)
+}
+
+func TestShouldReturnEmptyHistoryWhenFileNotExist(t *testing.T) {
+	// Arrange
+	repo := createTempHistoryRepo(t)
+
+	// Act
+	entries, err := repo.GetByTodoID("1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestShouldReturnErrorOnGetHistoryWhenFileIsCorrupted(t *testing.T) {
+	// Arrange
+	repo := createTempHistoryRepo(t)
+	os.WriteFile(repo.filename, []byte("{invalid json\n"), 0644)
+
+	// Act
+	entries, err := repo.GetByTodoID("1")
+
+	// Assert
+	assert.Nil(t, entries)
+	assert.Error(t, err)
+}
+
+func TestShouldReturnErrorOnAppendHistoryWhenOpenFails(t *testing.T) {
+	// Arrange
+	repo := createTempHistoryRepo(t)
+	patch := monkey.Patch(os.OpenFile, func(string, int, os.FileMode) (*os.File, error) {
+		return nil, errors.New("open error")
+	})
+	defer patch.Unpatch()
+
+	// Act
+	err := repo.Append(entity.NewHistoryEntry("1", entity.HistoryActionCreated, "alice", nil))
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, "open error", err.Error())
+}
+
+func TestShouldAppendAndReadHistoryForInMemory(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryHistoryRepository()
+	repo.Append(entity.NewHistoryEntry("1", entity.HistoryActionCreated, "alice", nil))
+	repo.Append(entity.NewHistoryEntry("2", entity.HistoryActionCreated, "alice", nil))
+
+	// Act
+	entries, err := repo.GetByTodoID("1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "1", entries[0].TodoID)
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+type FileJournalRepository struct {
+	filename string
+	mutex    sync.RWMutex
+}
+
+var _ repository.IJournalRepository = (*FileJournalRepository)(nil)
+
+func NewFileJournalRepository(filename string) repository.IJournalRepository {
+	return &FileJournalRepository{
+		filename: filename,
+	}
+}
+
+func (r *FileJournalRepository) Load() (*entity.Journal, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	journal := &entity.Journal{}
+
+	data, err := os.ReadFile(r.filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return journal, nil // Nenhuma operação registrada ainda
+		}
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return journal, nil
+	}
+
+	if err := json.Unmarshal(data, journal); err != nil {
+		return nil, err
+	}
+
+	return journal, nil
+}
+
+func (r *FileJournalRepository) Save(journal *entity.Journal) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	data, err := json.MarshalIndent(journal, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.filename, data, 0644)
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldSaveAndLoadJournal(t *testing.T) {
+	// Arrange
+	repo := NewFileJournalRepository(filepath.Join(t.TempDir(), "journal.json"))
+	journal := &entity.Journal{}
+	journal.Record(entity.NewOperation(entity.OperationDelete, &entity.Todo{ID: "1", Title: "Deleted"}, nil), 10)
+
+	// Act
+	saveErr := repo.Save(journal)
+	loaded, loadErr := repo.Load()
+
+	// Assert
+	assert.NoError(t, saveErr)
+	assert.NoError(t, loadErr)
+	assert.Len(t, loaded.Undo, 1)
+	assert.Equal(t, "Deleted", loaded.Undo[0].Before.Title)
+	assert.Nil(t, loaded.Undo[0].After)
+}
+
+func TestShouldLoadEmptyJournalWhenFileNotExist(t *testing.T) {
+	// Arrange
+	repo := NewFileJournalRepository(filepath.Join(t.TempDir(), "journal.json"))
+
+	// Act
+	journal, err := repo.Load()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, journal.Undo)
+	assert.Empty(t, journal.Redo)
+}
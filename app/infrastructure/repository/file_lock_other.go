@@ -0,0 +1,15 @@
+//go:build !unix
+
+package repository
+
+import "os"
+
+// lockFile não tem flock fora do Unix: só o mutex de cada repositório
+// protege o arquivo, e apenas dentro do mesmo processo
+func lockFile(file *os.File) error {
+	return nil
+}
+
+func unlockFile(file *os.File) error {
+	return nil
+}
@@ -3,23 +3,42 @@ package repository
 import (
 	"codecademy-yellowbelt2/core/domain/entity"
 	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
 	"sync"
+	"time"
 )
 
 type FileTodoRepository struct {
-	filename string
-	mutex    sync.RWMutex
+	filename     string
+	mutex        sync.RWMutex
+	pollInterval time.Duration
 }
 
 var _ repository.ITodoRepository = (*FileTodoRepository)(nil)
+var _ repository.IUnitOfWork = (*FileTodoRepository)(nil)
+var _ repository.IWatchable = (*FileTodoRepository)(nil)
 
-func NewFileTodoRepository(filename string) repository.ITodoRepository {
-	return &FileTodoRepository{
-		filename: filename,
+type FileOption func(*FileTodoRepository)
+
+// WithPollInterval define de quanto em quanto tempo Watch verifica o arquivo
+func WithPollInterval(interval time.Duration) FileOption {
+	return func(r *FileTodoRepository) {
+		r.pollInterval = interval
+	}
+}
+
+func NewFileTodoRepository(filename string, opts ...FileOption) repository.ITodoRepository {
+	r := &FileTodoRepository{
+		filename:     filename,
+		pollInterval: DefaultPollInterval,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 func (r *FileTodoRepository) load() (map[string]*entity.Todo, error) {
@@ -62,6 +81,7 @@ func (r *FileTodoRepository) Create(todo *entity.Todo) error {
 		return err
 	}
 
+	todo.Version = 1
 	todos[todo.ID] = todo
 	return r.save(todos)
 }
@@ -76,7 +96,7 @@ func (r *FileTodoRepository) GetByID(id string) (*entity.Todo, error) {
 	}
 
 	todo, exists := todos[id]
-	if !exists {
+	if !exists || todo.IsDeleted() {
 		return nil, errors.New("todo not found")
 	}
 
@@ -84,6 +104,10 @@ func (r *FileTodoRepository) GetByID(id string) (*entity.Todo, error) {
 }
 
 func (r *FileTodoRepository) GetAll() ([]*entity.Todo, error) {
+	return r.Find(repository.TodoQuery{})
+}
+
+func (r *FileTodoRepository) Find(query repository.TodoQuery) ([]*entity.Todo, error) {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
@@ -94,7 +118,9 @@ func (r *FileTodoRepository) GetAll() ([]*entity.Todo, error) {
 
 	todoList := make([]*entity.Todo, 0, len(todos))
 	for _, todo := range todos {
-		todoList = append(todoList, todo)
+		if query.Matches(todo) {
+			todoList = append(todoList, todo)
+		}
 	}
 
 	return todoList, nil
@@ -109,9 +135,13 @@ func (r *FileTodoRepository) Update(todo *entity.Todo) error {
 		return err
 	}
 
-	if _, exists := todos[todo.ID]; !exists {
+	stored, exists := todos[todo.ID]
+	if !exists {
 		return errors.New("todo not found")
 	}
+	if err := nextVersion(stored, todo); err != nil {
+		return err
+	}
 
 	todos[todo.ID] = todo
 	return r.save(todos)
@@ -133,3 +163,60 @@ func (r *FileTodoRepository) Delete(id string) error {
 	delete(todos, id)
 	return r.save(todos)
 }
+
+// Begin bloqueia o repositório até Commit/Rollback; todas as alterações da
+// transação são gravadas no arquivo de uma só vez no Commit
+func (r *FileTodoRepository) Begin() (repository.ITodoTransaction, error) {
+	r.mutex.Lock()
+
+	todos, err := r.load()
+	if err != nil {
+		r.mutex.Unlock()
+		return nil, err
+	}
+
+	return newTodoTransaction(todos, r.save, r.mutex.Unlock), nil
+}
+
+// Watch verifica periodicamente se o arquivo mudou e, quando muda, compara
+// as tarefas com a última leitura para descobrir o que foi alterado
+func (r *FileTodoRepository) Watch(ctx context.Context) (<-chan *entity.Event, error) {
+	r.mutex.RLock()
+	stamp, err := stampOf(r.filename)
+	var known map[string]*entity.Todo
+	if err == nil {
+		known, err = r.load()
+	}
+	r.mutex.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(chan *entity.Event, DefaultWatchBuffer)
+	go func() {
+		defer close(changes)
+		poll(ctx, r.pollInterval, func() bool {
+			current, err := stampOf(r.filename)
+			if err != nil || current.equal(stamp) {
+				return true
+			}
+
+			r.mutex.RLock()
+			todos, err := r.load()
+			r.mutex.RUnlock()
+			if err != nil {
+				return true // Gravação em andamento: tenta de novo na próxima verificação
+			}
+
+			stamp = current
+			for _, event := range diffChanges(known, todos) {
+				if !send(ctx, changes, event) {
+					return false
+				}
+			}
+			known = todos
+			return true
+		})
+	}()
+	return changes, nil
+}
@@ -2,10 +2,12 @@ package repository
 
 import (
 	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
 	"encoding/json"
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"bou.ke/monkey"
 	"github.com/stretchr/testify/assert"
@@ -73,7 +75,7 @@ func TestShouldUpdateTodo(t *testing.T) {
 	defer cleanup()
 	todo := &entity.Todo{ID: "1", Title: "Old", Completed: false}
 	repo.Create(todo)
-	updated := &entity.Todo{ID: "1", Title: "New", Completed: true}
+	updated := &entity.Todo{ID: "1", Title: "New", Completed: true, Version: 1}
 
 	// Act
 	err := repo.Update(updated)
@@ -86,6 +88,44 @@ func TestShouldUpdateTodo(t *testing.T) {
 	assert.True(t, got.Completed)
 }
 
+func TestShouldIncrementVersionOnEveryWrite(t *testing.T) {
+	// Arrange
+	repo, cleanup := createTempRepo(t)
+	defer cleanup()
+	todo := &entity.Todo{ID: "1", Title: "Old"}
+	repo.Create(todo)
+
+	// Act
+	todo.Title = "New"
+	err := repo.Update(todo)
+	got, _ := repo.GetByID("1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, todo.Version)
+	assert.Equal(t, 2, got.Version)
+}
+
+func TestShouldRejectStaleUpdate(t *testing.T) {
+	// Arrange
+	repo, cleanup := createTempRepo(t)
+	defer cleanup()
+	repo.Create(&entity.Todo{ID: "1", Title: "Old"})
+	first, _ := repo.GetByID("1")
+	second, _ := repo.GetByID("1")
+	first.Title = "First"
+	repo.Update(first)
+
+	// Act
+	second.Title = "Second"
+	err := repo.Update(second)
+	got, _ := repo.GetByID("1")
+
+	// Assert
+	assert.ErrorIs(t, err, entity.ErrVersionConflict)
+	assert.Equal(t, "First", got.Title)
+}
+
 func TestShouldReturnErrorWhenUpdateTodoNotFound(t *testing.T) {
 	// Arrange
 	repo, cleanup := createTempRepo(t)
@@ -310,3 +350,31 @@ func TestShouldReturnErrorOnDeleteWhenLoadFails(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "read error")
 }
+
+func TestShouldHideDeletedTodosUnlessRequested(t *testing.T) {
+	// Arrange
+	repo, cleanup := createTempRepo(t)
+	defer cleanup()
+	deletedAt := time.Now()
+	repo.Create(&entity.Todo{ID: "1", Title: "Alive"})
+	repo.Create(&entity.Todo{ID: "2", Title: "Deleted", DeletedAt: &deletedAt})
+
+	// Act
+	all, allErr := repo.GetAll()
+	_, getErr := repo.GetByID("2")
+	included, includedErr := repo.Find(repository.TodoQuery{IncludeDeleted: true})
+	onlyDeleted, onlyErr := repo.Find(repository.TodoQuery{OnlyDeleted: true})
+	byID, byIDErr := repo.Find(repository.TodoQuery{ID: "2", IncludeDeleted: true})
+
+	// Assert
+	assert.NoError(t, allErr)
+	assert.Len(t, all, 1)
+	assert.Error(t, getErr)
+	assert.NoError(t, includedErr)
+	assert.Len(t, included, 2)
+	assert.NoError(t, onlyErr)
+	assert.Len(t, onlyDeleted, 1)
+	assert.Equal(t, "2", onlyDeleted[0].ID)
+	assert.NoError(t, byIDErr)
+	assert.Len(t, byID, 1)
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+type FileWebhookQueueRepository struct {
+	filename string
+	mutex    sync.RWMutex
+}
+
+var _ repository.IWebhookQueueRepository = (*FileWebhookQueueRepository)(nil)
+
+func NewFileWebhookQueueRepository(filename string) repository.IWebhookQueueRepository {
+	return &FileWebhookQueueRepository{
+		filename: filename,
+	}
+}
+
+func (r *FileWebhookQueueRepository) Load() ([]*entity.WebhookDelivery, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var deliveries []*entity.WebhookDelivery
+
+	data, err := os.ReadFile(r.filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return deliveries, nil // Nenhuma entrega pendente
+		}
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return deliveries, nil
+	}
+
+	if err := json.Unmarshal(data, &deliveries); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *FileWebhookQueueRepository) Save(deliveries []*entity.WebhookDelivery) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(deliveries) == 0 {
+		if err := os.Remove(r.filename); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(deliveries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.filename, data, 0644)
+}
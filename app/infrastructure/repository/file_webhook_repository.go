@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+)
+
+type FileWebhookRepository struct {
+	filename string
+	mutex    sync.RWMutex
+}
+
+var _ repository.IWebhookRepository = (*FileWebhookRepository)(nil)
+
+func NewFileWebhookRepository(filename string) repository.IWebhookRepository {
+	return &FileWebhookRepository{
+		filename: filename,
+	}
+}
+
+func (r *FileWebhookRepository) load() (map[string]*entity.Webhook, error) {
+	webhooks := make(map[string]*entity.Webhook)
+
+	data, err := os.ReadFile(r.filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return webhooks, nil // Nenhum webhook cadastrado ainda
+		}
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return webhooks, nil
+	}
+
+	if err := json.Unmarshal(data, &webhooks); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (r *FileWebhookRepository) save(webhooks map[string]*entity.Webhook) error {
+	data, err := json.MarshalIndent(webhooks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// O arquivo guarda os segredos de assinatura
+	return os.WriteFile(r.filename, data, 0600)
+}
+
+func (r *FileWebhookRepository) Create(webhook *entity.Webhook) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	webhooks, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	webhooks[webhook.ID] = webhook
+	return r.save(webhooks)
+}
+
+func (r *FileWebhookRepository) GetByID(id string) (*entity.Webhook, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	webhooks, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, exists := webhooks[id]
+	if !exists {
+		return nil, errors.New("webhook not found")
+	}
+	return webhook, nil
+}
+
+func (r *FileWebhookRepository) GetAll() ([]*entity.Webhook, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	webhooks, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return sortedWebhooks(webhooks), nil
+}
+
+func (r *FileWebhookRepository) Delete(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	webhooks, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	if _, exists := webhooks[id]; !exists {
+		return errors.New("webhook not found")
+	}
+
+	delete(webhooks, id)
+	return r.save(webhooks)
+}
+
+// sortedWebhooks ordena pelo cadastro para que a listagem seja estável
+func sortedWebhooks(webhooks map[string]*entity.Webhook) []*entity.Webhook {
+	list := make([]*entity.Webhook, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		list = append(list, webhook)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+	return list
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldCreateAndListWebhooks(t *testing.T) {
+	// Arrange
+	repo := NewFileWebhookRepository(filepath.Join(t.TempDir(), "webhooks.json"))
+	first := entity.NewWebhook("https://example.com/first", nil, "")
+	second := entity.NewWebhook("https://example.com/second", []string{entity.EventTodoCompleted}, "secret")
+	second.CreatedAt = first.CreatedAt.Add(time.Second)
+
+	// Act
+	repo.Create(second)
+	repo.Create(first)
+	webhooks, err := repo.GetAll()
+	got, getErr := repo.GetByID(second.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, webhooks, 2)
+	assert.Equal(t, first.ID, webhooks[0].ID, "Expected webhooks in creation order")
+	assert.NoError(t, getErr)
+	assert.Equal(t, []string{entity.EventTodoCompleted}, got.Events)
+	assert.Equal(t, "secret", got.Secret)
+}
+
+func TestShouldKeepWebhookSecretsPrivate(t *testing.T) {
+	// Arrange
+	filename := filepath.Join(t.TempDir(), "webhooks.json")
+	repo := NewFileWebhookRepository(filename)
+
+	// Act
+	repo.Create(entity.NewWebhook("https://example.com/hook", nil, "secret"))
+	info, err := os.Stat(filename)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestShouldDeleteWebhook(t *testing.T) {
+	// Arrange
+	repo := NewFileWebhookRepository(filepath.Join(t.TempDir(), "webhooks.json"))
+	webhook := entity.NewWebhook("https://example.com/hook", nil, "")
+	repo.Create(webhook)
+
+	// Act
+	err := repo.Delete(webhook.ID)
+	_, getErr := repo.GetByID(webhook.ID)
+	missingErr := repo.Delete(webhook.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.EqualError(t, getErr, "webhook not found")
+	assert.EqualError(t, missingErr, "webhook not found")
+}
+
+func TestShouldSaveAndLoadWebhookQueue(t *testing.T) {
+	// Arrange
+	filename := filepath.Join(t.TempDir(), "webhooks.queue.json")
+	repo := NewFileWebhookQueueRepository(filename)
+	delivery := entity.NewWebhookDelivery("d1", "w1", entity.EventTodoCreated, []byte(`{"event":"todo.created"}`))
+	delivery.Attempts = 2
+
+	// Act
+	saveErr := repo.Save([]*entity.WebhookDelivery{delivery})
+	loaded, loadErr := repo.Load()
+	clearErr := repo.Save(nil)
+	_, statErr := os.Stat(filename)
+
+	// Assert
+	assert.NoError(t, saveErr)
+	assert.NoError(t, loadErr)
+	assert.Len(t, loaded, 1)
+	assert.Equal(t, 2, loaded[0].Attempts)
+	assert.JSONEq(t, `{"event":"todo.created"}`, string(loaded[0].Payload))
+	assert.NoError(t, clearErr)
+	assert.True(t, os.IsNotExist(statErr), "Expected empty queue to remove the file")
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"slices"
+	"sync"
+)
+
+type InMemoryContextRepository struct {
+	names   []string
+	current string
+	mutex   sync.Mutex
+}
+
+var _ repository.IContextRepository = (*InMemoryContextRepository)(nil)
+
+func NewInMemoryContextRepository() repository.IContextRepository {
+	return &InMemoryContextRepository{current: entity.DefaultContext}
+}
+
+func (r *InMemoryContextRepository) List() ([]string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	names := append([]string{entity.DefaultContext}, r.names...)
+	slices.Sort(names[1:])
+	return names, nil
+}
+
+func (r *InMemoryContextRepository) Create(name string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := entity.ValidateContextName(name); err != nil {
+		return err
+	}
+	if r.exists(name) {
+		return entity.ErrContextExists
+	}
+	r.names = append(r.names, name)
+	return nil
+}
+
+func (r *InMemoryContextRepository) Delete(name string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	index := slices.Index(r.names, name)
+	if index < 0 {
+		return entity.ErrContextNotFound
+	}
+	r.names = slices.Delete(r.names, index, index+1)
+	if r.current == name {
+		r.current = entity.DefaultContext
+	}
+	return nil
+}
+
+func (r *InMemoryContextRepository) Rename(oldName, newName string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := entity.ValidateContextName(newName); err != nil {
+		return err
+	}
+	index := slices.Index(r.names, oldName)
+	if index < 0 {
+		return entity.ErrContextNotFound
+	}
+	if r.exists(newName) {
+		return entity.ErrContextExists
+	}
+	r.names[index] = newName
+	if r.current == oldName {
+		r.current = newName
+	}
+	return nil
+}
+
+func (r *InMemoryContextRepository) Current() (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.current, nil
+}
+
+func (r *InMemoryContextRepository) SetCurrent(name string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.exists(name) {
+		return entity.ErrContextNotFound
+	}
+	r.current = name
+	return nil
+}
+
+func (r *InMemoryContextRepository) exists(name string) bool {
+	return name == entity.DefaultContext || slices.Contains(r.names, name)
+}
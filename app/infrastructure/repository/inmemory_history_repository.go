@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"sync"
+)
+
+type InMemoryHistoryRepository struct {
+	entries []*entity.HistoryEntry
+	mutex   sync.RWMutex
+}
+
+var _ repository.IHistoryRepository = (*InMemoryHistoryRepository)(nil)
+
+func NewInMemoryHistoryRepository() repository.IHistoryRepository {
+	return &InMemoryHistoryRepository{}
+}
+
+func (r *InMemoryHistoryRepository) Append(entry *entity.HistoryEntry) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *InMemoryHistoryRepository) GetByTodoID(todoID string) ([]*entity.HistoryEntry, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	entries := make([]*entity.HistoryEntry, 0)
+	for _, entry := range r.entries {
+		if entry.TodoID == todoID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"sync"
+)
+
+type InMemoryJournalRepository struct {
+	journal entity.Journal
+	mutex   sync.RWMutex
+}
+
+var _ repository.IJournalRepository = (*InMemoryJournalRepository)(nil)
+
+func NewInMemoryJournalRepository() repository.IJournalRepository {
+	return &InMemoryJournalRepository{}
+}
+
+func (r *InMemoryJournalRepository) Load() (*entity.Journal, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return &entity.Journal{
+		Undo: append([]*entity.Operation(nil), r.journal.Undo...),
+		Redo: append([]*entity.Operation(nil), r.journal.Redo...),
+	}, nil
+}
+
+func (r *InMemoryJournalRepository) Save(journal *entity.Journal) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.journal = entity.Journal{
+		Undo: append([]*entity.Operation(nil), journal.Undo...),
+		Redo: append([]*entity.Operation(nil), journal.Redo...),
+	}
+	return nil
+}
@@ -3,16 +3,22 @@ package repository
 import (
 	"codecademy-yellowbelt2/core/domain/entity"
 	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"context"
 	"errors"
 	"sync"
 )
 
+// InMemoryTodoRepository guarda cópias das tarefas: nada do que o chamador
+// recebe ou entrega compartilha memória com o estado armazenado
 type InMemoryTodoRepository struct {
-	todos map[string]*entity.Todo
-	mutex sync.RWMutex
+	todos    map[string]*entity.Todo
+	mutex    sync.RWMutex
+	watchers watchers
 }
 
 var _ repository.ITodoRepository = (*InMemoryTodoRepository)(nil)
+var _ repository.IUnitOfWork = (*InMemoryTodoRepository)(nil)
+var _ repository.IWatchable = (*InMemoryTodoRepository)(nil)
 
 func NewInMemoryTodoRepository() repository.ITodoRepository {
 	return &InMemoryTodoRepository{
@@ -25,7 +31,9 @@ func (r *InMemoryTodoRepository) Create(todo *entity.Todo) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	r.todos[todo.ID] = todo
+	todo.Version = 1
+	r.todos[todo.ID] = todo.Clone()
+	r.watchers.notify(changeEvent(todo.ID, nil, todo))
 	return nil
 }
 
@@ -34,19 +42,25 @@ func (r *InMemoryTodoRepository) GetByID(id string) (*entity.Todo, error) {
 	defer r.mutex.RUnlock()
 
 	todo, exists := r.todos[id]
-	if !exists {
+	if !exists || todo.IsDeleted() {
 		return nil, errors.New("todo not found")
 	}
-	return todo, nil
+	return todo.Clone(), nil
 }
 
 func (r *InMemoryTodoRepository) GetAll() ([]*entity.Todo, error) {
+	return r.Find(repository.TodoQuery{})
+}
+
+func (r *InMemoryTodoRepository) Find(query repository.TodoQuery) ([]*entity.Todo, error) {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
 	todos := make([]*entity.Todo, 0, len(r.todos))
 	for _, todo := range r.todos {
-		todos = append(todos, todo)
+		if query.Matches(todo) {
+			todos = append(todos, todo.Clone())
+		}
 	}
 	return todos, nil
 }
@@ -55,11 +69,16 @@ func (r *InMemoryTodoRepository) Update(todo *entity.Todo) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	if _, exists := r.todos[todo.ID]; !exists {
+	stored, exists := r.todos[todo.ID]
+	if !exists {
 		return errors.New("todo not found")
 	}
+	if err := nextVersion(stored, todo); err != nil {
+		return err
+	}
 
-	r.todos[todo.ID] = todo
+	r.todos[todo.ID] = todo.Clone()
+	r.watchers.notify(changeEvent(todo.ID, stored, todo))
 	return nil
 }
 
@@ -67,10 +86,31 @@ func (r *InMemoryTodoRepository) Delete(id string) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	if _, exists := r.todos[id]; !exists {
+	stored, exists := r.todos[id]
+	if !exists {
 		return errors.New("todo not found")
 	}
 
 	delete(r.todos, id)
+	r.watchers.notify(changeEvent(id, stored, nil))
 	return nil
 }
+
+// Begin bloqueia o repositório e trabalha sobre uma cópia do mapa, que só
+// substitui o original no Commit (copy-on-write)
+func (r *InMemoryTodoRepository) Begin() (repository.ITodoTransaction, error) {
+	r.mutex.Lock()
+
+	commit := func(todos map[string]*entity.Todo) error {
+		changes := diffChanges(r.todos, todos)
+		r.todos = todos
+		r.watchers.notify(changes...)
+		return nil
+	}
+	return newTodoTransaction(r.todos, commit, r.mutex.Unlock), nil
+}
+
+// Watch avisa sobre as alterações feitas a partir de agora neste repositório
+func (r *InMemoryTodoRepository) Watch(ctx context.Context) (<-chan *entity.Event, error) {
+	return r.watchers.add(ctx), nil
+}
@@ -2,6 +2,9 @@ package repository
 
 import (
 	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -97,3 +100,156 @@ func TestShouldReturnErrorWhenDeletingNonExistentTodo(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "todo not found", err.Error())
 }
+
+func TestShouldHideDeletedTodosForInMemory(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryTodoRepository()
+	alive := entity.NewTodo("Alive", "")
+	deleted := entity.NewTodo("Deleted", "")
+	deleted.MarkAsDeleted()
+	repo.Create(alive)
+	repo.Create(deleted)
+
+	// Act
+	all, allErr := repo.GetAll()
+	_, getErr := repo.GetByID(deleted.ID)
+	onlyDeleted, onlyErr := repo.Find(repository.TodoQuery{OnlyDeleted: true})
+
+	// Assert
+	assert.NoError(t, allErr)
+	assert.Len(t, all, 1)
+	assert.Error(t, getErr)
+	assert.NoError(t, onlyErr)
+	assert.Len(t, onlyDeleted, 1)
+	assert.Equal(t, deleted.ID, onlyDeleted[0].ID)
+}
+
+func TestShouldNotLeakMutationsOfReturnedTodosForInMemory(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryTodoRepository()
+	todo := entity.NewTodo("Stored", "")
+	repo.Create(todo)
+
+	// Act
+	todo.Title = "Changed after create"
+	all, _ := repo.GetAll()
+	all[0].Title = "Changed after read"
+	all[0].Assign("mallory")
+	retrieved, _ := repo.GetByID(todo.ID)
+
+	// Assert
+	assert.Equal(t, "Stored", retrieved.Title)
+	assert.Empty(t, retrieved.Assignees)
+}
+
+func TestShouldRejectStaleUpdateForInMemory(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryTodoRepository()
+	repo.Create(entity.NewTodo("Stored", ""))
+	all, _ := repo.GetAll()
+	first, _ := repo.GetByID(all[0].ID)
+	second, _ := repo.GetByID(all[0].ID)
+	first.Update("First", "")
+	repo.Update(first)
+
+	// Act
+	second.Update("Second", "")
+	err := repo.Update(second)
+
+	// Assert
+	assert.ErrorIs(t, err, entity.ErrVersionConflict)
+}
+
+// Rode com -race (make test-race) para detectar acessos concorrentes sem bloqueio
+func TestShouldHandleConcurrentReadsAndWritesForInMemory(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryTodoRepository()
+	const workers = 8
+	const rounds = 50
+	var wg sync.WaitGroup
+
+	// Act
+	for w := 0; w < workers; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				todo := entity.NewTodo(fmt.Sprintf("worker %d todo %d", w, i), "")
+				if err := repo.Create(todo); err != nil {
+					t.Error(err)
+					return
+				}
+				todo.Update("updated", "")
+				todo.Assign("alice")
+				if err := repo.Update(todo); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				todos, err := repo.GetAll()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				for _, todo := range todos {
+					todo.Title = "mutated by reader"
+					todo.Assignees = append(todo.Assignees, "reader")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	todos, err := repo.GetAll()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, todos, workers*rounds)
+	for _, todo := range todos {
+		assert.Equal(t, "updated", todo.Title)
+		assert.Equal(t, []string{"alice"}, todo.Assignees)
+		assert.Equal(t, 2, todo.Version)
+	}
+}
+
+func TestShouldHandleConcurrentTransactionsForInMemory(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryTodoRepository()
+	todo := entity.NewTodo("Counter", "")
+	repo.Create(todo)
+	uow := repo.(repository.IUnitOfWork)
+	const workers = 20
+	var wg sync.WaitGroup
+
+	// Act
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tx, err := uow.Begin()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			current, _ := tx.GetByID(todo.ID)
+			current.Update("incremented", "")
+			if err := tx.Update(current); err != nil {
+				tx.Rollback()
+				t.Error(err)
+				return
+			}
+			if err := tx.Commit(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	final, err := repo.GetByID(todo.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, workers+1, final.Version)
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"sync"
+)
+
+type InMemoryWebhookQueueRepository struct {
+	deliveries []entity.WebhookDelivery
+	mutex      sync.RWMutex
+}
+
+var _ repository.IWebhookQueueRepository = (*InMemoryWebhookQueueRepository)(nil)
+
+func NewInMemoryWebhookQueueRepository() repository.IWebhookQueueRepository {
+	return &InMemoryWebhookQueueRepository{}
+}
+
+func (r *InMemoryWebhookQueueRepository) Load() ([]*entity.WebhookDelivery, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	deliveries := make([]*entity.WebhookDelivery, 0, len(r.deliveries))
+	for _, delivery := range r.deliveries {
+		copied := delivery
+		deliveries = append(deliveries, &copied)
+	}
+	return deliveries, nil
+}
+
+func (r *InMemoryWebhookQueueRepository) Save(deliveries []*entity.WebhookDelivery) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.deliveries = make([]entity.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		r.deliveries = append(r.deliveries, *delivery)
+	}
+	return nil
+}
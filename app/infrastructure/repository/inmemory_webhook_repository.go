@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"errors"
+	"sync"
+)
+
+type InMemoryWebhookRepository struct {
+	webhooks map[string]*entity.Webhook
+	mutex    sync.RWMutex
+}
+
+var _ repository.IWebhookRepository = (*InMemoryWebhookRepository)(nil)
+
+func NewInMemoryWebhookRepository() repository.IWebhookRepository {
+	return &InMemoryWebhookRepository{
+		webhooks: make(map[string]*entity.Webhook),
+	}
+}
+
+func (r *InMemoryWebhookRepository) Create(webhook *entity.Webhook) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.webhooks[webhook.ID] = webhook.Clone()
+	return nil
+}
+
+func (r *InMemoryWebhookRepository) GetByID(id string) (*entity.Webhook, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	webhook, exists := r.webhooks[id]
+	if !exists {
+		return nil, errors.New("webhook not found")
+	}
+	return webhook.Clone(), nil
+}
+
+func (r *InMemoryWebhookRepository) GetAll() ([]*entity.Webhook, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	copies := make(map[string]*entity.Webhook, len(r.webhooks))
+	for id, webhook := range r.webhooks {
+		copies[id] = webhook.Clone()
+	}
+	return sortedWebhooks(copies), nil
+}
+
+func (r *InMemoryWebhookRepository) Delete(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.webhooks[id]; !exists {
+		return errors.New("webhook not found")
+	}
+
+	delete(r.webhooks, id)
+	return nil
+}
@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Comportamento que todo ITodoRepository precisa ter, independente de onde
+// as tarefas são guardadas
+func runTodoRepositoryContract(t *testing.T, newRepo func(t *testing.T) repository.ITodoRepository) {
+	t.Run("create and get by id", func(t *testing.T) {
+		// Arrange
+		repo := newRepo(t)
+		todo := entity.NewTodo("Contract", "Description")
+
+		// Act
+		err := repo.Create(todo)
+		got, getErr := repo.GetByID(todo.ID)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NoError(t, getErr)
+		assert.Equal(t, "Contract", got.Title)
+		assert.Equal(t, "Description", got.Description)
+		assert.Equal(t, 1, got.Version)
+	})
+
+	t.Run("get all", func(t *testing.T) {
+		// Arrange
+		repo := newRepo(t)
+		repo.Create(entity.NewTodo("First", ""))
+		repo.Create(entity.NewTodo("Second", ""))
+
+		// Act
+		todos, err := repo.GetAll()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, todos, 2)
+	})
+
+	t.Run("update bumps version", func(t *testing.T) {
+		// Arrange
+		repo := newRepo(t)
+		todo := entity.NewTodo("Old", "")
+		repo.Create(todo)
+		todo.Update("New", "")
+		todo.MarkAsCompleted()
+
+		// Act
+		err := repo.Update(todo)
+		got, _ := repo.GetByID(todo.ID)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "New", got.Title)
+		assert.True(t, got.Completed)
+		assert.Equal(t, 2, got.Version)
+	})
+
+	t.Run("stale update is rejected", func(t *testing.T) {
+		// Arrange
+		repo := newRepo(t)
+		todo := entity.NewTodo("Old", "")
+		repo.Create(todo)
+		stale, _ := repo.GetByID(todo.ID)
+		todo.Update("First", "")
+		repo.Update(todo)
+
+		// Act
+		stale.Update("Second", "")
+		err := repo.Update(stale)
+		got, _ := repo.GetByID(todo.ID)
+
+		// Assert
+		assert.ErrorIs(t, err, entity.ErrVersionConflict)
+		assert.Equal(t, "First", got.Title)
+	})
+
+	t.Run("returned todos are copies", func(t *testing.T) {
+		// Arrange
+		repo := newRepo(t)
+		todo := entity.NewTodo("Stored", "")
+		repo.Create(todo)
+
+		// Act
+		todo.Title = "Changed after create"
+		got, _ := repo.GetByID(todo.ID)
+		got.Title = "Changed after read"
+		again, _ := repo.GetByID(todo.ID)
+
+		// Assert
+		assert.Equal(t, "Stored", again.Title)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		// Arrange
+		repo := newRepo(t)
+		todo := entity.NewTodo("Doomed", "")
+		repo.Create(todo)
+
+		// Act
+		err := repo.Delete(todo.ID)
+		_, getErr := repo.GetByID(todo.ID)
+		all, _ := repo.Find(repository.TodoQuery{IncludeDeleted: true})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.EqualError(t, getErr, "todo not found")
+		assert.Empty(t, all)
+	})
+
+	t.Run("missing todos are not found", func(t *testing.T) {
+		// Arrange
+		repo := newRepo(t)
+
+		// Act
+		_, getErr := repo.GetByID("missing")
+		updateErr := repo.Update(entity.NewTodo("Missing", ""))
+		deleteErr := repo.Delete("missing")
+
+		// Assert
+		assert.EqualError(t, getErr, "todo not found")
+		assert.EqualError(t, updateErr, "todo not found")
+		assert.EqualError(t, deleteErr, "todo not found")
+	})
+
+	t.Run("soft deleted todos are hidden by default", func(t *testing.T) {
+		// Arrange
+		repo := newRepo(t)
+		alive := entity.NewTodo("Alive", "")
+		trashed := entity.NewTodo("Trashed", "")
+		repo.Create(alive)
+		repo.Create(trashed)
+		trashed.MarkAsDeleted()
+		repo.Update(trashed)
+
+		// Act
+		all, _ := repo.GetAll()
+		_, getErr := repo.GetByID(trashed.ID)
+		onlyDeleted, _ := repo.Find(repository.TodoQuery{OnlyDeleted: true})
+		withDeleted, _ := repo.Find(repository.TodoQuery{IncludeDeleted: true})
+
+		// Assert
+		assert.Len(t, all, 1)
+		assert.Error(t, getErr)
+		assert.Len(t, onlyDeleted, 1)
+		assert.Equal(t, trashed.ID, onlyDeleted[0].ID)
+		assert.Len(t, withDeleted, 2)
+	})
+
+	t.Run("transaction commit", func(t *testing.T) {
+		// Arrange
+		repo := newRepo(t)
+		existing := entity.NewTodo("Existing", "")
+		repo.Create(existing)
+		tx, beginErr := repo.(repository.IUnitOfWork).Begin()
+
+		// Act
+		tx.Create(entity.NewTodo("Inside", ""))
+		inside, _ := tx.GetByID(existing.ID)
+		inside.Update("Changed", "")
+		tx.Update(inside)
+		commitErr := tx.Commit()
+		all, _ := repo.GetAll()
+		got, _ := repo.GetByID(existing.ID)
+
+		// Assert
+		assert.NoError(t, beginErr)
+		assert.NoError(t, commitErr)
+		assert.Len(t, all, 2)
+		assert.Equal(t, "Changed", got.Title)
+		assert.Equal(t, 2, got.Version)
+	})
+
+	t.Run("transaction rollback", func(t *testing.T) {
+		// Arrange
+		repo := newRepo(t)
+		existing := entity.NewTodo("Existing", "")
+		repo.Create(existing)
+		tx, _ := repo.(repository.IUnitOfWork).Begin()
+
+		// Act
+		tx.Create(entity.NewTodo("Inside", ""))
+		tx.Delete(existing.ID)
+		rollbackErr := tx.Rollback()
+		all, _ := repo.GetAll()
+
+		// Assert
+		assert.NoError(t, rollbackErr)
+		assert.Len(t, all, 1)
+		assert.Equal(t, existing.ID, all[0].ID)
+	})
+
+	t.Run("watch reports each change", func(t *testing.T) {
+		// Arrange
+		repo := newRepo(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		changes, err := repo.(repository.IWatchable).Watch(ctx)
+		assert.NoError(t, err)
+		todo := entity.NewTodo("Watched", "")
+
+		// Act
+		var types []string
+		repo.Create(todo)
+		types = append(types, nextChange(t, changes).Type)
+		todo.MarkAsCompleted()
+		repo.Update(todo)
+		completed := nextChange(t, changes)
+		types = append(types, completed.Type)
+		todo.MarkAsDeleted()
+		repo.Update(todo)
+		types = append(types, nextChange(t, changes).Type)
+		repo.Delete(todo.ID)
+		types = append(types, nextChange(t, changes).Type)
+		cancel()
+		_, open := <-changes
+
+		// Assert
+		assert.Equal(t, []string{entity.EventTodoCreated, entity.EventTodoCompleted, entity.EventTodoDeleted, entity.EventTodoPurged}, types)
+		assert.Equal(t, todo.ID, completed.TodoID)
+		assert.True(t, completed.Todo.Completed)
+		assert.False(t, completed.Previous.Completed)
+		assert.False(t, open, "Expected channel to close when the context ends")
+	})
+}
+
+func TestFileTodoRepositoryContract(t *testing.T) {
+	runTodoRepositoryContract(t, func(t *testing.T) repository.ITodoRepository {
+		return NewFileTodoRepository(filepath.Join(t.TempDir(), "todos.json"), WithPollInterval(5*time.Millisecond))
+	})
+}
+
+func TestInMemoryTodoRepositoryContract(t *testing.T) {
+	runTodoRepositoryContract(t, func(t *testing.T) repository.ITodoRepository {
+		return NewInMemoryTodoRepository()
+	})
+}
+
+func TestEventSourcedTodoRepositoryContract(t *testing.T) {
+	runTodoRepositoryContract(t, func(t *testing.T) repository.ITodoRepository {
+		return NewEventSourcedTodoRepository(filepath.Join(t.TempDir(), "todos.events.jsonl"), WithSnapshotInterval(3), WithTailInterval(5*time.Millisecond))
+	})
+}
+
+func nextChange(t *testing.T, changes <-chan *entity.Event) *entity.Event {
+	t.Helper()
+	select {
+	case event := <-changes:
+		return event
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for a change")
+		return nil
+	}
+}
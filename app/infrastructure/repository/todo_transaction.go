@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"errors"
+)
+
+var ErrTransactionClosed = errors.New("transaction already committed or rolled back")
+
+// todoTransaction trabalha sobre uma cópia profunda das tarefas; commit decide
+// como publicar a cópia e release libera o bloqueio obtido no Begin
+type todoTransaction struct {
+	todos   map[string]*entity.Todo
+	commit  func(todos map[string]*entity.Todo) error
+	release func()
+	closed  bool
+}
+
+var _ repository.ITodoTransaction = (*todoTransaction)(nil)
+
+func newTodoTransaction(todos map[string]*entity.Todo, commit func(map[string]*entity.Todo) error, release func()) *todoTransaction {
+	working := make(map[string]*entity.Todo, len(todos))
+	for id, todo := range todos {
+		working[id] = todo.Clone()
+	}
+	return &todoTransaction{
+		todos:   working,
+		commit:  commit,
+		release: release,
+	}
+}
+
+func (tx *todoTransaction) Create(todo *entity.Todo) error {
+	if tx.closed {
+		return ErrTransactionClosed
+	}
+
+	todo.Version = 1
+	tx.todos[todo.ID] = todo.Clone()
+	return nil
+}
+
+func (tx *todoTransaction) GetByID(id string) (*entity.Todo, error) {
+	if tx.closed {
+		return nil, ErrTransactionClosed
+	}
+
+	todo, exists := tx.todos[id]
+	if !exists || todo.IsDeleted() {
+		return nil, errors.New("todo not found")
+	}
+	return todo.Clone(), nil
+}
+
+func (tx *todoTransaction) GetAll() ([]*entity.Todo, error) {
+	return tx.Find(repository.TodoQuery{})
+}
+
+func (tx *todoTransaction) Find(query repository.TodoQuery) ([]*entity.Todo, error) {
+	if tx.closed {
+		return nil, ErrTransactionClosed
+	}
+
+	todos := make([]*entity.Todo, 0, len(tx.todos))
+	for _, todo := range tx.todos {
+		if query.Matches(todo) {
+			todos = append(todos, todo.Clone())
+		}
+	}
+	return todos, nil
+}
+
+func (tx *todoTransaction) Update(todo *entity.Todo) error {
+	if tx.closed {
+		return ErrTransactionClosed
+	}
+
+	stored, exists := tx.todos[todo.ID]
+	if !exists {
+		return errors.New("todo not found")
+	}
+	if err := nextVersion(stored, todo); err != nil {
+		return err
+	}
+
+	tx.todos[todo.ID] = todo.Clone()
+	return nil
+}
+
+func (tx *todoTransaction) Delete(id string) error {
+	if tx.closed {
+		return ErrTransactionClosed
+	}
+
+	if _, exists := tx.todos[id]; !exists {
+		return errors.New("todo not found")
+	}
+
+	delete(tx.todos, id)
+	return nil
+}
+
+func (tx *todoTransaction) Commit() error {
+	if tx.closed {
+		return ErrTransactionClosed
+	}
+	tx.closed = true
+	defer tx.release()
+
+	return tx.commit(tx.todos)
+}
+
+func (tx *todoTransaction) Rollback() error {
+	if tx.closed {
+		return ErrTransactionClosed
+	}
+	tx.closed = true
+	tx.release()
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"errors"
+	"os"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldCommitFileTransaction(t *testing.T) {
+	// Arrange
+	repo, cleanup := createTempRepo(t)
+	defer cleanup()
+	repo.Create(&entity.Todo{ID: "1", Title: "Old"})
+
+	// Act
+	tx, beginErr := repo.Begin()
+	tx.Create(&entity.Todo{ID: "2", Title: "New"})
+	tx.Update(&entity.Todo{ID: "1", Title: "Updated", Version: 1})
+	commitErr := tx.Commit()
+	todos, _ := repo.GetAll()
+	updated, _ := repo.GetByID("1")
+
+	// Assert
+	assert.NoError(t, beginErr)
+	assert.NoError(t, commitErr)
+	assert.Len(t, todos, 2)
+	assert.Equal(t, "Updated", updated.Title)
+}
+
+func TestShouldSaveFileTransactionOnlyOnceAtCommit(t *testing.T) {
+	// Arrange
+	repo, cleanup := createTempRepo(t)
+	defer cleanup()
+	writes := 0
+	patch := monkey.Patch(os.WriteFile, func(string, []byte, os.FileMode) error {
+		writes++
+		return nil
+	})
+	defer patch.Unpatch()
+
+	// Act
+	tx, _ := repo.Begin()
+	tx.Create(&entity.Todo{ID: "1", Title: "A"})
+	tx.Create(&entity.Todo{ID: "2", Title: "B"})
+	tx.Delete("1")
+	writesBeforeCommit := writes
+	commitErr := tx.Commit()
+
+	// Assert
+	assert.NoError(t, commitErr)
+	assert.Equal(t, 0, writesBeforeCommit)
+	assert.Equal(t, 1, writes)
+}
+
+func TestShouldDiscardFileTransactionOnRollback(t *testing.T) {
+	// Arrange
+	repo, cleanup := createTempRepo(t)
+	defer cleanup()
+	repo.Create(&entity.Todo{ID: "1", Title: "Keep"})
+
+	// Act
+	tx, _ := repo.Begin()
+	tx.Delete("1")
+	tx.Create(&entity.Todo{ID: "2", Title: "Discard"})
+	rollbackErr := tx.Rollback()
+	todos, _ := repo.GetAll()
+
+	// Assert
+	assert.NoError(t, rollbackErr)
+	assert.Len(t, todos, 1)
+	assert.Equal(t, "Keep", todos[0].Title)
+}
+
+func TestShouldReturnErrorOnBeginWhenLoadFails(t *testing.T) {
+	// Arrange
+	repo, cleanup := createTempRepo(t)
+	defer cleanup()
+	patch := monkey.Patch(os.ReadFile, func(string) ([]byte, error) {
+		return nil, errors.New("read error")
+	})
+	defer patch.Unpatch()
+
+	// Act
+	tx, err := repo.Begin()
+	patch.Unpatch()
+	_, getErr := repo.GetAll()
+
+	// Assert
+	assert.Nil(t, tx)
+	assert.EqualError(t, err, "read error")
+	assert.NoError(t, getErr, "Expected lock to be released after a failed Begin")
+}
+
+func TestShouldIsolateInMemoryTransactionUntilCommit(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryTodoRepository().(*InMemoryTodoRepository)
+	repo.Create(&entity.Todo{ID: "1", Title: "Old"})
+
+	// Act
+	tx, _ := repo.Begin()
+	todo, _ := tx.GetByID("1")
+	todo.Title = "Changed in transaction"
+	tx.Update(todo)
+	tx.Rollback()
+	afterRollback, _ := repo.GetByID("1")
+	afterRollbackTitle := afterRollback.Title
+
+	tx, _ = repo.Begin()
+	todo, _ = tx.GetByID("1")
+	todo.Title = "Committed"
+	tx.Update(todo)
+	commitErr := tx.Commit()
+	afterCommit, _ := repo.GetByID("1")
+
+	// Assert
+	assert.Equal(t, "Old", afterRollbackTitle, "Expected in-place changes to be discarded on rollback")
+	assert.NoError(t, commitErr)
+	assert.Equal(t, "Committed", afterCommit.Title)
+}
+
+func TestShouldRejectOperationsOnClosedTransaction(t *testing.T) {
+	// Arrange
+	repo := NewInMemoryTodoRepository().(repository.IUnitOfWork)
+	tx, _ := repo.Begin()
+	tx.Commit()
+
+	// Act
+	createErr := tx.Create(&entity.Todo{ID: "1"})
+	_, getErr := tx.GetAll()
+	commitErr := tx.Commit()
+	rollbackErr := tx.Rollback()
+
+	// Assert
+	assert.ErrorIs(t, createErr, ErrTransactionClosed)
+	assert.ErrorIs(t, getErr, ErrTransactionClosed)
+	assert.ErrorIs(t, commitErr, ErrTransactionClosed)
+	assert.ErrorIs(t, rollbackErr, ErrTransactionClosed)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "codecademy-yellowbelt2/core/domain/entity"
+
+// nextVersion confere se a tarefa recebida partiu da versão armazenada e
+// avança o contador; tarefas gravadas antes do controle de versão estão na 0
+func nextVersion(stored, todo *entity.Todo) error {
+	if err := todo.CheckVersion(stored.Version); err != nil {
+		return err
+	}
+	todo.Version = stored.Version + 1
+	return nil
+}
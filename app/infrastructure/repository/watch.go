@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"context"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPollInterval é de quanto em quanto tempo os repositórios em
+	// arquivo verificam se ele mudou
+	DefaultPollInterval = 500 * time.Millisecond
+
+	// DefaultWatchBuffer é quantas alterações um observador acumula antes
+	// de as próximas serem descartadas
+	DefaultWatchBuffer = 100
+)
+
+// watchers distribui as alterações feitas no próprio processo aos canais
+// abertos por Watch, sem nunca bloquear quem grava
+type watchers struct {
+	mutex    sync.Mutex
+	channels map[chan *entity.Event]struct{}
+}
+
+func (w *watchers) add(ctx context.Context) <-chan *entity.Event {
+	changes := make(chan *entity.Event, DefaultWatchBuffer)
+
+	w.mutex.Lock()
+	if w.channels == nil {
+		w.channels = make(map[chan *entity.Event]struct{})
+	}
+	w.channels[changes] = struct{}{}
+	w.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		w.mutex.Lock()
+		delete(w.channels, changes)
+		close(changes)
+		w.mutex.Unlock()
+	}()
+	return changes
+}
+
+func (w *watchers) notify(events ...*entity.Event) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for changes := range w.channels {
+		for _, event := range events {
+			select {
+			case changes <- event.Clone():
+			default: // Observador lento: a alteração é descartada para ele
+			}
+		}
+	}
+}
+
+// changeEvent descreve a passagem de before para after, guardando o estado
+// anterior para quem observa
+func changeEvent(id string, before, after *entity.Todo) *entity.Event {
+	event := entity.NewEvent(watchEventType(entity.EventTypeFor(before, after), before, after), id, after)
+	event.Previous = before.Clone()
+	return event
+}
+
+func diffChanges(before, after map[string]*entity.Todo) []*entity.Event {
+	events := diffEvents(before, after)
+	for _, event := range events {
+		previous := before[event.TodoID]
+		event.Type = watchEventType(event.Type, previous, event.Todo)
+		event.Previous = previous.Clone()
+	}
+	return events
+}
+
+// watchEventType distingue a ida para a lixeira, a restauração e a exclusão
+// definitiva, que no armazenamento são apenas atualizações e remoções
+func watchEventType(eventType string, before, after *entity.Todo) string {
+	switch {
+	case before == nil:
+		return eventType
+	case after == nil && before.IsDeleted():
+		return entity.EventTodoPurged
+	case after == nil || eventType != entity.EventTodoUpdated:
+		return eventType
+	case !before.IsDeleted() && after.IsDeleted():
+		return entity.EventTodoDeleted
+	case before.IsDeleted() && !after.IsDeleted():
+		return entity.EventTodoRestored
+	}
+	return eventType
+}
+
+// fileStamp identifica uma versão do arquivo sem precisar lê-lo
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func stampOf(filename string) (fileStamp, error) {
+	info, err := os.Stat(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fileStamp{}, nil
+		}
+		return fileStamp{}, err
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+func (s fileStamp) equal(other fileStamp) bool {
+	return s.size == other.size && s.modTime.Equal(other.modTime)
+}
+
+// poll chama check a cada interval até ctx terminar ou check retornar false
+func poll(ctx context.Context, interval time.Duration, check func() bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !check() {
+				return
+			}
+		}
+	}
+}
+
+// send entrega o evento ao observador e retorna false se ele desistiu
+func send(ctx context.Context, changes chan<- *entity.Event, event *entity.Event) bool {
+	select {
+	case changes <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
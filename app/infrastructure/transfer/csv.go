@@ -0,0 +1,331 @@
+package transfer
+
+import (
+	"bytes"
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/transfer"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvColumn liga um campo da tarefa à coluna do CSV; Field é o nome usado no
+// cabeçalho exportado e em --map, e Alias é o nome do campo no JSON
+type csvColumn struct {
+	Field string
+	Alias string
+	get   func(todo *entity.Todo) string
+	set   func(todo *entity.Todo, value string) error
+}
+
+var csvColumns = []csvColumn{
+	{"ID", "id",
+		func(todo *entity.Todo) string { return todo.ID },
+		func(todo *entity.Todo, value string) error { todo.ID = value; return nil }},
+	{"Title", "title",
+		func(todo *entity.Todo) string { return todo.Title },
+		func(todo *entity.Todo, value string) error { todo.Title = value; return nil }},
+	{"Description", "description",
+		func(todo *entity.Todo) string { return todo.Description },
+		func(todo *entity.Todo, value string) error { todo.Description = value; return nil }},
+	{"Completed", "completed",
+		func(todo *entity.Todo) string { return strconv.FormatBool(todo.Completed) },
+		func(todo *entity.Todo, value string) (err error) {
+			todo.Completed, err = parseCSVBool(value)
+			return err
+		}},
+	{"Priority", "priority",
+		func(todo *entity.Todo) string { return todo.Priority },
+		func(todo *entity.Todo, value string) error {
+			todo.Priority = strings.ToUpper(value)
+			if !entity.IsValidPriority(todo.Priority) {
+				return fmt.Errorf("invalid priority %q (expected a letter from A to Z)", value)
+			}
+			return nil
+		}},
+	{"Projects", "projects",
+		func(todo *entity.Todo) string { return strings.Join(todo.Projects, ", ") },
+		func(todo *entity.Todo, value string) error { todo.Projects = splitCSVList(value); return nil }},
+	{"Tags", "tags",
+		func(todo *entity.Todo) string { return strings.Join(todo.Tags, ", ") },
+		func(todo *entity.Todo, value string) error { todo.Tags = splitCSVList(value); return nil }},
+	{"Assignees", "assignees",
+		func(todo *entity.Todo) string { return strings.Join(todo.Assignees, ", ") },
+		func(todo *entity.Todo, value string) error { todo.Assignees = splitCSVList(value); return nil }},
+	{"DueDate", "due_date",
+		func(todo *entity.Todo) string { return formatCSVDate(todo.DueDate, todoTxtDate) },
+		func(todo *entity.Todo, value string) (err error) { todo.DueDate, err = parseCSVDate(value); return err }},
+	{"CreatedAt", "created_at",
+		func(todo *entity.Todo) string { return formatCSVDate(&todo.CreatedAt, time.RFC3339) },
+		func(todo *entity.Todo, value string) error {
+			date, err := parseCSVDate(value)
+			if date != nil {
+				todo.CreatedAt = *date
+			}
+			return err
+		}},
+	{"CompletedAt", "completed_at",
+		func(todo *entity.Todo) string { return formatCSVDate(todo.CompletedAt, time.RFC3339) },
+		func(todo *entity.Todo, value string) (err error) {
+			todo.CompletedAt, err = parseCSVDate(value)
+			return err
+		}},
+}
+
+// csvDateLayouts são os formatos de data aceitos na importação, do mais
+// completo ao mais simples
+var csvDateLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", todoTxtDate, "02/01/2006 15:04", "02/01/2006"}
+
+// CSVFormat lê e escreve planilhas em CSV com uma tarefa por linha e um
+// cabeçalho com os nomes das colunas, que podem ser trocados por Options.Columns
+type CSVFormat struct {
+	options transfer.Options
+}
+
+var (
+	_ transfer.ITodoFormat         = (*CSVFormat)(nil)
+	_ transfer.IConfigurableFormat = (*CSVFormat)(nil)
+)
+
+func NewCSVFormat() transfer.ITodoFormat {
+	return &CSVFormat{options: transfer.Options{Delimiter: ',', Encoding: transfer.EncodingUTF8}}
+}
+
+// WithOptions valida o mapeamento (campo -> coluna), o delimitador e a codificação
+func (f *CSVFormat) WithOptions(options transfer.Options) (transfer.ITodoFormat, error) {
+	configured := transfer.Options{Delimiter: options.Delimiter, Columns: make(map[string]string)}
+	for field, column := range options.Columns {
+		known := lookupCSVColumn(field)
+		if known == nil {
+			return nil, fmt.Errorf("unknown field %q in column mapping (expected %s)", field, strings.Join(csvFieldNames(), ", "))
+		}
+		if strings.TrimSpace(column) == "" {
+			return nil, fmt.Errorf("empty column name for field %s", known.Field)
+		}
+		configured.Columns[known.Field] = strings.TrimSpace(column)
+	}
+
+	if configured.Delimiter == 0 {
+		configured.Delimiter = ','
+	}
+	if configured.Delimiter == '"' || configured.Delimiter == '\r' || configured.Delimiter == '\n' {
+		return nil, fmt.Errorf("invalid delimiter %q", configured.Delimiter)
+	}
+
+	encoding, err := NormalizeEncoding(options.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	configured.Encoding = encoding
+	return &CSVFormat{options: configured}, nil
+}
+
+func (f *CSVFormat) Decode(r io.Reader) (*transfer.Decoded, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text, err := decodeText(data, f.options.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = f.options.Delimiter
+	reader.FieldsPerRecord = -1
+
+	decoded := &transfer.Decoded{}
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return decoded, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	indexes, err := f.columnIndexes(header, decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			decoded.Skipped = append(decoded.Skipped, transfer.Issue{Line: parseErr.StartLine, Text: "-", Reason: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		todo, err := parseCSVRecord(record, indexes)
+		if err != nil {
+			decoded.Skipped = append(decoded.Skipped, transfer.Issue{Line: line, Text: strings.Join(record, string(f.options.Delimiter)), Reason: err.Error()})
+			continue
+		}
+		decoded.Todos = append(decoded.Todos, todo)
+		decoded.Lines = append(decoded.Lines, line)
+	}
+	return decoded, nil
+}
+
+// columnIndexes localiza no cabeçalho a coluna de cada campo e aponta as
+// colunas que não correspondem a nenhum campo
+func (f *CSVFormat) columnIndexes(header []string, decoded *transfer.Decoded) (map[string]int, error) {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		positions[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	indexes := make(map[string]int)
+	used := make(map[int]bool)
+	for _, column := range csvColumns {
+		names := []string{column.Field, column.Alias}
+		if mapped, ok := f.options.Columns[column.Field]; ok {
+			names = []string{mapped}
+		}
+		for _, name := range names {
+			if i, ok := positions[strings.ToLower(name)]; ok {
+				indexes[column.Field] = i
+				used[i] = true
+				break
+			}
+		}
+		if _, found := indexes[column.Field]; !found {
+			if mapped, ok := f.options.Columns[column.Field]; ok {
+				return nil, fmt.Errorf("column %q mapped to %s not found in the header", mapped, column.Field)
+			}
+		}
+	}
+	if _, ok := indexes["Title"]; !ok {
+		return nil, errors.New("missing Title column (use --map Title=<column>)")
+	}
+
+	for i, name := range header {
+		if !used[i] && strings.TrimSpace(name) != "" {
+			decoded.Unmapped = append(decoded.Unmapped, transfer.Issue{Line: 1, Text: name, Reason: "column not mapped to any field"})
+		}
+	}
+	return indexes, nil
+}
+
+func parseCSVRecord(record []string, indexes map[string]int) (*entity.Todo, error) {
+	todo := &entity.Todo{}
+	for _, column := range csvColumns {
+		i, ok := indexes[column.Field]
+		if !ok || i >= len(record) {
+			continue
+		}
+		if err := column.set(todo, strings.TrimSpace(record[i])); err != nil {
+			return nil, fmt.Errorf("%s: %w", column.Field, err)
+		}
+	}
+	if todo.Title == "" {
+		return nil, errors.New("missing title")
+	}
+	if todo.CompletedAt != nil {
+		todo.Completed = true
+	}
+	return todo, nil
+}
+
+func (f *CSVFormat) Encode(w io.Writer, todos []*entity.Todo) error {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	writer.Comma = f.options.Delimiter
+
+	header := make([]string, len(csvColumns))
+	for i, column := range csvColumns {
+		header[i] = column.Field
+		if mapped, ok := f.options.Columns[column.Field]; ok {
+			header[i] = mapped
+		}
+	}
+	writer.Write(header)
+
+	for _, todo := range todos {
+		record := make([]string, len(csvColumns))
+		for i, column := range csvColumns {
+			record[i] = column.get(todo)
+		}
+		writer.Write(record)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+
+	data, err := encodeText(buffer.String(), f.options.Encoding)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func lookupCSVColumn(name string) *csvColumn {
+	for i, column := range csvColumns {
+		if strings.EqualFold(name, column.Field) || strings.EqualFold(name, column.Alias) {
+			return &csvColumns[i]
+		}
+	}
+	return nil
+}
+
+func csvFieldNames() []string {
+	names := make([]string, len(csvColumns))
+	for i, column := range csvColumns {
+		names[i] = column.Field
+	}
+	return names
+}
+
+// parseCSVBool aceita os valores usados em planilhas em inglês e em português
+func parseCSVBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "", "false", "0", "no", "n", "não", "nao":
+		return false, nil
+	case "true", "1", "yes", "y", "sim", "s", "x":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid boolean %q", value)
+	}
+}
+
+func parseCSVDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range csvDateLayouts {
+		if date, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			date = date.In(time.Local)
+			return &date, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid date %q (expected YYYY-MM-DD or DD/MM/YYYY)", value)
+}
+
+func formatCSVDate(value *time.Time, layout string) string {
+	if value == nil || value.IsZero() {
+		return ""
+	}
+	return value.In(time.Local).Format(layout)
+}
+
+func splitCSVList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = appendUnique(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,200 @@
+package transfer
+
+import (
+	"bytes"
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/transfer"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func configuredCSV(t *testing.T, options transfer.Options) transfer.ITodoFormat {
+	format, err := NewCSVFormat().(transfer.IConfigurableFormat).WithOptions(options)
+	if err != nil {
+		t.Fatalf("Expected valid options, got %v", err)
+	}
+	return format
+}
+
+func TestCSVFormat_DecodeMapsColumnsAndDelimiter(t *testing.T) {
+	// Arrange
+	format := configuredCSV(t, transfer.Options{
+		Columns:   map[string]string{"Title": "Tarefa", "description": "Notas", "DueDate": "Prazo"},
+		Delimiter: ';',
+	})
+	input := "Tarefa;Notas;Prazo;Completed;Tags;Responsável\n" +
+		"Pagar aluguel;\"até dia 10; sem falta\";10/03/2024;sim;casa, contas;Ana\n" +
+		"Comprar pão;;;não;;\n"
+
+	// Act
+	decoded, err := format.Decode(strings.NewReader(input))
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, decoded.Todos, 2) {
+		return
+	}
+	assert.Equal(t, []int{2, 3}, decoded.Lines)
+	assert.Empty(t, decoded.Skipped)
+	assert.Equal(t, []transfer.Issue{{Line: 1, Text: "Responsável", Reason: "column not mapped to any field"}}, decoded.Unmapped)
+
+	rent := decoded.Todos[0]
+	assert.Equal(t, "", rent.ID, "Expected the ID to be left for the import")
+	assert.Equal(t, "Pagar aluguel", rent.Title)
+	assert.Equal(t, "até dia 10; sem falta", rent.Description)
+	assert.True(t, rent.Completed)
+	assert.Equal(t, []string{"casa", "contas"}, rent.Tags)
+	assert.True(t, rent.DueDate.Equal(date("2024-03-10")))
+
+	bread := decoded.Todos[1]
+	assert.Equal(t, "Comprar pão", bread.Title)
+	assert.False(t, bread.Completed)
+	assert.Nil(t, bread.DueDate)
+}
+
+func TestCSVFormat_DecodeReportsRowErrors(t *testing.T) {
+	// Arrange
+	input := "title,completed,priority,due_date\n" +
+		"Válida,false,A,2024-03-10\n" +
+		"Booleano,talvez,,\n" +
+		"Prioridade,,AB,\n" +
+		",,,\n" +
+		"Data,,,amanhã\n"
+
+	// Act
+	decoded, err := NewCSVFormat().Decode(strings.NewReader(input))
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, decoded.Todos, 1) {
+		return
+	}
+	assert.Equal(t, "Válida", decoded.Todos[0].Title)
+	if !assert.Len(t, decoded.Skipped, 4) {
+		return
+	}
+	assert.Equal(t, 3, decoded.Skipped[0].Line)
+	assert.Equal(t, `Completed: invalid boolean "talvez"`, decoded.Skipped[0].Reason)
+	assert.Equal(t, 4, decoded.Skipped[1].Line)
+	assert.Contains(t, decoded.Skipped[1].Reason, "invalid priority")
+	assert.Equal(t, 5, decoded.Skipped[2].Line)
+	assert.Equal(t, "missing title", decoded.Skipped[2].Reason)
+	assert.Equal(t, 6, decoded.Skipped[3].Line)
+	assert.Contains(t, decoded.Skipped[3].Reason, "invalid date")
+}
+
+func TestCSVFormat_DecodeRejectsMissingColumns(t *testing.T) {
+	// Arrange
+	mapped := configuredCSV(t, transfer.Options{Columns: map[string]string{"Title": "Tarefa"}})
+
+	// Act
+	_, missingTitle := NewCSVFormat().Decode(strings.NewReader("Nome,Notas\nA,B\n"))
+	_, missingMapped := mapped.Decode(strings.NewReader("Title\nA\n"))
+
+	// Assert
+	assert.EqualError(t, missingTitle, "missing Title column (use --map Title=<column>)")
+	assert.EqualError(t, missingMapped, `column "Tarefa" mapped to Title not found in the header`)
+}
+
+func TestCSVFormat_WithOptionsValidates(t *testing.T) {
+	// Arrange
+	format := NewCSVFormat().(transfer.IConfigurableFormat)
+
+	// Act
+	_, unknownField := format.WithOptions(transfer.Options{Columns: map[string]string{"Prazo": "Data"}})
+	_, badDelimiter := format.WithOptions(transfer.Options{Delimiter: '"'})
+	_, badEncoding := format.WithOptions(transfer.Options{Encoding: "ebcdic"})
+	_, aliasEncoding := format.WithOptions(transfer.Options{Encoding: "CP1252"})
+
+	// Assert
+	assert.ErrorContains(t, unknownField, `unknown field "Prazo" in column mapping`)
+	assert.ErrorContains(t, badDelimiter, "invalid delimiter")
+	assert.ErrorContains(t, badEncoding, `unknown encoding "ebcdic"`)
+	assert.NoError(t, aliasEncoding)
+}
+
+func TestCSVFormat_DecodeEncodings(t *testing.T) {
+	// Arrange
+	windows := []byte("Title;Notes\nCaf\xe9 \x96 manh\xe3;\x93aspas\x94\n")
+	utf16 := []byte{0xFF, 0xFE}
+	for _, r := range "Title\nAção\n" {
+		utf16 = append(utf16, byte(r), byte(r>>8))
+	}
+
+	// Act
+	fromWindows, windowsErr := configuredCSV(t, transfer.Options{Delimiter: ';', Encoding: "windows-1252"}).Decode(bytes.NewReader(windows))
+	fromUTF16, utf16Err := configuredCSV(t, transfer.Options{Encoding: "utf-16"}).Decode(bytes.NewReader(utf16))
+	_, invalidUTF8 := NewCSVFormat().Decode(bytes.NewReader(windows))
+
+	// Assert
+	if !assert.NoError(t, windowsErr) || !assert.NoError(t, utf16Err) {
+		return
+	}
+	assert.Equal(t, "Café – manhã", fromWindows.Todos[0].Title)
+	assert.Equal(t, []transfer.Issue{{Line: 1, Text: "Notes", Reason: "column not mapped to any field"}}, fromWindows.Unmapped)
+	assert.Equal(t, "Ação", fromUTF16.Todos[0].Title)
+	assert.ErrorContains(t, invalidUTF8, "invalid UTF-8 text")
+}
+
+func TestCSVFormat_RoundTripTodos(t *testing.T) {
+	// Arrange
+	due := date("2024-03-10")
+	completedAt := date("2024-03-05")
+	todos := []*entity.Todo{
+		{ID: "a1", Title: "Pagar aluguel, luz", Description: "linha 1\nlinha 2", Completed: true, Priority: "A",
+			Projects: []string{"casa"}, Tags: []string{"contas", "mensal"}, Assignees: []string{"ana"},
+			DueDate: &due, CreatedAt: date("2024-03-01"), CompletedAt: &completedAt},
+		{ID: "b2", Title: "Comprar pão", CreatedAt: date("2024-03-02")},
+	}
+	format := configuredCSV(t, transfer.Options{
+		Columns:  map[string]string{"Title": "Tarefa"},
+		Encoding: "windows-1252",
+	})
+
+	// Act
+	var out bytes.Buffer
+	encodeErr := format.Encode(&out, todos)
+	decoded, decodeErr := format.Decode(&out)
+
+	// Assert
+	if !assert.NoError(t, encodeErr) || !assert.NoError(t, decodeErr) {
+		return
+	}
+	assert.Empty(t, decoded.Skipped)
+	assert.Empty(t, decoded.Unmapped)
+	if !assert.Len(t, decoded.Todos, 2) {
+		return
+	}
+	for i, want := range todos {
+		got := decoded.Todos[i]
+		assert.Equal(t, want.ID, got.ID)
+		assert.Equal(t, want.Title, got.Title)
+		assert.Equal(t, want.Description, got.Description)
+		assert.Equal(t, want.Completed, got.Completed)
+		assert.Equal(t, want.Priority, got.Priority)
+		assert.Equal(t, want.Projects, got.Projects)
+		assert.Equal(t, want.Tags, got.Tags)
+		assert.Equal(t, want.Assignees, got.Assignees)
+		assert.Equal(t, want.DueDate, got.DueDate)
+		assert.Equal(t, want.CompletedAt, got.CompletedAt)
+		assert.True(t, want.CreatedAt.Equal(got.CreatedAt))
+	}
+}
+
+func TestCSVFormat_EncodeRejectsUnrepresentableCharacters(t *testing.T) {
+	// Arrange
+	format := configuredCSV(t, transfer.Options{Encoding: "latin1"})
+	todos := []*entity.Todo{{ID: "a1", Title: "Reunião 🚀"}}
+
+	// Act
+	err := format.Encode(&bytes.Buffer{}, todos)
+
+	// Assert
+	assert.ErrorContains(t, err, "cannot be written in latin1")
+}
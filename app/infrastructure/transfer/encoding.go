@@ -0,0 +1,129 @@
+package transfer
+
+import (
+	"bytes"
+	"codecademy-yellowbelt2/infrastructure/interface/transfer"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16LEBOM = []byte{0xFF, 0xFE}
+	utf16BEBOM = []byte{0xFE, 0xFF}
+)
+
+// windows1252 traz os caracteres da faixa 0x80-0x9F, a única em que a
+// codificação difere do latin1; as posições sem caractere ficam como no latin1
+var windows1252 = [32]rune{
+	'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0x8D, 'Ž', 0x8F,
+	0x90, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0x9D, 'ž', 'Ÿ',
+}
+
+// NormalizeEncoding aceita variações comuns dos nomes (UTF8, ISO-8859-1,
+// cp1252...) e devolve o nome canônico
+func NormalizeEncoding(name string) (string, error) {
+	key := strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(name))
+	switch key {
+	case "", "utf8":
+		return transfer.EncodingUTF8, nil
+	case "utf16", "utf16le", "utf16be", "unicode":
+		return transfer.EncodingUTF16, nil
+	case "latin1", "iso88591":
+		return transfer.EncodingLatin1, nil
+	case "windows1252", "cp1252":
+		return transfer.EncodingWindows1252, nil
+	default:
+		return "", fmt.Errorf("unknown encoding %q (expected %s)", name, strings.Join(transfer.Encodings, ", "))
+	}
+}
+
+// decodeText converte o conteúdo para UTF-8, descartando o BOM se houver
+func decodeText(data []byte, encoding string) (string, error) {
+	switch encoding {
+	case transfer.EncodingUTF16:
+		return decodeUTF16(data)
+	case transfer.EncodingLatin1, transfer.EncodingWindows1252:
+		var text strings.Builder
+		for _, b := range data {
+			if encoding == transfer.EncodingWindows1252 && b >= 0x80 && b <= 0x9F {
+				text.WriteRune(windows1252[b-0x80])
+				continue
+			}
+			text.WriteRune(rune(b))
+		}
+		return text.String(), nil
+	default:
+		data = bytes.TrimPrefix(data, utf8BOM)
+		if !utf8.Valid(data) {
+			return "", errors.New("invalid UTF-8 text (try --encoding windows-1252 or latin1)")
+		}
+		return string(data), nil
+	}
+}
+
+func decodeUTF16(data []byte) (string, error) {
+	bigEndian := bytes.HasPrefix(data, utf16BEBOM)
+	if bigEndian || bytes.HasPrefix(data, utf16LEBOM) {
+		data = data[2:]
+	}
+	if len(data)%2 != 0 {
+		return "", errors.New("invalid UTF-16 text: odd number of bytes")
+	}
+
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		if bigEndian {
+			units[i] = uint16(data[2*i])<<8 | uint16(data[2*i+1])
+		} else {
+			units[i] = uint16(data[2*i+1])<<8 | uint16(data[2*i])
+		}
+	}
+	return string(utf16.Decode(units)), nil
+}
+
+// encodeText converte o texto UTF-8 para a codificação pedida; o utf-16 sai
+// little-endian e com BOM, como o Excel espera
+func encodeText(text, encoding string) ([]byte, error) {
+	switch encoding {
+	case transfer.EncodingUTF16:
+		units := utf16.Encode([]rune(text))
+		data := append([]byte{}, utf16LEBOM...)
+		for _, unit := range units {
+			data = append(data, byte(unit), byte(unit>>8))
+		}
+		return data, nil
+	case transfer.EncodingLatin1, transfer.EncodingWindows1252:
+		data := make([]byte, 0, len(text))
+		for _, r := range text {
+			b, ok := encodeSingleByte(r, encoding)
+			if !ok {
+				return nil, fmt.Errorf("character %q cannot be written in %s (try --encoding utf-8)", r, encoding)
+			}
+			data = append(data, b)
+		}
+		return data, nil
+	default:
+		return []byte(text), nil
+	}
+}
+
+func encodeSingleByte(r rune, encoding string) (byte, bool) {
+	if encoding == transfer.EncodingWindows1252 {
+		for i, special := range windows1252 {
+			if special == r {
+				return byte(0x80 + i), true
+			}
+		}
+		if r >= 0x80 && r <= 0x9F {
+			return 0, false
+		}
+	}
+	if r > 0xFF {
+		return 0, false
+	}
+	return byte(r), true
+}
@@ -0,0 +1,346 @@
+package transfer
+
+import (
+	"bufio"
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/transfer"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	icsDateTime   = "20060102T150405Z"
+	icsLocalTime  = "20060102T150405"
+	icsDate       = "20060102"
+	icsLineLimit  = 75 // octetos por linha antes da dobra (RFC 5545, 3.1)
+	icsProductID  = "-//codecademy-yellowbelt2//todo//PT"
+	icsStatusDone = "COMPLETED"
+	icsStatusToDo = "NEEDS-ACTION"
+	icsVTodo      = "VTODO"
+	icsVCalendar  = "VCALENDAR"
+)
+
+// icsIgnored são as propriedades conhecidas que não têm campo na tarefa e
+// podem ser descartadas sem aviso
+var icsIgnored = map[string]bool{"DTSTAMP": true, "SEQUENCE": true}
+
+// ICSFormat lê e escreve componentes VTODO do iCalendar (RFC 5545), o formato
+// das tarefas em aplicativos de calendário. O UID guarda o ID da tarefa, para
+// que reimportar o arquivo atualize as tarefas em vez de duplicá-las
+type ICSFormat struct{}
+
+var _ transfer.ITodoFormat = (*ICSFormat)(nil)
+
+func NewICSFormat() transfer.ITodoFormat {
+	return &ICSFormat{}
+}
+
+// icsLine é uma linha de conteúdo já desdobrada, com a linha física em que começa
+type icsLine struct {
+	number int
+	name   string
+	params map[string]string
+	value  string
+}
+
+func (l icsLine) String() string {
+	return l.name + ":" + l.value
+}
+
+func (f *ICSFormat) Decode(r io.Reader) (*transfer.Decoded, error) {
+	lines, err := readICSLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := &transfer.Decoded{}
+	var (
+		todo  *entity.Todo
+		begin int
+		depth int    // componentes abertos dentro do VTODO atual (como VALARM)
+		other int    // componentes abertos fora de um VTODO (como VEVENT)
+		bad   string // motivo para ignorar o VTODO atual
+	)
+	for _, line := range lines {
+		switch {
+		case todo == nil && line.name == "BEGIN":
+			component := strings.ToUpper(line.value)
+			switch {
+			case other > 0:
+				other++
+			case component == icsVTodo:
+				todo, begin, depth, bad = &entity.Todo{}, line.number, 0, ""
+			case component != icsVCalendar:
+				decoded.Skipped = append(decoded.Skipped, transfer.Issue{Line: line.number, Text: component, Reason: "only VTODO components are imported"})
+				other++
+			}
+		case todo == nil:
+			if line.name == "END" && other > 0 {
+				other--
+			}
+		case line.name == "BEGIN":
+			if depth == 0 {
+				decoded.Unmapped = append(decoded.Unmapped, transfer.Issue{Line: line.number, Text: strings.ToUpper(line.value), Reason: "unsupported component, ignored"})
+			}
+			depth++
+		case line.name == "END" && depth > 0:
+			depth--
+		case line.name == "END":
+			switch {
+			case bad != "":
+				decoded.Skipped = append(decoded.Skipped, transfer.Issue{Line: begin, Text: icsVTodo, Reason: bad})
+			case strings.TrimSpace(todo.Title) == "":
+				decoded.Skipped = append(decoded.Skipped, transfer.Issue{Line: begin, Text: icsVTodo, Reason: "missing SUMMARY"})
+			default:
+				if todo.CompletedAt != nil {
+					todo.Completed = true
+				}
+				decoded.Todos = append(decoded.Todos, todo)
+				decoded.Lines = append(decoded.Lines, begin)
+			}
+			todo = nil
+		case depth > 0:
+			continue
+		default:
+			mapped, err := setICSProperty(todo, line)
+			if err != nil && bad == "" {
+				bad = err.Error()
+			}
+			if !mapped {
+				decoded.Unmapped = append(decoded.Unmapped, transfer.Issue{Line: line.number, Text: line.String(), Reason: "unsupported property, ignored"})
+			}
+		}
+	}
+	if todo != nil {
+		decoded.Skipped = append(decoded.Skipped, transfer.Issue{Line: begin, Text: icsVTodo, Reason: "missing END:VTODO"})
+	}
+	return decoded, nil
+}
+
+// setICSProperty aplica uma propriedade do VTODO à tarefa; false indica que
+// a propriedade não tem campo correspondente
+func setICSProperty(todo *entity.Todo, line icsLine) (bool, error) {
+	switch line.name {
+	case "UID":
+		todo.ID = line.value
+	case "SUMMARY":
+		todo.Title = unescapeICSText(line.value)
+	case "DESCRIPTION":
+		todo.Description = unescapeICSText(line.value)
+	case "STATUS":
+		switch strings.ToUpper(line.value) {
+		case icsStatusDone:
+			todo.Completed = true
+		case icsStatusToDo, "IN-PROCESS":
+			todo.Completed = false
+		default:
+			return false, nil
+		}
+	case "COMPLETED", "CREATED", "LAST-MODIFIED", "DUE":
+		date, err := parseICSTime(line)
+		if err != nil {
+			return true, fmt.Errorf("invalid %s: %w", line.name, err)
+		}
+		switch line.name {
+		case "COMPLETED":
+			todo.CompletedAt = &date
+		case "CREATED":
+			todo.CreatedAt = date
+		case "LAST-MODIFIED":
+			todo.UpdatedAt = date
+		default:
+			todo.DueDate = &date
+		}
+	default:
+		return icsIgnored[line.name], nil
+	}
+	return true, nil
+}
+
+// parseICSTime aceita data (VALUE=DATE), data e hora em UTC, no fuso do
+// parâmetro TZID ou sem fuso, que vale como horário local
+func parseICSTime(line icsLine) (time.Time, error) {
+	location := time.Local
+	if tzid := line.params["TZID"]; tzid != "" {
+		loaded, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unknown time zone %q", tzid)
+		}
+		location = loaded
+	}
+
+	layout := icsLocalTime
+	switch {
+	case strings.EqualFold(line.params["VALUE"], "DATE") || len(line.value) == len(icsDate):
+		layout, location = icsDate, time.Local
+	case strings.HasSuffix(line.value, "Z"):
+		layout, location = icsDateTime, time.UTC
+	}
+	date, err := time.ParseInLocation(layout, line.value, location)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a valid date", line.value)
+	}
+	return date.In(time.Local), nil
+}
+
+// readICSLines desdobra as linhas (as que começam com espaço ou tab continuam
+// a anterior) e separa nome, parâmetros e valor
+func readICSLines(r io.Reader) ([]icsLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []icsLine
+	var current *strings.Builder
+	start := 0
+	flush := func() {
+		if current == nil {
+			return
+		}
+		if line, ok := parseICSLine(current.String()); ok {
+			line.number = start
+			lines = append(lines, line)
+		}
+		current = nil
+	}
+
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if number == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if current != nil && (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) {
+			current.WriteString(text[1:])
+			continue
+		}
+		flush()
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		current, start = &strings.Builder{}, number
+		current.WriteString(text)
+	}
+	flush()
+	return lines, scanner.Err()
+}
+
+// parseICSLine separa "NOME;PARAM=valor:conteúdo"; os dois-pontos dentro de
+// parâmetros entre aspas não encerram o nome
+func parseICSLine(text string) (icsLine, bool) {
+	quoted := false
+	colon := -1
+	for i, r := range text {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return icsLine{}, false
+	}
+
+	parts := strings.Split(text[:colon], ";")
+	line := icsLine{name: strings.ToUpper(parts[0]), params: make(map[string]string), value: text[colon+1:]}
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		line.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return line, true
+}
+
+func unescapeICSText(value string) string {
+	var text strings.Builder
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped && (r == 'n' || r == 'N'):
+			text.WriteRune('\n')
+		case escaped:
+			text.WriteRune(r)
+		case r == '\\':
+			escaped = true
+			continue
+		default:
+			text.WriteRune(r)
+		}
+		escaped = false
+	}
+	return text.String()
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func (f *ICSFormat) Encode(w io.Writer, todos []*entity.Todo) error {
+	writer := bufio.NewWriter(w)
+	write := func(line string) {
+		writer.WriteString(foldICSLine(line))
+	}
+
+	write("BEGIN:" + icsVCalendar)
+	write("VERSION:2.0")
+	write("PRODID:" + icsProductID)
+	for _, todo := range todos {
+		modified := todo.UpdatedAt
+		if modified.IsZero() {
+			modified = todo.CreatedAt
+		}
+
+		write("BEGIN:" + icsVTodo)
+		write("UID:" + todo.ID)
+		write("DTSTAMP:" + formatICSTime(modified))
+		write("SUMMARY:" + icsEscaper.Replace(todo.Title))
+		if todo.Description != "" {
+			write("DESCRIPTION:" + icsEscaper.Replace(todo.Description))
+		}
+		if todo.Completed {
+			write("STATUS:" + icsStatusDone)
+			write("COMPLETED:" + formatICSTime(todo.CompletionTime()))
+		} else {
+			write("STATUS:" + icsStatusToDo)
+		}
+		write("CREATED:" + formatICSTime(todo.CreatedAt))
+		write("LAST-MODIFIED:" + formatICSTime(modified))
+		if todo.DueDate != nil {
+			write(formatICSDue(*todo.DueDate))
+		}
+		write("END:" + icsVTodo)
+	}
+	write("END:" + icsVCalendar)
+	return writer.Flush()
+}
+
+func formatICSTime(t time.Time) string {
+	return t.UTC().Format(icsDateTime)
+}
+
+// formatICSDue usa só a data quando o prazo cai à meia-noite, como os prazos
+// criados pela CLI e pelos outros formatos
+func formatICSDue(due time.Time) string {
+	local := due.In(time.Local)
+	if local.Hour() == 0 && local.Minute() == 0 && local.Second() == 0 {
+		return "DUE;VALUE=DATE:" + local.Format(icsDate)
+	}
+	return "DUE:" + formatICSTime(due)
+}
+
+// foldICSLine quebra a linha a cada 75 octetos sem partir caracteres UTF-8;
+// as continuações começam com um espaço
+func foldICSLine(line string) string {
+	var folded strings.Builder
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		folded.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = icsLineLimit - 1
+	}
+	folded.WriteString(line + "\r\n")
+	return folded.String()
+}
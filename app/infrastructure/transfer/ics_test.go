@@ -0,0 +1,153 @@
+package transfer
+
+import (
+	"bytes"
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/transfer"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// go test ./infrastructure/transfer -run ICS -update regrava os arquivos de testdata
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(want), string(got))
+}
+
+func icsTodos() []*entity.Todo {
+	created := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	updated := time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)
+	completedAt := time.Date(2024, 3, 5, 12, 15, 0, 0, time.UTC)
+	due := date("2024-03-10")
+	return []*entity.Todo{
+		{ID: "a1", Title: "Pagar aluguel; luz, água", Description: "Transferir até dia 10\nGuardar o comprovante em C:\\contas",
+			DueDate: &due, CreatedAt: created, UpdatedAt: updated},
+		{ID: "b2", Title: "Preparar apresentação da reunião trimestral com os resultados de vendas do período",
+			Completed: true, CreatedAt: created, UpdatedAt: completedAt, CompletedAt: &completedAt},
+	}
+}
+
+func TestICSFormat_EncodeMatchesGolden(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+
+	// Act
+	err := NewICSFormat().Encode(&out, icsTodos())
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	golden(t, "export.ics", out.Bytes())
+	for _, line := range strings.Split(out.String(), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, "Expected lines to be folded at 75 octets")
+	}
+}
+
+func TestICSFormat_RoundTripGolden(t *testing.T) {
+	// Arrange
+	input, err := os.ReadFile(filepath.Join("testdata", "export.ics"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	format := NewICSFormat()
+
+	// Act
+	decoded, decodeErr := format.Decode(bytes.NewReader(input))
+	var out bytes.Buffer
+	encodeErr := format.Encode(&out, decoded.Todos)
+
+	// Assert
+	if !assert.NoError(t, decodeErr) || !assert.NoError(t, encodeErr) {
+		return
+	}
+	assert.Empty(t, decoded.Skipped)
+	assert.Empty(t, decoded.Unmapped)
+	assert.Equal(t, string(input), out.String())
+
+	want := icsTodos()
+	if !assert.Len(t, decoded.Todos, len(want)) {
+		return
+	}
+	for i, got := range decoded.Todos {
+		assert.Equal(t, want[i].ID, got.ID)
+		assert.Equal(t, want[i].Title, got.Title)
+		assert.Equal(t, want[i].Description, got.Description)
+		assert.Equal(t, want[i].Completed, got.Completed)
+	}
+}
+
+func TestICSFormat_DecodeCalendarFromOtherApps(t *testing.T) {
+	// Arrange
+	input, err := os.ReadFile(filepath.Join("testdata", "import.ics"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	decoded, err := NewICSFormat().Decode(bytes.NewReader(input))
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, decoded.Todos, 3) {
+		return
+	}
+
+	dentist := decoded.Todos[0]
+	assert.Equal(t, "dentista-1@example.com", dentist.ID)
+	assert.Equal(t, "Marcar dentista, com urgência", dentist.Title)
+	assert.Equal(t, "Ligar antes das 18h; levar a carteirinha\nPedir recibo", dentist.Description)
+	assert.False(t, dentist.Completed)
+	assert.True(t, dentist.CreatedAt.Equal(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)))
+	assert.True(t, dentist.DueDate.Equal(time.Date(2024, 3, 15, 9, 0, 0, 0, mustLocation(t, "America/Sao_Paulo"))))
+
+	report := decoded.Todos[1]
+	assert.Equal(t, "Relatório mensal", report.Title, "Expected folded lines to be joined, even inside a UTF-8 character")
+	assert.True(t, report.Completed)
+	assert.True(t, report.CompletedAt.Equal(time.Date(2024, 3, 2, 15, 0, 0, 0, time.UTC)))
+	assert.True(t, report.DueDate.Equal(date("2024-03-20")))
+
+	noID := decoded.Todos[2]
+	assert.Empty(t, noID.ID, "Expected the ID to be left for the import")
+	assert.Equal(t, "Sem UID", noID.Title)
+	assert.Equal(t, []int{6, 21, 31}, decoded.Lines)
+
+	assert.Equal(t, []transfer.Issue{
+		{Line: 35, Text: "VEVENT", Reason: "only VTODO components are imported"},
+		{Line: 43, Text: "VTODO", Reason: "missing SUMMARY"},
+		{Line: 46, Text: "VTODO", Reason: `invalid DUE: "amanhã" is not a valid date`},
+	}, decoded.Skipped)
+	assert.Equal(t, []transfer.Issue{
+		{Line: 14, Text: "PRIORITY:1", Reason: "unsupported property, ignored"},
+		{Line: 15, Text: "VALARM", Reason: "unsupported component, ignored"},
+		{Line: 27, Text: "X-APPLE-SORT-ORDER:3", Reason: "unsupported property, ignored"},
+	}, decoded.Unmapped)
+}
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	return location
+}
@@ -0,0 +1,253 @@
+package transfer
+
+import (
+	"bufio"
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/transfer"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const markdownDue = "📅"
+
+var (
+	markdownHeading = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	markdownItem    = regexp.MustCompile(`^(\s*)[-*+]\s+\[([ xX])\]\s*(.*)$`)
+	markdownID      = regexp.MustCompile(`\s*<!--\s*id:\s*(\S+?)\s*-->\s*`)
+	markdownTag     = regexp.MustCompile(`^#[\p{L}\p{N}_/-]*\p{L}[\p{L}\p{N}_/-]*$`)
+)
+
+// MarkdownFormat lê e escreve listas de tarefas em Markdown, como as das atas
+// de reunião: "- [ ] título — descrição", com "- [x]" para as concluídas. O
+// título de seção vira projeto, #palavras viram tags e o ID vai em um
+// comentário HTML, invisível ao renderizar, para que reimportar o arquivo
+// editado atualize as tarefas em vez de duplicá-las
+type MarkdownFormat struct{}
+
+var _ transfer.ITodoFormat = (*MarkdownFormat)(nil)
+
+func NewMarkdownFormat() transfer.ITodoFormat {
+	return &MarkdownFormat{}
+}
+
+func (f *MarkdownFormat) Decode(r io.Reader) (*transfer.Decoded, error) {
+	decoded := &transfer.Decoded{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var (
+		headings []string // títulos de seção abertos, do nível 1 ao 6
+		current  *entity.Todo
+		indent   int // recuo do item atual, para reconhecer as continuações
+		parents  []int
+	)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if number == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		if match := markdownHeading.FindStringSubmatch(line); match != nil {
+			level := len(match[1])
+			for len(headings) < level {
+				headings = append(headings, "")
+			}
+			headings = append(headings[:level-1], match[2])
+			current, parents = nil, nil
+			continue
+		}
+
+		match := markdownItem.FindStringSubmatch(line)
+		if match == nil {
+			// Texto recuado logo abaixo de um item continua a descrição dele
+			if current != nil && line != "" && markdownIndent(line) > indent {
+				current.Description = strings.TrimLeft(current.Description+"\n"+strings.TrimSpace(line), "\n")
+				continue
+			}
+			current = nil
+			if line != "" && markdownIndent(line) == 0 {
+				parents = nil
+			}
+			continue
+		}
+
+		indent = markdownIndent(match[1])
+		for len(parents) > 0 && parents[len(parents)-1] >= indent {
+			parents = parents[:len(parents)-1]
+		}
+		todo, unmapped := parseMarkdownItem(match[3], match[2] != " ")
+		for _, text := range unmapped {
+			decoded.Unmapped = append(decoded.Unmapped, transfer.Issue{Line: number, Text: text, Reason: "invalid due date, kept in the title"})
+		}
+		if len(parents) > 0 && todo != nil {
+			decoded.Unmapped = append(decoded.Unmapped, transfer.Issue{Line: number, Text: todo.Title, Reason: "nested item imported as a separate todo (todos have no subtasks)"})
+		}
+		parents = append(parents, indent)
+		if todo == nil {
+			decoded.Skipped = append(decoded.Skipped, transfer.Issue{Line: number, Text: strings.TrimSpace(line), Reason: "missing task description"})
+			current = nil
+			continue
+		}
+
+		if section := markdownSection(headings); section != "" {
+			projects := []string{section}
+			for _, project := range todo.Projects {
+				projects = appendUnique(projects, project)
+			}
+			todo.Projects = projects
+		}
+		current = todo
+		decoded.Todos = append(decoded.Todos, todo)
+		decoded.Lines = append(decoded.Lines, number)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// parseMarkdownItem separa o conteúdo do item em título, descrição (depois
+// do travessão), +projetos, #tags, prazo (📅 AAAA-MM-DD) e o ID do comentário.
+// Devolve nil quando o item não tem título
+func parseMarkdownItem(content string, completed bool) (todo *entity.Todo, unmapped []string) {
+	todo = &entity.Todo{Completed: completed}
+	if match := markdownID.FindStringSubmatch(content); match != nil {
+		todo.ID = match[1]
+		content = markdownID.ReplaceAllString(content, " ")
+	}
+
+	head, description, found := strings.Cut(content, " — ")
+	if !found {
+		head, description, _ = strings.Cut(content, " -- ")
+	}
+	todo.Description = strings.TrimSpace(description)
+
+	tokens := strings.Fields(head)
+	var words []string
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		switch {
+		case token == markdownDue && i+1 < len(tokens):
+			date, err := time.ParseInLocation(todoTxtDate, tokens[i+1], time.Local)
+			if err != nil {
+				unmapped = append(unmapped, token+" "+tokens[i+1])
+				words = append(words, token)
+				continue
+			}
+			todo.DueDate = &date
+			i++
+		case len(token) > 1 && token[0] == '+':
+			todo.Projects = appendUnique(todo.Projects, token[1:])
+		case markdownTag.MatchString(token):
+			todo.Tags = appendUnique(todo.Tags, token[1:])
+		default:
+			words = append(words, token)
+		}
+	}
+
+	todo.Title = strings.Join(words, " ")
+	if todo.Title == "" {
+		return nil, unmapped
+	}
+	return todo, unmapped
+}
+
+// markdownSection devolve o título de seção mais interno
+func markdownSection(headings []string) string {
+	for i := len(headings) - 1; i >= 0; i-- {
+		if headings[i] != "" {
+			return headings[i]
+		}
+	}
+	return ""
+}
+
+// markdownIndent mede o recuo contando o tab como quatro espaços
+func markdownIndent(text string) int {
+	width := 0
+	for _, r := range text {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// Encode escreve primeiro as tarefas sem projeto e depois uma seção por
+// projeto, na ordem em que aparecem; os demais projetos da tarefa vão como +projeto
+func (f *MarkdownFormat) Encode(w io.Writer, todos []*entity.Todo) error {
+	var sections []string
+	grouped := make(map[string][]*entity.Todo)
+	for _, todo := range todos {
+		section := ""
+		if len(todo.Projects) > 0 {
+			section = todo.Projects[0]
+		}
+		if _, ok := grouped[section]; !ok && section != "" {
+			sections = append(sections, section)
+		}
+		grouped[section] = append(grouped[section], todo)
+	}
+
+	writer := bufio.NewWriter(w)
+	writeItems := func(todos []*entity.Todo) {
+		for _, todo := range todos {
+			writer.WriteString(formatMarkdownItem(todo))
+		}
+	}
+	writeItems(grouped[""])
+	for i, section := range sections {
+		if i > 0 || len(grouped[""]) > 0 {
+			writer.WriteString("\n")
+		}
+		writer.WriteString("## " + section + "\n\n")
+		writeItems(grouped[section])
+	}
+	return writer.Flush()
+}
+
+// formatMarkdownItem escreve o item e, recuadas abaixo dele, as demais
+// linhas da descrição
+func formatMarkdownItem(todo *entity.Todo) string {
+	mark := " "
+	if todo.Completed {
+		mark = "x"
+	}
+	parts := []string{"- [" + mark + "]", strings.Join(strings.Fields(todo.Title), " ")}
+	if len(todo.Projects) > 1 {
+		for _, project := range todo.Projects[1:] {
+			parts = append(parts, "+"+project)
+		}
+	}
+	for _, tag := range todo.Tags {
+		parts = append(parts, "#"+tag)
+	}
+	if todo.DueDate != nil {
+		parts = append(parts, markdownDue, formatTodoTxtDate(*todo.DueDate))
+	}
+
+	var continuation []string
+	if description := strings.TrimSpace(todo.Description); description != "" {
+		lines := strings.Split(description, "\n")
+		parts = append(parts, "—", strings.TrimSpace(lines[0]))
+		for _, line := range lines[1:] {
+			if line = strings.TrimSpace(line); line != "" {
+				continuation = append(continuation, "  "+line)
+			}
+		}
+	}
+	parts = append(parts, "<!-- id:"+todo.ID+" -->")
+
+	item := strings.Join(parts, " ") + "\n"
+	for _, line := range continuation {
+		item += line + "\n"
+	}
+	return item
+}
@@ -0,0 +1,121 @@
+package transfer
+
+import (
+	"bytes"
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/transfer"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownFormat_DecodeMeetingNotes(t *testing.T) {
+	// Arrange
+	input := "# Reunião de planejamento\n" +
+		"\n" +
+		"Participantes: Ana, Bruno\n" +
+		"\n" +
+		"- [ ] Enviar ata — para todos os participantes <!-- id:a1 -->\n" +
+		"- [x] Reservar sala #escritorio\n" +
+		"\n" +
+		"## Marketing\n" +
+		"\n" +
+		"* [ ] Revisar PR #123 +site 📅 2024-03-10 -- conferir textos\n" +
+		"  e as imagens da home\n" +
+		"    - [ ] Trocar banner\n" +
+		"- [ ] 📅 amanhã Ligar para a agência\n" +
+		"- [ ]  <!-- id:b2 -->\n" +
+		"- item sem caixa de seleção\n"
+
+	// Act
+	decoded, err := NewMarkdownFormat().Decode(strings.NewReader(input))
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, decoded.Todos, 5) {
+		return
+	}
+	assert.Equal(t, []int{5, 6, 10, 12, 13}, decoded.Lines)
+
+	minutes := decoded.Todos[0]
+	assert.Equal(t, "a1", minutes.ID)
+	assert.Equal(t, "Enviar ata", minutes.Title)
+	assert.Equal(t, "para todos os participantes", minutes.Description)
+	assert.Equal(t, []string{"Reunião de planejamento"}, minutes.Projects)
+	assert.False(t, minutes.Completed)
+
+	room := decoded.Todos[1]
+	assert.Empty(t, room.ID, "Expected the ID to be left for the import")
+	assert.Equal(t, "Reservar sala", room.Title)
+	assert.Equal(t, []string{"escritorio"}, room.Tags)
+	assert.True(t, room.Completed)
+
+	review := decoded.Todos[2]
+	assert.Equal(t, "Revisar PR #123", review.Title, "Expected numbers not to be read as tags")
+	assert.Equal(t, "conferir textos\ne as imagens da home", review.Description)
+	assert.Equal(t, []string{"Marketing", "site"}, review.Projects)
+	assert.True(t, review.DueDate.Equal(date("2024-03-10")))
+
+	banner := decoded.Todos[3]
+	assert.Equal(t, "Trocar banner", banner.Title)
+	assert.Equal(t, []string{"Marketing"}, banner.Projects)
+
+	assert.Equal(t, "📅 amanhã Ligar para a agência", decoded.Todos[4].Title)
+	assert.Equal(t, []transfer.Issue{
+		{Line: 12, Text: "Trocar banner", Reason: "nested item imported as a separate todo (todos have no subtasks)"},
+		{Line: 13, Text: "📅 amanhã", Reason: "invalid due date, kept in the title"},
+	}, decoded.Unmapped)
+	assert.Equal(t, []transfer.Issue{{Line: 14, Text: "- [ ]  <!-- id:b2 -->", Reason: "missing task description"}}, decoded.Skipped)
+}
+
+func TestMarkdownFormat_EncodeGroupsByProject(t *testing.T) {
+	// Arrange
+	due := date("2024-03-10")
+	todos := []*entity.Todo{
+		{ID: "a1", Title: "Enviar ata", Description: "para todos\ne para a diretoria"},
+		{ID: "b2", Title: "Revisar PR", Projects: []string{"Marketing", "site"}, Tags: []string{"urgente"}, DueDate: &due},
+		{ID: "c3", Title: "Reservar sala", Completed: true},
+		{ID: "d4", Title: "Trocar banner", Projects: []string{"Marketing"}},
+	}
+
+	// Act
+	var out bytes.Buffer
+	err := NewMarkdownFormat().Encode(&out, todos)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "- [ ] Enviar ata — para todos <!-- id:a1 -->\n"+
+		"  e para a diretoria\n"+
+		"- [x] Reservar sala <!-- id:c3 -->\n"+
+		"\n"+
+		"## Marketing\n"+
+		"\n"+
+		"- [ ] Revisar PR +site #urgente 📅 2024-03-10 <!-- id:b2 -->\n"+
+		"- [ ] Trocar banner <!-- id:d4 -->\n", out.String())
+}
+
+func TestMarkdownFormat_RoundTripTodos(t *testing.T) {
+	// Arrange
+	due := date("2024-03-10")
+	todos := []*entity.Todo{
+		{ID: "a1", Title: "Enviar ata", Description: "para todos\ne para a diretoria", Tags: []string{"reuniao"}},
+		{ID: "b2", Title: "Revisar PR", Projects: []string{"Marketing", "site"}, DueDate: &due, Completed: true},
+	}
+	format := NewMarkdownFormat()
+
+	// Act
+	var out bytes.Buffer
+	encodeErr := format.Encode(&out, todos)
+	decoded, decodeErr := format.Decode(&out)
+
+	// Assert
+	if !assert.NoError(t, encodeErr) || !assert.NoError(t, decodeErr) {
+		return
+	}
+	assert.Empty(t, decoded.Skipped)
+	assert.Empty(t, decoded.Unmapped)
+	assert.Equal(t, todos, decoded.Todos)
+}
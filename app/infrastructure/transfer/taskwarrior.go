@@ -0,0 +1,263 @@
+package transfer
+
+import (
+	"bytes"
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/transfer"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const taskwarriorDate = "20060102T150405Z"
+
+// Status das tarefas no Taskwarrior
+const (
+	taskwarriorPending   = "pending"
+	taskwarriorCompleted = "completed"
+	taskwarriorDeleted   = "deleted"
+	taskwarriorWaiting   = "waiting"
+	taskwarriorRecurring = "recurring"
+)
+
+// taskwarriorPriorities liga as prioridades H, M e L do Taskwarrior às letras A, B e C
+var taskwarriorPriorities = map[string]string{"H": "A", "M": "B", "L": "C"}
+
+// taskwarriorIgnored são atributos calculados pelo Taskwarrior, descartados sem aviso
+var taskwarriorIgnored = map[string]bool{"id": true, "urgency": true}
+
+type taskwarriorAnnotation struct {
+	Entry       string `json:"entry"`
+	Description string `json:"description"`
+}
+
+// taskwarriorTask segue o JSON de "task export"; a ordem dos campos é a da exportação
+type taskwarriorTask struct {
+	UUID        string                  `json:"uuid"`
+	Description string                  `json:"description"`
+	Status      string                  `json:"status"`
+	Entry       string                  `json:"entry,omitempty"`
+	Modified    string                  `json:"modified,omitempty"`
+	End         string                  `json:"end,omitempty"`
+	Due         string                  `json:"due,omitempty"`
+	Project     string                  `json:"project,omitempty"`
+	Priority    string                  `json:"priority,omitempty"`
+	Tags        []string                `json:"tags,omitempty"`
+	Annotations []taskwarriorAnnotation `json:"annotations,omitempty"`
+}
+
+var taskwarriorFields = []string{"uuid", "description", "status", "entry", "modified", "end", "due", "project", "priority", "tags", "annotations"}
+
+// TaskwarriorFormat lê a saída de "task export" (um array JSON ou, nas versões
+// antigas, um objeto por linha) e escreve as tarefas no mesmo formato, que o
+// "task import" aceita. O uuid guarda o ID da tarefa nos dois sentidos
+type TaskwarriorFormat struct{}
+
+var _ transfer.ITodoFormat = (*TaskwarriorFormat)(nil)
+
+func NewTaskwarriorFormat() transfer.ITodoFormat {
+	return &TaskwarriorFormat{}
+}
+
+func (f *TaskwarriorFormat) Decode(r io.Reader) (*transfer.Decoded, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, utf8BOM)
+
+	decoded := &transfer.Decoded{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	array := bytes.HasPrefix(bytes.TrimSpace(data), []byte("["))
+	if array {
+		if _, err := decoder.Token(); err != nil {
+			return nil, fmt.Errorf("invalid Taskwarrior JSON: %w", err)
+		}
+	}
+
+	for decoder.More() {
+		offset := int(decoder.InputOffset())
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("invalid Taskwarrior JSON: %w", err)
+		}
+		// O deslocamento fica antes da vírgula e dos espaços que precedem o objeto
+		offset += len(data[offset:]) - len(bytes.TrimLeft(data[offset:], ", \t\r\n"))
+		line := 1 + bytes.Count(data[:offset], []byte("\n"))
+
+		todo, unmapped, err := parseTaskwarriorTask(raw)
+		if err != nil {
+			decoded.Skipped = append(decoded.Skipped, transfer.Issue{Line: line, Text: taskwarriorLabel(todo), Reason: err.Error()})
+			continue
+		}
+		for _, text := range unmapped {
+			decoded.Unmapped = append(decoded.Unmapped, transfer.Issue{Line: line, Text: text, Reason: "unsupported attribute, ignored"})
+		}
+		decoded.Todos = append(decoded.Todos, todo)
+		decoded.Lines = append(decoded.Lines, line)
+	}
+	if array {
+		if _, err := decoder.Token(); err != nil {
+			return nil, fmt.Errorf("invalid Taskwarrior JSON: %w", err)
+		}
+	}
+	return decoded, nil
+}
+
+// parseTaskwarriorTask converte os atributos conhecidos; unmapped lista os
+// demais como "nome=valor", em ordem alfabética. Com erro, a tarefa volta
+// assim mesmo para ser identificada no relatório
+func parseTaskwarriorTask(raw json.RawMessage) (todo *entity.Todo, unmapped []string, err error) {
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &attributes); err != nil {
+		return &entity.Todo{}, nil, fmt.Errorf("invalid task: %w", err)
+	}
+	for name, value := range attributes {
+		if !taskwarriorIgnored[name] && !slices.Contains(taskwarriorFields, name) {
+			unmapped = append(unmapped, name+"="+string(value))
+		}
+	}
+	sort.Strings(unmapped)
+
+	var task taskwarriorTask
+	if err := json.Unmarshal(raw, &task); err != nil {
+		return &entity.Todo{}, unmapped, fmt.Errorf("invalid task: %w", err)
+	}
+
+	todo = &entity.Todo{ID: task.UUID, Title: strings.TrimSpace(task.Description)}
+	switch task.Status {
+	case taskwarriorCompleted:
+		todo.Completed = true
+	case taskwarriorDeleted:
+		return todo, unmapped, errors.New("task is deleted in Taskwarrior")
+	case taskwarriorRecurring:
+		unmapped = append(unmapped, "status="+task.Status)
+	case "", taskwarriorPending, taskwarriorWaiting:
+	default:
+		return todo, unmapped, fmt.Errorf("unknown status %q", task.Status)
+	}
+	if todo.Title == "" {
+		return todo, unmapped, errors.New("missing description")
+	}
+
+	dates := []struct {
+		name  string
+		value string
+		set   func(time.Time)
+	}{
+		{"entry", task.Entry, func(t time.Time) { todo.CreatedAt = t }},
+		{"modified", task.Modified, func(t time.Time) { todo.UpdatedAt = t }},
+		{"end", task.End, func(t time.Time) {
+			if todo.Completed {
+				todo.CompletedAt = &t
+			}
+		}},
+		{"due", task.Due, func(t time.Time) { todo.DueDate = &t }},
+	}
+	for _, date := range dates {
+		if date.value == "" {
+			continue
+		}
+		parsed, err := time.Parse(taskwarriorDate, date.value)
+		if err != nil {
+			return todo, unmapped, fmt.Errorf("invalid %s date %q", date.name, date.value)
+		}
+		date.set(parsed.In(time.Local))
+	}
+
+	if task.Project != "" {
+		todo.Projects = []string{task.Project}
+	}
+	if task.Priority != "" {
+		priority, ok := taskwarriorPriorities[task.Priority]
+		if !ok {
+			return todo, unmapped, fmt.Errorf("invalid priority %q (expected H, M or L)", task.Priority)
+		}
+		todo.Priority = priority
+	}
+	for _, tag := range task.Tags {
+		todo.Tags = appendUnique(todo.Tags, tag)
+	}
+	var notes []string
+	for _, annotation := range task.Annotations {
+		notes = append(notes, annotation.Description)
+	}
+	todo.Description = strings.Join(notes, "\n")
+	return todo, unmapped, nil
+}
+
+// taskwarriorLabel identifica a tarefa no relatório pela descrição ou, sem ela, pelo uuid
+func taskwarriorLabel(todo *entity.Todo) string {
+	switch {
+	case todo.Title != "":
+		return todo.Title
+	case todo.ID != "":
+		return todo.ID
+	default:
+		return "-"
+	}
+}
+
+// Encode escreve um array com uma tarefa por linha, como o "task export".
+// Cada linha da descrição vira uma anotação; só o primeiro projeto é exportado,
+// e prioridades depois de C não têm equivalente
+func (f *TaskwarriorFormat) Encode(w io.Writer, todos []*entity.Todo) error {
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	out.WriteString("[\n")
+	for i, todo := range todos {
+		task := taskwarriorTask{
+			UUID:        todo.ID,
+			Description: todo.Title,
+			Status:      taskwarriorPending,
+			Entry:       formatTaskwarriorDate(todo.CreatedAt),
+			Modified:    formatTaskwarriorDate(todo.UpdatedAt),
+			Tags:        todo.Tags,
+		}
+		if todo.Completed {
+			task.Status = taskwarriorCompleted
+			task.End = formatTaskwarriorDate(todo.CompletionTime())
+		}
+		if todo.DueDate != nil {
+			task.Due = formatTaskwarriorDate(*todo.DueDate)
+		}
+		if len(todo.Projects) > 0 {
+			task.Project = todo.Projects[0]
+		}
+		for letter, priority := range taskwarriorPriorities {
+			if priority == todo.Priority {
+				task.Priority = letter
+			}
+		}
+		if description := strings.TrimSpace(todo.Description); description != "" {
+			for _, note := range strings.Split(description, "\n") {
+				task.Annotations = append(task.Annotations, taskwarriorAnnotation{Entry: task.Entry, Description: note})
+			}
+		}
+
+		if err := encoder.Encode(task); err != nil {
+			return err
+		}
+		if i < len(todos)-1 {
+			out.Truncate(out.Len() - 1) // troca a quebra de linha do Encode por ",\n"
+			out.WriteString(",\n")
+		}
+	}
+	out.WriteString("]\n")
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+func formatTaskwarriorDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(taskwarriorDate)
+}
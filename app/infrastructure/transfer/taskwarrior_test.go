@@ -0,0 +1,132 @@
+package transfer
+
+import (
+	"bytes"
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/transfer"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskwarriorFormat_DecodeTaskExport(t *testing.T) {
+	// Arrange
+	input, err := os.ReadFile(filepath.Join("testdata", "taskwarrior.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Act
+	decoded, err := NewTaskwarriorFormat().Decode(bytes.NewReader(input))
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, decoded.Todos, 3) {
+		return
+	}
+	assert.Equal(t, []int{2, 4, 6}, decoded.Lines)
+
+	rent := decoded.Todos[0]
+	assert.Equal(t, "5b1c6d0e-3a4f-4c1e-9f7a-2d8e6b0c1a11", rent.ID)
+	assert.Equal(t, "Pagar aluguel", rent.Title)
+	assert.Equal(t, "Transferir até dia 10\nGuardar o comprovante", rent.Description)
+	assert.Equal(t, "A", rent.Priority)
+	assert.Equal(t, []string{"casa"}, rent.Projects)
+	assert.Equal(t, []string{"contas", "mensal"}, rent.Tags)
+	assert.False(t, rent.Completed)
+	assert.True(t, rent.CreatedAt.Equal(time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)))
+	assert.True(t, rent.UpdatedAt.Equal(time.Date(2024, 3, 4, 18, 0, 0, 0, time.UTC)))
+	assert.True(t, rent.DueDate.Equal(time.Date(2024, 3, 10, 3, 0, 0, 0, time.UTC)))
+
+	report := decoded.Todos[1]
+	assert.True(t, report.Completed)
+	assert.True(t, report.CompletedAt.Equal(time.Date(2024, 3, 5, 12, 15, 0, 0, time.UTC)))
+
+	contract := decoded.Todos[2]
+	assert.Equal(t, "Revisar contrato", contract.Title)
+	assert.False(t, contract.Completed, "Expected waiting tasks to be imported as pending")
+
+	assert.Equal(t, []transfer.Issue{
+		{Line: 6, Text: `depends="5b1c6d0e-3a4f-4c1e-9f7a-2d8e6b0c1a11"`, Reason: "unsupported attribute, ignored"},
+		{Line: 6, Text: `scheduled="20240325T000000Z"`, Reason: "unsupported attribute, ignored"},
+		{Line: 6, Text: `wait="20240401T000000Z"`, Reason: "unsupported attribute, ignored"},
+	}, decoded.Unmapped)
+	assert.Equal(t, []transfer.Issue{
+		{Line: 8, Text: "Tarefa apagada", Reason: "task is deleted in Taskwarrior"},
+		{Line: 10, Text: "Data quebrada", Reason: `invalid entry date "ontem"`},
+	}, decoded.Skipped)
+}
+
+func TestTaskwarriorFormat_DecodeOneTaskPerLine(t *testing.T) {
+	// Arrange
+	input := `{"uuid":"a1","description":"Primeira","status":"pending"}` + "\n" +
+		`{"uuid":"b2","description":"Segunda","status":"completed","priority":"X"}` + "\n" +
+		`{"uuid":"c3","description":"Terceira","status":"pending"}` + "\n"
+
+	// Act
+	decoded, err := NewTaskwarriorFormat().Decode(strings.NewReader(input))
+	_, invalid := NewTaskwarriorFormat().Decode(strings.NewReader(`[{"uuid":"a1",`))
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, decoded.Todos, 2) {
+		return
+	}
+	assert.Equal(t, "a1", decoded.Todos[0].ID)
+	assert.Equal(t, "c3", decoded.Todos[1].ID)
+	assert.Equal(t, []int{1, 3}, decoded.Lines)
+	assert.Equal(t, []transfer.Issue{{Line: 2, Text: "Segunda", Reason: `invalid priority "X" (expected H, M or L)`}}, decoded.Skipped)
+	assert.ErrorContains(t, invalid, "invalid Taskwarrior JSON")
+}
+
+func TestTaskwarriorFormat_EncodeMatchesGolden(t *testing.T) {
+	// Arrange
+	todos := icsTodos()
+	due := time.Date(2024, 3, 10, 3, 0, 0, 0, time.UTC)
+	todos[0].DueDate, todos[0].Priority, todos[0].Projects, todos[0].Tags = &due, "A", []string{"casa", "reforma"}, []string{"contas"}
+	var out bytes.Buffer
+
+	// Act
+	err := NewTaskwarriorFormat().Encode(&out, todos)
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	golden(t, "taskwarrior_export.json", out.Bytes())
+}
+
+func TestTaskwarriorFormat_RoundTripTodos(t *testing.T) {
+	// Arrange
+	due := date("2024-03-10")
+	completedAt := time.Date(2024, 3, 5, 12, 15, 0, 0, time.Local)
+	todos := []*entity.Todo{
+		{ID: "a1", Title: "Pagar aluguel <luz & água>", Description: "Transferir até dia 10\nGuardar o comprovante", Priority: "B",
+			Projects: []string{"casa"}, Tags: []string{"contas"}, DueDate: &due,
+			CreatedAt: time.Date(2024, 3, 1, 9, 30, 0, 0, time.Local), UpdatedAt: time.Date(2024, 3, 4, 18, 0, 0, 0, time.Local)},
+		{ID: "b2", Title: "Enviar relatório", Completed: true, CompletedAt: &completedAt,
+			CreatedAt: time.Date(2024, 3, 1, 9, 30, 0, 0, time.Local), UpdatedAt: completedAt},
+	}
+	format := NewTaskwarriorFormat()
+
+	// Act
+	var out bytes.Buffer
+	encodeErr := format.Encode(&out, todos)
+	decoded, decodeErr := format.Decode(&out)
+
+	// Assert
+	if !assert.NoError(t, encodeErr) || !assert.NoError(t, decodeErr) {
+		return
+	}
+	assert.Empty(t, decoded.Skipped)
+	assert.Empty(t, decoded.Unmapped)
+	assert.Equal(t, todos, decoded.Todos)
+}
@@ -0,0 +1,223 @@
+package transfer
+
+import (
+	"bufio"
+	"codecademy-yellowbelt2/core/domain/entity"
+	"codecademy-yellowbelt2/infrastructure/interface/transfer"
+	"io"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const todoTxtDate = "2006-01-02"
+
+var (
+	todoTxtPriority = regexp.MustCompile(`^\(([A-Z])\)$`)
+	todoTxtKey      = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_-]*):([^/\s]\S*)$`)
+)
+
+// TodoTxtFormat lê e escreve o formato do todo.txt (http://todotxt.org): uma
+// tarefa por linha, com prioridade, datas, +projetos, @contextos e chave:valor.
+// Os @contextos viram tags e o ID da tarefa vai em id:, para que reimportar
+// o arquivo atualize as tarefas em vez de duplicá-las. Descrição e
+// responsáveis, que o todo.txt não prevê, vão em desc: e assignee: com os
+// espaços e demais caracteres especiais escapados como em uma URL
+type TodoTxtFormat struct{}
+
+var _ transfer.ITodoFormat = (*TodoTxtFormat)(nil)
+
+func NewTodoTxtFormat() transfer.ITodoFormat {
+	return &TodoTxtFormat{}
+}
+
+func (f *TodoTxtFormat) Decode(r io.Reader) (*transfer.Decoded, error) {
+	decoded := &transfer.Decoded{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for number := 1; scanner.Scan(); number++ {
+		line := scanner.Text()
+		if number == 1 {
+			line = strings.TrimPrefix(line, "\ufeff") // BOM de editores do Windows
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		todo, unmapped := parseTodoTxtLine(line)
+		for _, text := range unmapped {
+			decoded.Unmapped = append(decoded.Unmapped, transfer.Issue{Line: number, Text: text, Reason: "unknown key or invalid value, kept in the title"})
+		}
+		if todo == nil {
+			decoded.Skipped = append(decoded.Skipped, transfer.Issue{Line: number, Text: strings.TrimSpace(line), Reason: "missing task description"})
+			continue
+		}
+		decoded.Todos = append(decoded.Todos, todo)
+		decoded.Lines = append(decoded.Lines, number)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// parseTodoTxtLine devolve nil quando a linha não tem descrição; unmapped
+// lista os pares chave:valor sem campo correspondente
+func parseTodoTxtLine(line string) (todo *entity.Todo, unmapped []string) {
+	tokens := strings.Fields(line)
+	todo = &entity.Todo{}
+
+	var createdAt, completedAt *time.Time
+	if tokens[0] == "x" {
+		todo.Completed = true
+		tokens = tokens[1:]
+		if date, ok := parseTodoTxtDate(tokens); ok {
+			completedAt, tokens = &date, tokens[1:]
+		}
+	} else if match := todoTxtPriority.FindStringSubmatch(tokens[0]); match != nil {
+		todo.Priority = match[1]
+		tokens = tokens[1:]
+	}
+	if date, ok := parseTodoTxtDate(tokens); ok {
+		createdAt, tokens = &date, tokens[1:]
+	}
+
+	var words []string
+	for _, token := range tokens {
+		switch {
+		case len(token) > 1 && token[0] == '+':
+			todo.Projects = appendUnique(todo.Projects, token[1:])
+		case len(token) > 1 && token[0] == '@':
+			todo.Tags = appendUnique(todo.Tags, token[1:])
+		case todoTxtKey.MatchString(token):
+			if !setTodoTxtKey(todo, token) {
+				unmapped = append(unmapped, token)
+				words = append(words, token)
+			}
+		default:
+			words = append(words, token)
+		}
+	}
+
+	todo.Title = strings.Join(words, " ")
+	if todo.Title == "" {
+		return nil, unmapped
+	}
+
+	now := time.Now()
+	if todo.ID == "" {
+		todo.ID = uuid.New().String()
+	}
+	todo.CreatedAt = now
+	if createdAt != nil {
+		todo.CreatedAt = *createdAt
+	}
+	todo.UpdatedAt = now
+	todo.CompletedAt = completedAt
+	return todo, unmapped
+}
+
+// setTodoTxtKey aplica as chaves conhecidas, retornando false para as demais
+// e para valores inválidos
+func setTodoTxtKey(todo *entity.Todo, token string) bool {
+	key, value, _ := strings.Cut(token, ":")
+	switch key {
+	case "id":
+		todo.ID = value
+	case "due":
+		date, err := time.ParseInLocation(todoTxtDate, value, time.Local)
+		if err != nil {
+			return false
+		}
+		todo.DueDate = &date
+	case "pri":
+		if !entity.IsValidPriority(value) {
+			return false
+		}
+		todo.Priority = value
+	case "desc":
+		description, err := url.QueryUnescape(value)
+		if err != nil {
+			return false
+		}
+		todo.Description = description
+	case "assignee":
+		assignee, err := url.QueryUnescape(value)
+		if err != nil || strings.TrimSpace(assignee) == "" {
+			return false
+		}
+		todo.Assignees = appendUnique(todo.Assignees, assignee)
+	default:
+		return false
+	}
+	return true
+}
+
+func parseTodoTxtDate(tokens []string) (time.Time, bool) {
+	if len(tokens) == 0 {
+		return time.Time{}, false
+	}
+	date, err := time.ParseInLocation(todoTxtDate, tokens[0], time.Local)
+	return date, err == nil
+}
+
+func (f *TodoTxtFormat) Encode(w io.Writer, todos []*entity.Todo) error {
+	writer := bufio.NewWriter(w)
+	for _, todo := range todos {
+		if _, err := writer.WriteString(formatTodoTxtLine(todo) + "\n"); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+// formatTodoTxtLine escreve a linha canônica: marcas e datas no início e
+// projetos, contextos e chaves no final, depois do título
+func formatTodoTxtLine(todo *entity.Todo) string {
+	var parts []string
+	if todo.Completed {
+		parts = append(parts, "x", formatTodoTxtDate(todo.CompletionTime()))
+	} else if todo.Priority != "" {
+		parts = append(parts, "("+todo.Priority+")")
+	}
+	parts = append(parts, formatTodoTxtDate(todo.CreatedAt))
+	parts = append(parts, strings.Fields(todo.Title)...)
+
+	for _, project := range todo.Projects {
+		parts = append(parts, "+"+project)
+	}
+	for _, tag := range todo.Tags {
+		parts = append(parts, "@"+tag)
+	}
+	if todo.DueDate != nil {
+		parts = append(parts, "due:"+formatTodoTxtDate(*todo.DueDate))
+	}
+	if todo.Completed && todo.Priority != "" {
+		// Tarefas concluídas perdem o (A) do início; a convenção é guardá-la em pri:
+		parts = append(parts, "pri:"+todo.Priority)
+	}
+	if todo.Description != "" {
+		parts = append(parts, "desc:"+url.QueryEscape(todo.Description))
+	}
+	for _, assignee := range todo.Assignees {
+		parts = append(parts, "assignee:"+url.QueryEscape(assignee))
+	}
+	parts = append(parts, "id:"+todo.ID)
+	return strings.Join(parts, " ")
+}
+
+func formatTodoTxtDate(t time.Time) string {
+	return t.In(time.Local).Format(todoTxtDate)
+}
+
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
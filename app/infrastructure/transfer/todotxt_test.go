@@ -0,0 +1,168 @@
+package transfer
+
+import (
+	"bytes"
+	"codecademy-yellowbelt2/core/domain/entity"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(value string) time.Time {
+	parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+func TestTodoTxtFormat_DecodeMapsFields(t *testing.T) {
+	// Arrange
+	input := "(A) 2024-03-01 Ligar para a mãe +familia @telefone due:2024-03-10\n" +
+		"x 2024-03-05 2024-03-02 Pagar aluguel +casa pri:B id:a1\n" +
+		"Comprar pão\n"
+
+	// Act
+	decoded, err := NewTodoTxtFormat().Decode(strings.NewReader(input))
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, decoded.Todos, 3) {
+		return
+	}
+	assert.Empty(t, decoded.Skipped)
+	assert.Empty(t, decoded.Unmapped)
+
+	call := decoded.Todos[0]
+	assert.Equal(t, "Ligar para a mãe", call.Title)
+	assert.Equal(t, "A", call.Priority)
+	assert.Equal(t, []string{"familia"}, call.Projects)
+	assert.Equal(t, []string{"telefone"}, call.Tags)
+	assert.True(t, call.CreatedAt.Equal(date("2024-03-01")))
+	assert.True(t, call.DueDate.Equal(date("2024-03-10")))
+	assert.False(t, call.Completed)
+	assert.NotEmpty(t, call.ID, "Expected a generated ID")
+
+	rent := decoded.Todos[1]
+	assert.Equal(t, "a1", rent.ID)
+	assert.True(t, rent.Completed)
+	assert.True(t, rent.CompletedAt.Equal(date("2024-03-05")))
+	assert.True(t, rent.CreatedAt.Equal(date("2024-03-02")))
+	assert.Equal(t, "B", rent.Priority)
+
+	bread := decoded.Todos[2]
+	assert.Equal(t, "Comprar pão", bread.Title)
+	assert.Nil(t, bread.DueDate)
+	assert.False(t, bread.CreatedAt.IsZero())
+}
+
+func TestTodoTxtFormat_DecodeReportsUnmappedAndSkippedLines(t *testing.T) {
+	// Arrange
+	input := "Regar as plantas rec:1w due:amanhã\n" +
+		"\n" +
+		"(B) +projeto @contexto\n" +
+		"Reunião às 10:30 em https://meet.example.com\n"
+
+	// Act
+	decoded, err := NewTodoTxtFormat().Decode(strings.NewReader(input))
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, decoded.Todos, 2) {
+		return
+	}
+	assert.Equal(t, "Regar as plantas rec:1w due:amanhã", decoded.Todos[0].Title, "Expected unmapped keys to stay in the title")
+	assert.Equal(t, "Reunião às 10:30 em https://meet.example.com", decoded.Todos[1].Title)
+
+	if !assert.Len(t, decoded.Unmapped, 2) {
+		return
+	}
+	assert.Equal(t, 1, decoded.Unmapped[0].Line)
+	assert.Equal(t, "rec:1w", decoded.Unmapped[0].Text)
+	assert.Equal(t, "due:amanhã", decoded.Unmapped[1].Text)
+
+	if !assert.Len(t, decoded.Skipped, 1) {
+		return
+	}
+	assert.Equal(t, 3, decoded.Skipped[0].Line)
+	assert.Equal(t, "(B) +projeto @contexto", decoded.Skipped[0].Text)
+}
+
+func TestTodoTxtFormat_RoundTripLines(t *testing.T) {
+	lines := []string{
+		"2024-01-10 Escrever relatório id:r1",
+		"(A) 2024-01-10 Ligar para o banco +financas @telefone due:2024-01-15 id:b2",
+		"x 2024-01-12 2024-01-10 Lavar o carro @rua id:c3",
+		"x 2024-01-12 2024-01-11 Revisar PR +trabalho pri:C id:d4",
+		"2024-01-10 Estudar Go +curso +golang @casa @noite id:e5",
+		"2024-01-10 Pagar condomínio desc:Boleto+de+mar%C3%A7o%2C+vence+dia+10 assignee:ana assignee:bruno+lima id:f6",
+	}
+
+	for _, line := range lines {
+		t.Run(line, func(t *testing.T) {
+			// Arrange
+			format := NewTodoTxtFormat()
+			decoded, err := format.Decode(strings.NewReader(line + "\n"))
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			// Act
+			var out bytes.Buffer
+			err = format.Encode(&out, decoded.Todos)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, line+"\n", out.String())
+		})
+	}
+}
+
+func TestTodoTxtFormat_RoundTripTodos(t *testing.T) {
+	// Arrange
+	completedAt := date("2024-02-03")
+	dueDate := date("2024-02-20")
+	todos := []*entity.Todo{
+		{ID: "a1", Title: "Comprar   pão", CreatedAt: date("2024-02-01"), Priority: "B", Projects: []string{"casa"}, Tags: []string{"mercado"}, DueDate: &dueDate},
+		{ID: "b2", Title: "Enviar proposta", CreatedAt: date("2024-02-01"), Completed: true, CompletedAt: &completedAt, Priority: "A"},
+		{ID: "c3", Title: "Renovar passaporte", CreatedAt: date("2024-02-01"), Description: "Levar RG\ne 100% das fotos: 3x4 / coloridas", Assignees: []string{"ana", "bruno lima"}},
+	}
+	format := NewTodoTxtFormat()
+
+	// Act
+	var out bytes.Buffer
+	encodeErr := format.Encode(&out, todos)
+	decoded, decodeErr := format.Decode(&out)
+
+	// Assert
+	if !assert.NoError(t, encodeErr) {
+		return
+	}
+	if !assert.NoError(t, decodeErr) {
+		return
+	}
+	if !assert.Len(t, decoded.Todos, len(todos)) {
+		return
+	}
+	assert.Empty(t, decoded.Unmapped)
+	for i, want := range todos {
+		got := decoded.Todos[i]
+		assert.Equal(t, want.ID, got.ID)
+		assert.Equal(t, strings.Join(strings.Fields(want.Title), " "), got.Title)
+		assert.Equal(t, want.Completed, got.Completed)
+		assert.Equal(t, want.Priority, got.Priority)
+		assert.Equal(t, want.Projects, got.Projects)
+		assert.Equal(t, want.Tags, got.Tags)
+		assert.Equal(t, want.Description, got.Description)
+		assert.Equal(t, want.Assignees, got.Assignees)
+		assert.Equal(t, want.DueDate, got.DueDate)
+		assert.Equal(t, want.CompletedAt, got.CompletedAt)
+		assert.True(t, want.CreatedAt.Equal(got.CreatedAt))
+	}
+}
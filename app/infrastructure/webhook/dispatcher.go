@@ -0,0 +1,206 @@
+package webhook
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	event_interfaces "codecademy-yellowbelt2/infrastructure/interface/event"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultMaxAttempts = 8
+	DefaultBaseDelay   = 30 * time.Second
+	DefaultMaxDelay    = time.Hour
+	// DefaultFlushTimeout é quanto um Flush pode gastar enviando; o que
+	// sobrar continua na fila para a próxima vez
+	DefaultFlushTimeout = 5 * time.Second
+)
+
+// Dispatcher assina o barramento de eventos e enfileira uma entrega para cada
+// webhook interessado. As entregas ficam em uma fila persistente até serem
+// confirmadas e são enviadas por Run, em segundo plano, ou por Flush, com
+// novas tentativas em intervalos exponenciais
+type Dispatcher struct {
+	webhooks     repository.IWebhookRepository
+	queue        repository.IWebhookQueueRepository
+	sender       event_interfaces.IWebhookSender
+	mutex        sync.Mutex
+	wake         chan struct{}
+	maxAttempts  int
+	baseDelay    time.Duration
+	maxDelay     time.Duration
+	flushTimeout time.Duration
+	now          func() time.Time
+}
+
+var _ event_interfaces.ISubscriber = (*Dispatcher)(nil)
+
+type DispatcherOption func(*Dispatcher)
+
+// WithRetryPolicy define quantas tentativas uma entrega recebe e os limites
+// do intervalo entre elas, que dobra a cada falha
+func WithRetryPolicy(maxAttempts int, baseDelay, maxDelay time.Duration) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.maxAttempts = maxAttempts
+		d.baseDelay = baseDelay
+		d.maxDelay = maxDelay
+	}
+}
+
+// WithFlushTimeout define quanto tempo cada Flush pode gastar enviando
+func WithFlushTimeout(timeout time.Duration) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.flushTimeout = timeout
+	}
+}
+
+func NewDispatcher(webhooks repository.IWebhookRepository, queue repository.IWebhookQueueRepository, sender event_interfaces.IWebhookSender, opts ...DispatcherOption) *Dispatcher {
+	d := &Dispatcher{
+		webhooks:     webhooks,
+		queue:        queue,
+		sender:       sender,
+		wake:         make(chan struct{}, 1),
+		maxAttempts:  DefaultMaxAttempts,
+		baseDelay:    DefaultBaseDelay,
+		maxDelay:     DefaultMaxDelay,
+		flushTimeout: DefaultFlushTimeout,
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Handle enfileira uma entrega para cada webhook interessado no evento e
+// avisa Run; nenhum envio é feito aqui, para que um destino fora do ar não
+// atrase quem publicou o evento
+func (d *Dispatcher) Handle(event *entity.Event) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	webhooks, err := d.webhooks.GetAll()
+	if err != nil {
+		return err
+	}
+	deliveries, err := d.queue.Load()
+	if err != nil {
+		return err
+	}
+
+	queued := false
+	for _, webhook := range webhooks {
+		if !webhook.Accepts(event.Type) {
+			continue
+		}
+		deliveryID := uuid.New().String()
+		payload, err := NewPayload(deliveryID, event)
+		if err != nil {
+			return err
+		}
+		delivery := entity.NewWebhookDelivery(deliveryID, webhook.ID, event.Type, payload)
+		delivery.NextAttemptAt = d.now()
+		deliveries = append(deliveries, delivery)
+		queued = true
+	}
+	if !queued {
+		return nil
+	}
+
+	// A fila é gravada antes do envio para que nada se perca se o processo
+	// terminar no meio das tentativas
+	if err := d.queue.Save(deliveries); err != nil {
+		return err
+	}
+	select {
+	case d.wake <- struct{}{}:
+	default: // Um envio já está agendado e vai incluir esta entrega
+	}
+	return nil
+}
+
+// Run envia em segundo plano as entregas enfileiradas por Handle até ctx
+// ser cancelado; falhas ficam na fila para o próximo Flush
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+			if err := d.Flush(); err != nil {
+				log.Printf("webhook flush failed: %v", err)
+			}
+		}
+	}
+}
+
+// Flush envia as entregas cujo próximo horário já chegou, parando de enviar
+// quando o tempo do flush acaba
+func (d *Dispatcher) Flush() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	deliveries, err := d.queue.Load()
+	if err != nil || len(deliveries) == 0 {
+		return err
+	}
+	webhooks, err := d.webhooks.GetAll()
+	if err != nil {
+		return err
+	}
+	return d.flush(webhooks, deliveries)
+}
+
+func (d *Dispatcher) flush(webhooks []*entity.Webhook, deliveries []*entity.WebhookDelivery) error {
+	byID := make(map[string]*entity.Webhook, len(webhooks))
+	for _, webhook := range webhooks {
+		byID[webhook.ID] = webhook
+	}
+
+	now := d.now()
+	deadline := time.Now().Add(d.flushTimeout)
+	remaining := make([]*entity.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		webhook, exists := byID[delivery.WebhookID]
+		if !exists {
+			continue // Webhook removido: a entrega é descartada
+		}
+		if !delivery.IsDue(now) || time.Now().After(deadline) {
+			remaining = append(remaining, delivery)
+			continue
+		}
+
+		err := d.sender.Post(webhook, delivery)
+		if err == nil {
+			continue
+		}
+
+		delivery.Attempts++
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= d.maxAttempts {
+			log.Printf("webhook delivery %s to %s dropped after %d attempts: %v", delivery.ID, webhook.URL, delivery.Attempts, err)
+			continue
+		}
+		delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+		remaining = append(remaining, delivery)
+	}
+
+	return d.queue.Save(remaining)
+}
+
+// backoff dobra o intervalo a cada falha: base, 2×base, 4×base... até maxDelay
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.baseDelay
+	for i := 1; i < attempts && delay < d.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > d.maxDelay {
+		return d.maxDelay
+	}
+	return delay
+}
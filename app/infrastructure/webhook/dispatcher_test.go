@@ -0,0 +1,263 @@
+package webhook
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	event_interfaces "codecademy-yellowbelt2/infrastructure/interface/event"
+	"codecademy-yellowbelt2/infrastructure/interface/repository"
+	infraRepo "codecademy-yellowbelt2/infrastructure/repository"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type receivedRequest struct {
+	body      []byte
+	signature string
+	event     string
+	delivery  string
+}
+
+// receiver simula o destino de um webhook, respondendo com os status da
+// lista em ordem (200 quando a lista acaba)
+type receiver struct {
+	mutex    sync.Mutex
+	statuses []int
+	requests []receivedRequest
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.requests = append(r.requests, receivedRequest{
+		body:      body,
+		signature: req.Header.Get(SignatureHeader),
+		event:     req.Header.Get(EventHeader),
+		delivery:  req.Header.Get(DeliveryHeader),
+	})
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func newTestDispatcher(t *testing.T, url string, events []string, opts ...DispatcherOption) (*Dispatcher, repository.IWebhookQueueRepository) {
+	webhooks := infraRepo.NewInMemoryWebhookRepository()
+	webhooks.Create(entity.NewWebhook(url, events, "s3cr3t"))
+	queue := infraRepo.NewInMemoryWebhookQueueRepository()
+	return NewDispatcher(webhooks, queue, NewHTTPSender(time.Second), opts...), queue
+}
+
+func TestShouldPostSignedPayloadToWebhook(t *testing.T) {
+	// Arrange
+	target := &receiver{}
+	server := httptest.NewServer(target)
+	defer server.Close()
+	dispatcher, queue := newTestDispatcher(t, server.URL, nil)
+	event := entity.NewEvent(entity.EventTodoCompleted, "1", &entity.Todo{ID: "1", Title: "Ship it", Completed: true})
+	event.Actor = "alice"
+
+	// Act
+	handleErr := dispatcher.Handle(event)
+	err := dispatcher.Flush()
+	pending, _ := queue.Load()
+
+	// Assert
+	assert.NoError(t, handleErr)
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+	assert.Len(t, target.requests, 1)
+	request := target.requests[0]
+	assert.Equal(t, entity.EventTodoCompleted, request.event)
+	assert.True(t, VerifySignature("s3cr3t", request.body, request.signature), "Expected a valid HMAC-SHA256 signature")
+	var payload Payload
+	assert.NoError(t, json.Unmarshal(request.body, &payload))
+	assert.Equal(t, request.delivery, payload.DeliveryID)
+	assert.Equal(t, "alice", payload.Actor)
+	assert.Equal(t, "Ship it", payload.Todo.Title)
+}
+
+func TestShouldSkipWebhooksNotInterestedInEvent(t *testing.T) {
+	// Arrange
+	target := &receiver{}
+	server := httptest.NewServer(target)
+	defer server.Close()
+	dispatcher, _ := newTestDispatcher(t, server.URL, []string{entity.EventTodoCompleted})
+
+	// Act
+	err := dispatcher.Handle(entity.NewEvent(entity.EventTodoCreated, "1", &entity.Todo{ID: "1"}))
+	dispatcher.Flush()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, target.requests)
+}
+
+func TestShouldRetryFailedDeliveriesWithExponentialBackoff(t *testing.T) {
+	// Arrange
+	target := &receiver{statuses: []int{http.StatusInternalServerError, http.StatusBadGateway}}
+	server := httptest.NewServer(target)
+	defer server.Close()
+	dispatcher, queue := newTestDispatcher(t, server.URL, nil, WithRetryPolicy(5, time.Minute, time.Hour))
+	now := time.Now()
+	dispatcher.now = func() time.Time { return now }
+
+	// Act
+	handleErr := dispatcher.Handle(entity.NewEvent(entity.EventTodoCreated, "1", &entity.Todo{ID: "1"}))
+	dispatcher.Flush()
+	afterFirst, _ := queue.Load()
+	dispatcher.Flush() // Ainda não venceu: nada é enviado
+	now = now.Add(time.Minute)
+	retriedAt := now
+	dispatcher.Flush()
+	afterSecond, _ := queue.Load()
+	now = now.Add(2 * time.Minute)
+	dispatcher.Flush()
+	afterThird, _ := queue.Load()
+
+	// Assert
+	assert.NoError(t, handleErr, "Expected delivery failures to stay in the queue")
+	assert.Len(t, afterFirst, 1)
+	assert.Equal(t, 1, afterFirst[0].Attempts)
+	assert.Equal(t, "webhook responded with status 500", afterFirst[0].LastError)
+	assert.Len(t, afterSecond, 1)
+	assert.Equal(t, 2, afterSecond[0].Attempts)
+	assert.True(t, afterSecond[0].NextAttemptAt.Equal(retriedAt.Add(2*time.Minute)), "Expected the delay to double")
+	assert.Empty(t, afterThird)
+	assert.Len(t, target.requests, 3)
+	assert.Equal(t, target.requests[0].body, target.requests[2].body, "Expected retries to resend the same payload")
+}
+
+func TestShouldDropDeliveryAfterMaxAttempts(t *testing.T) {
+	// Arrange
+	target := &receiver{statuses: []int{http.StatusInternalServerError, http.StatusInternalServerError}}
+	server := httptest.NewServer(target)
+	defer server.Close()
+	dispatcher, queue := newTestDispatcher(t, server.URL, nil, WithRetryPolicy(2, time.Minute, time.Hour))
+	now := time.Now()
+	dispatcher.now = func() time.Time { return now }
+
+	// Act
+	dispatcher.Handle(entity.NewEvent(entity.EventTodoCreated, "1", &entity.Todo{ID: "1"}))
+	dispatcher.Flush()
+	now = now.Add(time.Hour)
+	dispatcher.Flush()
+	pending, _ := queue.Load()
+
+	// Assert
+	assert.Empty(t, pending)
+	assert.Len(t, target.requests, 2)
+}
+
+func TestShouldKeepQueueAcrossDispatchers(t *testing.T) {
+	// Arrange
+	target := &receiver{statuses: []int{http.StatusServiceUnavailable}}
+	server := httptest.NewServer(target)
+	defer server.Close()
+	dir := t.TempDir()
+	webhooks := infraRepo.NewFileWebhookRepository(filepath.Join(dir, "webhooks.json"))
+	webhooks.Create(entity.NewWebhook(server.URL, nil, ""))
+	queueFile := filepath.Join(dir, "webhooks.queue.json")
+	first := NewDispatcher(webhooks, infraRepo.NewFileWebhookQueueRepository(queueFile), NewHTTPSender(time.Second), WithRetryPolicy(3, time.Nanosecond, time.Nanosecond))
+	first.Handle(entity.NewEvent(entity.EventTodoDeleted, "1", nil))
+	first.Flush()
+
+	// Act
+	second := NewDispatcher(webhooks, infraRepo.NewFileWebhookQueueRepository(queueFile), NewHTTPSender(time.Second))
+	err := second.Flush()
+	pending, _ := infraRepo.NewFileWebhookQueueRepository(queueFile).Load()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+	assert.Len(t, target.requests, 2)
+	assert.Empty(t, target.requests[1].signature, "Expected no signature without secret")
+}
+
+func TestShouldOnlyQueueDeliveriesWhenHandlingEvents(t *testing.T) {
+	// Arrange
+	webhooks := infraRepo.NewInMemoryWebhookRepository()
+	webhooks.Create(entity.NewWebhook("http://unreachable.invalid", nil, ""))
+	queue := infraRepo.NewInMemoryWebhookQueueRepository()
+	sender := new(event_interfaces.MockWebhookSender)
+	dispatcher := NewDispatcher(webhooks, queue, sender)
+
+	// Act
+	err := dispatcher.Handle(entity.NewEvent(entity.EventTodoCreated, "1", &entity.Todo{ID: "1"}))
+	pending, _ := queue.Load()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	sender.AssertNotCalled(t, "Post", mock.Anything, mock.Anything)
+}
+
+func TestShouldSendQueuedDeliveriesInBackground(t *testing.T) {
+	// Arrange
+	target := &receiver{}
+	server := httptest.NewServer(target)
+	defer server.Close()
+	dispatcher, queue := newTestDispatcher(t, server.URL, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go dispatcher.Run(ctx)
+
+	// Act
+	err := dispatcher.Handle(entity.NewEvent(entity.EventTodoCreated, "1", &entity.Todo{ID: "1"}))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		pending, _ := queue.Load()
+		return len(pending) == 0
+	}, time.Second, 5*time.Millisecond)
+	target.mutex.Lock()
+	defer target.mutex.Unlock()
+	assert.Len(t, target.requests, 1)
+}
+
+func TestShouldStopSendingWhenFlushTimeoutExpires(t *testing.T) {
+	// Arrange
+	webhooks := infraRepo.NewInMemoryWebhookRepository()
+	webhooks.Create(entity.NewWebhook("http://slow.invalid/a", nil, ""))
+	webhooks.Create(entity.NewWebhook("http://slow.invalid/b", nil, ""))
+	queue := infraRepo.NewInMemoryWebhookQueueRepository()
+	sender := new(event_interfaces.MockWebhookSender)
+	sender.On("Post", mock.Anything, mock.Anything).After(20 * time.Millisecond).Return(nil)
+	dispatcher := NewDispatcher(webhooks, queue, sender, WithFlushTimeout(10*time.Millisecond))
+	dispatcher.Handle(entity.NewEvent(entity.EventTodoCreated, "1", &entity.Todo{ID: "1"}))
+
+	// Act
+	err := dispatcher.Flush()
+	pending, _ := queue.Load()
+
+	// Assert
+	assert.NoError(t, err)
+	sender.AssertNumberOfCalls(t, "Post", 1)
+	if !assert.Len(t, pending, 1) {
+		return
+	}
+	assert.Zero(t, pending[0].Attempts, "Expected the skipped delivery not to count as an attempt")
+}
+
+func TestShouldCapBackoffAtMaxDelay(t *testing.T) {
+	// Arrange
+	dispatcher := NewDispatcher(nil, nil, nil, WithRetryPolicy(10, time.Minute, 10*time.Minute))
+
+	// Act & Assert
+	assert.Equal(t, time.Minute, dispatcher.backoff(1))
+	assert.Equal(t, 2*time.Minute, dispatcher.backoff(2))
+	assert.Equal(t, 8*time.Minute, dispatcher.backoff(4))
+	assert.Equal(t, 10*time.Minute, dispatcher.backoff(9))
+}
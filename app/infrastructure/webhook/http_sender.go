@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"bytes"
+	"codecademy-yellowbelt2/core/domain/entity"
+	event_interfaces "codecademy-yellowbelt2/infrastructure/interface/event"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultTimeout = 5 * time.Second
+	// DefaultDeliveryTimeout limita cada envio da fila, que roda ao fim dos
+	// comandos e não deve segurá-los por muito tempo
+	DefaultDeliveryTimeout = 2 * time.Second
+)
+
+// HTTPSender faz o POST dos eventos; qualquer resposta 2xx confirma a entrega
+type HTTPSender struct {
+	client *http.Client
+}
+
+var _ event_interfaces.IWebhookSender = (*HTTPSender)(nil)
+
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return &HTTPSender{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *HTTPSender) Send(webhook *entity.Webhook, event *entity.Event) error {
+	deliveryID := uuid.New().String()
+	payload, err := NewPayload(deliveryID, event)
+	if err != nil {
+		return err
+	}
+	return s.Post(webhook, entity.NewWebhookDelivery(deliveryID, webhook.ID, event.Type, payload))
+}
+
+// Post envia uma entrega já montada, usada também nas novas tentativas
+func (s *HTTPSender) Post(webhook *entity.Webhook, delivery *entity.WebhookDelivery) error {
+	request, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "todo-cli-webhook")
+	request.Header.Set(EventHeader, delivery.EventType)
+	request.Header.Set(DeliveryHeader, delivery.ID)
+	if webhook.Secret != "" {
+		request.Header.Set(SignatureHeader, Sign(webhook.Secret, delivery.Payload))
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", response.StatusCode)
+	}
+	return nil
+}
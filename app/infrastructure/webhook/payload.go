@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"codecademy-yellowbelt2/core/domain/entity"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Todo-Signature"
+	EventHeader     = "X-Todo-Event"
+	DeliveryHeader  = "X-Todo-Delivery"
+)
+
+// Payload é o corpo JSON enviado aos webhooks
+type Payload struct {
+	DeliveryID string       `json:"delivery_id"`
+	Event      string       `json:"event"`
+	Timestamp  time.Time    `json:"timestamp"`
+	Actor      string       `json:"actor,omitempty"`
+	TodoID     string       `json:"todo_id"`
+	Todo       *entity.Todo `json:"todo,omitempty"`
+	Previous   *entity.Todo `json:"previous,omitempty"`
+}
+
+func NewPayload(deliveryID string, event *entity.Event) ([]byte, error) {
+	return json.Marshal(Payload{
+		DeliveryID: deliveryID,
+		Event:      event.Type,
+		Timestamp:  event.Timestamp,
+		Actor:      event.Actor,
+		TodoID:     event.TodoID,
+		Todo:       event.Todo,
+		Previous:   event.Previous,
+	})
+}
+
+// Sign calcula o valor do cabeçalho X-Todo-Signature: "sha256=" seguido do
+// HMAC-SHA256 do corpo em hexadecimal
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature confere a assinatura recebida em tempo constante
+func VerifySignature(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
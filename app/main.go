@@ -2,40 +2,163 @@ package main
 
 import (
 	"codecademy-yellowbelt2/core/application"
+	"codecademy-yellowbelt2/infrastructure/config"
+	"codecademy-yellowbelt2/infrastructure/event"
+	"codecademy-yellowbelt2/infrastructure/hook"
 	"codecademy-yellowbelt2/infrastructure/interface/cli"
 	"codecademy-yellowbelt2/infrastructure/interface/repository"
 	fileRepo "codecademy-yellowbelt2/infrastructure/repository"
+	"codecademy-yellowbelt2/infrastructure/transfer"
+	"codecademy-yellowbelt2/infrastructure/webhook"
+	"context"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+
+	"github.com/spf13/cobra"
 )
 
 func main() {
-	// Definir arquivo de dados
-	homeDir, err := os.UserHomeDir()
+	// Configuração: arquivo (com perfis), variáveis TODO_* e flags globais
+	settings, err := config.Load(os.Args[1:])
 	if err != nil {
-		log.Fatal("Erro ao obter diretório home:", err)
+		log.Fatal("Erro na configuração: ", err)
 	}
 
-	dataFile := filepath.Join(homeDir, ".todo-cli", "todos.json")
+	// Cada contexto guarda as tarefas no próprio diretório; o padrão usa o
+	// diretório de dados, como antes dos contextos existirem
+	contextRepo := fileRepo.NewFileContextRepository(settings.DataDir)
+	currentContext, err := contextRepo.Current()
+	if err != nil {
+		log.Fatal("Erro ao ler o contexto atual: ", err)
+	}
+
+	// Definir arquivo de dados
+	dataFile := filepath.Join(fileRepo.ContextDir(settings.DataDir, currentContext), "todos.json")
 
 	// Criar diretório se não existir
 	if err := os.MkdirAll(filepath.Dir(dataFile), 0755); err != nil {
 		log.Fatal("Erro ao criar diretório de dados:", err)
 	}
 
-	// Inicializar repository com arquivo JSON
-	var todoRepo repository.ITodoRepository = fileRepo.NewFileTodoRepository(dataFile)
+	// Inicializar repository com arquivo JSON ou com o log de eventos
+	todoRepo, err := openTodoRepository(settings.Storage, filepath.Dir(dataFile))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Histórico de alterações fica em um arquivo próprio para sobreviver à remoção das tarefas
+	historyFile := filepath.Join(filepath.Dir(dataFile), "history.jsonl")
+	var historyRepo repository.IHistoryRepository = fileRepo.NewFileHistoryRepository(historyFile)
+
+	// Tarefas arquivadas ficam em um arquivo separado para não pesar no carregamento
+	archiveFile := filepath.Join(filepath.Dir(dataFile), "todos.archive.json")
+	var archiveRepo repository.ITodoRepository = fileRepo.NewFileTodoRepository(archiveFile)
+
+	// Diário de operações para undo/redo entre execuções
+	journalFile := filepath.Join(filepath.Dir(dataFile), "journal.json")
+	var journalRepo repository.IJournalRepository = fileRepo.NewFileJournalRepository(journalFile)
+
+	currentUser := settings.User
+
+	// Barramento de eventos para reagir às alterações sem mexer no use case
+	eventBus := event.NewEventBus()
+
+	// Webhooks recebem os eventos por uma fila persistente, enviada em segundo
+	// plano e ao fim de cada execução
+	// (webhooks, hooks e o histórico do shell valem para todos os contextos)
+	webhookRepo := fileRepo.NewFileWebhookRepository(filepath.Join(settings.DataDir, "webhooks.json"))
+	webhookQueue := fileRepo.NewFileWebhookQueueRepository(filepath.Join(settings.DataDir, "webhooks.queue.json"))
+	webhookSender := webhook.NewHTTPSender(webhook.DefaultTimeout)
+	dispatcher := webhook.NewDispatcher(webhookRepo, webhookQueue, webhook.NewHTTPSender(webhook.DefaultDeliveryTimeout))
+	eventBus.Subscribe("webhooks", dispatcher, event.Async(0))
+	deliveries, stopDeliveries := context.WithCancel(context.Background())
+	go dispatcher.Run(deliveries)
 
 	// Inicializar use case
-	todoUseCase := application.NewTodoUseCase(todoRepo)
+	todoUseCase := application.NewTodoUseCase(
+		todoRepo,
+		application.WithHistoryRepository(historyRepo),
+		application.WithJournal(journalRepo, application.DefaultJournalSize),
+		application.WithArchiveRepository(archiveRepo),
+		application.WithEventPublisher(eventBus),
+		application.WithActor(currentUser),
+	)
+
+	// Hooks do usuário envolvem as operações, no estilo dos hooks do git
+	hooksDir := filepath.Join(settings.DataDir, "hooks")
+	todoUseCase = application.NewHookedTodoUseCase(todoUseCase, hook.NewScriptRunner(hooksDir, settings.HookTimeout))
+
+	// Backups completos dos dados
+	backupUseCase := application.NewBackupUseCase(fileRepo.NewFileBackupRepository(settings.DataDir, settings.ConfigFile))
+
+	// Mover tarefas entre contextos abre os repositórios do contexto de destino
+	contextUseCase := application.NewContextUseCase(contextRepo, func(name string) (*application.ContextStore, error) {
+		dir := fileRepo.ContextDir(settings.DataDir, name)
+		todos, err := openTodoRepository(settings.Storage, dir)
+		if err != nil {
+			return nil, err
+		}
+		return &application.ContextStore{
+			Todos:   todos,
+			History: fileRepo.NewFileHistoryRepository(filepath.Join(dir, "history.jsonl")),
+		}, nil
+	}, application.WithMoveActor(currentUser))
 
 	// Inicializar CLI
-	todoCLI := cli.NewTodoCLI(todoUseCase)
+	todoCLI := cli.NewTodoCLI(
+		todoUseCase,
+		cli.WithCurrentUser(currentUser),
+		cli.WithWebhooks(application.NewWebhookUseCase(webhookRepo, webhookSender)),
+		cli.WithContexts(contextUseCase),
+		cli.WithBackups(backupUseCase),
+		cli.WithFormat("todotxt", transfer.NewTodoTxtFormat()),
+		cli.WithFormat("csv", transfer.NewCSVFormat()),
+		cli.WithFormat("ics", transfer.NewICSFormat()),
+		cli.WithFormat("markdown", transfer.NewMarkdownFormat()),
+		cli.WithFormat("taskwarrior", transfer.NewTaskwarriorFormat()),
+		cli.WithShellHistory(filepath.Join(settings.DataDir, "shell_history")),
+		cli.WithOutputFormat(settings.Output),
+		cli.WithDateFormat(settings.DateFormat),
+		// Backup automático antes dos comandos que alteram tarefas, quando o último for mais antigo que o configurado
+		cli.WithAutoBackup(settings.AutoBackupInterval(), settings.BackupKeep),
+		// Arquivar automaticamente, antes dos comandos que alteram tarefas, as concluídas há mais tempo que o configurado
+		cli.WithAutoArchive(settings.AutoArchiveAfter()),
+	)
 
-	// Executar comando raiz
+	// Executar comando raiz; as flags de configuração já foram aplicadas acima
 	rootCmd := todoCLI.GetRootCommand()
-	if err := rootCmd.Execute(); err != nil {
+	rootCmd.PersistentFlags().AddFlagSet(config.Flags())
+	rootCmd.RegisterFlagCompletionFunc("profile", cobra.FixedCompletions(settings.ProfileNames, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.RegisterFlagCompletionFunc("storage", cobra.FixedCompletions(config.StorageBackends, cobra.ShellCompDirectiveNoFileComp))
+	err = rootCmd.Execute()
+
+	// Aguardar os assinantes assíncronos antes de encerrar
+	eventBus.Close()
+	stopDeliveries()
+
+	// Enviar as entregas desta execução e as pendentes de execuções anteriores
+	// que já venceram; só depois de comandos que alteram tarefas, para que
+	// consultas, a ajuda e a completação do shell não esperem pela rede
+	if todoCLI.Mutated() {
+		if flushErr := dispatcher.Flush(); flushErr != nil {
+			log.Println("Erro ao reenviar webhooks pendentes:", flushErr)
+		}
+	}
+	if err != nil {
 		log.Fatal(err)
 	}
 }
+
+// openTodoRepository abre as tarefas de um diretório no armazenamento configurado
+func openTodoRepository(storage, dir string) (repository.ITodoRepository, error) {
+	switch storage {
+	case config.StorageFile:
+		return fileRepo.NewFileTodoRepository(filepath.Join(dir, "todos.json")), nil
+	case config.StorageEvents:
+		return fileRepo.NewEventSourcedTodoRepository(filepath.Join(dir, "todos.events.jsonl")), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", storage)
+	}
+}